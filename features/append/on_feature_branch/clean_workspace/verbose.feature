@@ -22,6 +22,7 @@ Feature: display all executed Git commands
       |          | backend  | git branch -vva --sort=refname                       |
       |          | backend  | git rev-parse --verify --abbrev-ref @{-1}            |
       | existing | frontend | git checkout main                                    |
+      |          | backend  | git config --get rerere.enabled                      |
       | main     | frontend | git rebase origin/main                               |
       |          | backend  | git rev-list --left-right main...origin/main         |
       | main     | frontend | git checkout existing                                |
@@ -39,7 +40,7 @@ Feature: display all executed Git commands
       |          | backend  | git stash list                                       |
     And it prints:
       """
-      Ran 27 shell commands.
+      Ran 28 shell commands.
       """
     And the current branch is now "new"
 
@@ -0,0 +1,41 @@
+Feature: append a new feature branch in a new worktree
+
+  Background:
+    Given the current branch is a feature branch "existing"
+    And the commits
+      | BRANCH   | LOCATION      | MESSAGE         |
+      | existing | local, origin | existing commit |
+    When I run "git-town append new --worktree"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH   | COMMAND                               |
+      | existing | git fetch --prune --tags              |
+      |          | git checkout main                     |
+      | main     | git rebase origin/main                |
+      |          | git checkout existing                 |
+      | existing | git merge --no-edit origin/existing   |
+      |          | git merge --no-edit main              |
+      |          | git branch new existing               |
+      |          | git worktree add ../developer-new new |
+    And the current branch is still "existing"
+    And branch "new" is now checked out in the worktree "developer-new"
+    And the branches are now
+      | REPOSITORY | BRANCHES            |
+      | local      | main, existing, new |
+      | origin     | main, existing      |
+    And this lineage exists now
+      | BRANCH   | PARENT   |
+      | existing | main     |
+      | new      | existing |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH   | COMMAND                              |
+      | existing | git worktree remove ../developer-new |
+      |          | git branch -D new                    |
+    And the current branch is still "existing"
+    And the worktree "developer-new" no longer exists
+    And the initial commits exist
+    And the initial branches and lineage exist
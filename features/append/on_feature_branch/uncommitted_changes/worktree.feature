@@ -0,0 +1,41 @@
+Feature: append a new feature branch in a new worktree at a given location with uncommitted changes
+
+  Background:
+    Given the current branch is a feature branch "existing"
+    And the commits
+      | BRANCH   | LOCATION      | MESSAGE         |
+      | existing | local, origin | existing commit |
+    And an uncommitted file
+    When I run "git-town append new --worktree=../new_worktree"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH   | COMMAND                              |
+      | existing | git branch new existing              |
+      |          | git worktree add ../new_worktree new |
+    And the current branch is still "existing"
+    And the uncommitted file still exists
+    And branch "new" is now checked out in the worktree "new_worktree"
+    And the branches are now
+      | REPOSITORY | BRANCHES            |
+      | local      | main, existing, new |
+      | origin     | main, existing      |
+    And this lineage exists now
+      | BRANCH   | PARENT   |
+      | existing | main     |
+      | new      | existing |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH   | COMMAND                             |
+      | existing | git add -A                          |
+      |          | git stash                           |
+      |          | git worktree remove ../new_worktree |
+      |          | git branch -D new                   |
+      |          | git stash pop                       |
+    And the current branch is still "existing"
+    And the uncommitted file still exists
+    And the worktree "new_worktree" no longer exists
+    And the initial commits exist
+    And the initial branches and lineage exist
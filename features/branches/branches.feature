@@ -0,0 +1,42 @@
+@smoke
+Feature: display the branch hierarchy with the status of each branch
+
+  Background:
+    Given a feature branch "parent"
+    And a feature branch "child" as a child of "parent"
+    And a perennial branch "production"
+    And a local feature branch "local"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE       |
+      | main   | local, origin | main commit   |
+      | parent | local, origin | parent commit |
+      | child  | local         | child commit  |
+    And the current branch is "child"
+
+  Scenario: result
+    When I run "git-town branches"
+    Then it prints:
+      """
+      main (main branch, up to date)
+        local (feature branch, local only, 0 ahead, 1 behind main)
+        parent (feature branch, up to date, 1 ahead, 1 behind main)
+          child (feature branch, not in sync, 1 ahead, 1 behind parent, 1 ahead, 0 behind origin/child)
+
+      production (perennial branch, up to date)
+      """
+    And the current branch is still "child"
+
+  Scenario: offline mode
+    Given offline mode is enabled
+    When I run "git-town branches"
+    Then it prints:
+      """
+      main (main branch, up to date)
+      """
+
+  Scenario: the "--offline" flag
+    When I run "git-town branches --offline"
+    Then it prints:
+      """
+      main (main branch, up to date)
+      """
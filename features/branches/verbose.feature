@@ -0,0 +1,25 @@
+Feature: display all executed Git commands
+
+  Background:
+    Given a feature branch "feature"
+    And the current branch is "feature"
+
+  Scenario: result
+    When I run "git-town branches --verbose"
+    Then it runs the commands
+      | BRANCH | TYPE    | COMMAND                                                    |
+      |        | backend | git version                                                |
+      |        | backend | git config -lz --global                                    |
+      |        | backend | git config -lz --local                                     |
+      |        | backend | git rev-parse --show-toplevel                              |
+      |        | backend | git status --long --ignore-submodules                      |
+      |        | backend | git stash list                                             |
+      |        | backend | git branch -vva --sort=refname                             |
+      |        | backend | git remote get-url origin                                  |
+      |        | backend | git rev-list --left-right --count main...origin/main       |
+      |        | backend | git rev-list --left-right --count feature...main           |
+      |        | backend | git rev-list --left-right --count feature...origin/feature |
+    And it prints:
+      """
+      Ran 11 shell commands.
+      """
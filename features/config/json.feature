@@ -0,0 +1,84 @@
+Feature: show the configuration as JSON
+
+  Scenario: configured branches and lineage
+    Given the main branch is "main"
+    And the perennial branches are "qa" and "staging"
+    And a parked branch "parked"
+    And a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And branch "alpha" has the sync strategy "rebase"
+    And local Git Town setting "github-token" is "secret-token"
+    When I run "git-town config --format=json"
+    Then it prints:
+      """
+      {
+        "branchTypes": {
+          "alpha": "feature",
+          "beta": "feature",
+          "main": "main",
+          "parked": "parked",
+          "qa": "perennial",
+          "staging": "perennial"
+        },
+        "config": {
+          "aliases": {},
+          "azureDevOpsToken": null,
+          "bitbucketToken": null,
+          "branchSyncStrategies": {
+            "alpha": "rebase"
+          },
+          "contributionBranches": [],
+          "githubToken": "(redacted)",
+          "gitlabToken": null,
+          "gitUserEmail": "email@example.com",
+          "gitUserName": "user",
+          "giteaToken": null,
+          "hostingOriginHostname": "",
+          "hostingPlatform": "",
+          "mainBranch": "main",
+          "observedBranches": [],
+          "offline": false,
+          "parkedBranches": [
+            "parked"
+          ],
+          "perennialBranches": [
+            "qa",
+            "staging"
+          ],
+          "perennialRegex": "",
+          "prototypeBranches": [],
+          "pushHook": true,
+          "pushNewBranches": false,
+          "shareLineage": false,
+          "shipDeleteTrackingBranch": true,
+          "syncBeforeShip": false,
+          "syncFeatureStrategy": "merge",
+          "syncPerennialStrategy": "rebase",
+          "syncUpstream": true,
+          "worktreePath": "../{{repo}}-{{branch}}"
+        },
+        "lineage": [
+          {
+            "branch": "alpha",
+            "parent": "main"
+          },
+          {
+            "branch": "beta",
+            "parent": "alpha"
+          },
+          {
+            "branch": "parked",
+            "parent": "main"
+          }
+        ],
+        "schemaVersion": 1
+      }
+      """
+    And it does not print "secret-token"
+
+  Scenario: unknown format
+    When I run "git-town config --format=yaml"
+    Then it prints the error:
+      """
+      unknown output format "yaml", supported formats are "text" and "json"
+      """
@@ -0,0 +1,27 @@
+Feature: confirm the repairs in a dialog
+
+  Background:
+    Given a feature branch "existing"
+    And Git Town parent setting for branch "deleted" is "main"
+
+  Scenario: confirm the repairs
+    When I run "git-town config repair" and enter into the dialog:
+      | DIALOG               | KEYS  |
+      | repair configuration | enter |
+    Then it prints:
+      """
+      - branch "deleted" doesn't exist anymore, removing it from the lineage
+      """
+    And this lineage exists now
+      | BRANCH   | PARENT |
+      | existing | main   |
+
+  Scenario: decline the repairs
+    When I run "git-town config repair" and enter into the dialog:
+      | DIALOG               | KEYS       |
+      | repair configuration | down enter |
+    Then it prints:
+      """
+      - branch "deleted" doesn't exist anymore, removing it from the lineage
+      """
+    And the initial lineage exists
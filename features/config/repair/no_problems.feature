@@ -0,0 +1,15 @@
+Feature: no problems in the configuration
+
+  Scenario: consistent configuration
+    Given a feature branch "parent"
+    And a feature branch "child" as a child of "parent"
+    And the current branch is "child"
+    When I run "git-town config repair"
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | child  | git fetch --prune --tags |
+    And it prints:
+      """
+      The Git Town configuration has no problems.
+      """
+    And the initial lineage exists
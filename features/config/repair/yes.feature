@@ -0,0 +1,39 @@
+Feature: repair the configuration without asking
+
+  Background:
+    Given a feature branch "shipped"
+    And a feature branch "child" as a child of "shipped"
+    And Git Town parent setting for branch "deleted" is "main"
+    And branch "deleted" has the sync strategy "rebase"
+    And the perennial branches are "production"
+    And origin ships the "shipped" branch
+    And the current branch is "child"
+    When I run "git-town config repair --yes"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | child  | git fetch --prune --tags |
+    And it prints:
+      """
+      Found these problems in the Git Town configuration:
+      - perennial branch "production" doesn't exist, removing it from the configuration
+      - branch "deleted" with a sync strategy override doesn't exist, removing the override
+      - branch "deleted" doesn't exist anymore, removing it from the lineage
+      - the parent branch "shipped" of "child" was deleted at the remote, making "main" its new parent
+      """
+    And the current branch is still "child"
+    And there are now no perennial branches
+    And branch "deleted" now has no sync strategy
+    And this lineage exists now
+      | BRANCH  | PARENT |
+      | child   | main   |
+      | shipped | main   |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs no commands
+    And the current branch is still "child"
+    And the perennial branches are now "production"
+    And branch "deleted" now has the sync strategy "rebase"
+    And the initial lineage exists
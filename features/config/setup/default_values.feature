@@ -11,6 +11,7 @@ Feature: Accepting all default values leads to a working setup
       | main development branch     | enter |
       | perennial branches          | enter |
       | perennial regex             | enter |
+      | inferred lineage            | enter |
       | hosting platform            | enter |
       | origin hostname             | enter |
       | sync-feature-strategy       | enter |
@@ -26,6 +27,10 @@ Feature: Accepting all default values leads to a working setup
     Then it runs no commands
     And the main branch is still not set
     And there are still no perennial branches
+    And this lineage exists now
+      | BRANCH     | PARENT |
+      | dev        | main   |
+      | production | main   |
     And local Git Town setting "hosting-platform" still doesn't exist
     And local Git Town setting "push-new-branches" is still not set
     And local Git Town setting "push-hook" is still not set
@@ -156,6 +161,7 @@ Feature: Accepting all default values leads to a working setup
     And global Git setting "alias.sync" still doesn't exist
     And the main branch is still ""
     And there are still no perennial branches
+    And no lineage exists now
     And local Git Town setting "hosting-platform" still doesn't exist
     And local Git Town setting "github-token" still doesn't exist
     And local Git Town setting "hosting-origin-hostname" still doesn't exist
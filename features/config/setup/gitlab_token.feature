@@ -34,7 +34,7 @@ Feature: enter the GitLab API token
       | main development branch     | enter             |                                             |
       | perennial branches          |                   | no input here since the dialog doesn't show |
       | perennial regex             | enter             |                                             |
-      | hosting platform            | up enter          |                                             |
+      | hosting platform            | up up enter       |                                             |
       | gitlab token                | 1 2 3 4 5 6 enter |                                             |
       | origin hostname             | enter             |                                             |
       | sync-feature-strategy       | enter             |                                             |
@@ -18,22 +18,27 @@ Feature: show the configuration
         parked branches: parked-1, parked-2
         contribution branches: contribution-1, contribution-2
         observed branches: observed-1, observed-2
+        prototype branches: (none)
 
       Configuration:
         offline: no
         run pre-push hook: yes
         push new branches: no
+        share lineage: no
         ship deletes the tracking branch: yes
         sync-feature strategy: merge
         sync-perennial strategy: rebase
         sync with upstream: yes
         sync before shipping: no
+        worktree path: ../{{repo}}-{{branch}}
 
       Hosting:
         hosting platform override: (not set)
         GitHub token: (not set)
         GitLab token: (not set)
         Gitea token: (not set)
+        Bitbucket token: (not set)
+        Azure DevOps token: (not set)
       """
 
   Scenario: all configured in config file
@@ -66,22 +71,27 @@ Feature: show the configuration
         parked branches: (none)
         contribution branches: (none)
         observed branches: (none)
+        prototype branches: (none)
 
       Configuration:
         offline: no
         run pre-push hook: yes
         push new branches: yes
+        share lineage: no
         ship deletes the tracking branch: yes
         sync-feature strategy: rebase
         sync-perennial strategy: merge
         sync with upstream: yes
         sync before shipping: no
+        worktree path: ../{{repo}}-{{branch}}
 
       Hosting:
         hosting platform override: github
         GitHub token: (not set)
         GitLab token: (not set)
         Gitea token: (not set)
+        Bitbucket token: (not set)
+        Azure DevOps token: (not set)
       """
 
   Scenario: configured in both Git and config file
@@ -125,22 +135,27 @@ Feature: show the configuration
         parked branches: parked-1, parked-2
         contribution branches: contribution-1, contribution-2
         observed branches: observed-1, observed-2
+        prototype branches: (none)
 
       Configuration:
         offline: no
         run pre-push hook: yes
         push new branches: no
+        share lineage: no
         ship deletes the tracking branch: no
         sync-feature strategy: merge
         sync-perennial strategy: merge
         sync with upstream: no
         sync before shipping: no
+        worktree path: ../{{repo}}-{{branch}}
 
       Hosting:
         hosting platform override: github
         GitHub token: (not set)
         GitLab token: (not set)
         Gitea token: (not set)
+        Bitbucket token: (not set)
+        Azure DevOps token: (not set)
       """
 
   Scenario: all configured, with stacked changes
@@ -158,22 +173,27 @@ Feature: show the configuration
         parked branches: (none)
         contribution branches: (none)
         observed branches: (none)
+        prototype branches: (none)
 
       Configuration:
         offline: no
         run pre-push hook: yes
         push new branches: no
+        share lineage: no
         ship deletes the tracking branch: yes
         sync-feature strategy: merge
         sync-perennial strategy: rebase
         sync with upstream: yes
         sync before shipping: no
+        worktree path: ../{{repo}}-{{branch}}
 
       Hosting:
         hosting platform override: (not set)
         GitHub token: (not set)
         GitLab token: (not set)
         Gitea token: (not set)
+        Bitbucket token: (not set)
+        Azure DevOps token: (not set)
 
       Branch Lineage:
         main
@@ -185,6 +205,23 @@ Feature: show the configuration
           hotfix
       """
 
+  Scenario: branches with their own sync strategy
+    Given the feature branches "alpha" and "beta"
+    And branch "alpha" has the sync strategy "rebase"
+    And branch "beta" has the sync strategy "compress"
+    When I run "git-town config"
+    Then it prints:
+      """
+      Branch Sync Strategies:
+        alpha: rebase
+        beta: compress
+
+      Branch Lineage:
+        main
+          alpha
+          beta
+      """
+
   Scenario: no configuration data
     Given Git Town is not configured
     When I run "git-town config"
@@ -197,20 +234,25 @@ Feature: show the configuration
         parked branches: (none)
         contribution branches: (none)
         observed branches: (none)
+        prototype branches: (none)
 
       Configuration:
         offline: no
         run pre-push hook: yes
         push new branches: no
+        share lineage: no
         ship deletes the tracking branch: yes
         sync-feature strategy: merge
         sync-perennial strategy: rebase
         sync with upstream: yes
         sync before shipping: no
+        worktree path: ../{{repo}}-{{branch}}
 
       Hosting:
         hosting platform override: (not set)
         GitHub token: (not set)
         GitLab token: (not set)
         Gitea token: (not set)
+        Bitbucket token: (not set)
+        Azure DevOps token: (not set)
       """
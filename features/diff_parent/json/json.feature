@@ -0,0 +1,18 @@
+Feature: view changes made on the current feature branch as JSON
+
+  Scenario: feature branch with changes
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE        | FILE NAME | FILE CONTENT |
+      | feature | local    | feature commit | file      | content      |
+    When I run "git-town diff-parent --format=json"
+    Then it runs no commands
+    And it prints something like:
+      """
+      {
+        "branch": "feature",
+        "diff": "diff --git a/file b/file.+\+content.+",
+        "parent": "main",
+        "schemaVersion": 1
+      }
+      """
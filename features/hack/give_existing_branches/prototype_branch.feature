@@ -0,0 +1,21 @@
+Feature: promote a prototype branch to a feature branch
+
+  Background:
+    Given the current branch is a local prototype branch "prototype"
+    When I run "git-town hack prototype"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH    | COMMAND                  |
+      | prototype | git fetch --prune --tags |
+    And it prints:
+      """
+      branch "prototype" is now a feature branch
+      """
+    And branch "prototype" is now a feature branch
+    And there are now no prototype branches
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs no commands
+    And branch "prototype" is still a prototype branch
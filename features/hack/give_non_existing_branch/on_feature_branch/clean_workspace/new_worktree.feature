@@ -0,0 +1,56 @@
+Feature: hack a new branch in a new worktree at the configured location
+
+  Background:
+    Given the current branch is a feature branch "existing"
+    And the commits
+      | BRANCH | LOCATION | MESSAGE            |
+      | main   | origin   | origin main commit |
+    And Git Town setting "worktree-path" is "../worktrees/{{branch}}"
+    When I run "git-town hack new --worktree"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH   | COMMAND                               |
+      | existing | git fetch --prune --tags              |
+      |          | git checkout main                     |
+      | main     | git rebase origin/main                |
+      |          | git branch new main                   |
+      |          | git checkout existing                 |
+      | existing | git worktree add ../worktrees/new new |
+    And the current branch is still "existing"
+    And branch "new" is now checked out in the worktree "worktrees/new"
+    And the branches are now
+      | REPOSITORY | BRANCHES            |
+      | local      | main, existing, new |
+      | origin     | main, existing      |
+    And this lineage exists now
+      | BRANCH   | PARENT |
+      | existing | main   |
+      | new      | main   |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH   | COMMAND                                     |
+      | existing | git worktree remove ../worktrees/new        |
+      |          | git checkout main                           |
+      | main     | git reset --hard {{ sha 'initial commit' }} |
+      |          | git branch -D new                           |
+      |          | git checkout existing                       |
+    And the current branch is still "existing"
+    And the worktree "worktrees/new" no longer exists
+    And the initial branches and lineage exist
+
+  Scenario: the worktree location already exists
+    Given I run "git-town undo"
+    And a folder "../worktrees/new"
+    When I run "git-town hack new --worktree"
+    Then it runs the commands
+      | BRANCH   | COMMAND                  |
+      | existing | git fetch --prune --tags |
+    And it prints the error:
+      """
+      worktrees/new because this path already exists
+      """
+    And the current branch is still "existing"
+    And the initial branches and lineage exist
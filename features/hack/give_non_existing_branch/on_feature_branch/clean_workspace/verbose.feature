@@ -21,6 +21,7 @@ Feature: display all executed Git commands
       | main   | frontend | git fetch --prune --tags                      |
       |        | backend  | git branch -vva --sort=refname                |
       |        | backend  | git rev-parse --verify --abbrev-ref @{-1}     |
+      |        | backend  | git config --get rerere.enabled               |
       | main   | frontend | git rebase origin/main                        |
       |        | backend  | git rev-list --left-right main...origin/main  |
       |        | backend  | git show-ref --verify --quiet refs/heads/main |
@@ -34,7 +35,7 @@ Feature: display all executed Git commands
       |        | backend  | git stash list                                |
     And it prints:
       """
-      Ran 22 shell commands.
+      Ran 23 shell commands.
       """
     And the current branch is now "new"
 
@@ -0,0 +1,22 @@
+Feature: delete a branch that has a sync strategy override
+
+  Background:
+    Given the local feature branches "current" and "other"
+    And branch "current" has the sync strategy "rebase"
+    And the current branch is "current" and the previous branch is "other"
+    When I run "git-town kill"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                  |
+      | current | git fetch --prune --tags |
+      |         | git checkout other       |
+      | other   | git branch -D current    |
+    And the current branch is now "other"
+    And branch "current" now has no sync strategy
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then the current branch is now "current"
+    And branch "current" now has the sync strategy "rebase"
+    And the initial branches and lineage exist
@@ -14,20 +14,49 @@ Feature: delete a branch that is active in another worktree
 
   Scenario: result
     Then it runs the commands
-      | BRANCH | COMMAND                  |
-      | good   | git fetch --prune --tags |
-    And it prints the error:
-      """
-      branch "dead" is active in another worktree
-      """
+      | BRANCH | COMMAND                                     |
+      | good   | git fetch --prune --tags                    |
+      |        | git add -A                                  |
+      |        | git stash                                   |
+      |        | git push origin :dead                       |
+      |        | git worktree remove ../development_worktree |
+      |        | git branch -D dead                          |
+      |        | git stash pop                               |
+    And the current branch is still "good"
+    And the worktree "development_worktree" no longer exists
     And the uncommitted file still exists
+    And the branches are now
+      | REPOSITORY    | BRANCHES   |
+      | local, origin | main, good |
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | good   | main   |
 
   Scenario: undo
     When I run "git-town undo"
-    Then it runs no commands
-    And it prints:
-      """
-      nothing to undo
-      """
+    Then it runs the commands
+      | BRANCH | COMMAND                                       |
+      | good   | git add -A                                    |
+      |        | git stash                                     |
+      |        | git branch dead {{ sha 'dead-end commit' }}   |
+      |        | git push -u origin dead                       |
+      |        | git worktree add ../development_worktree dead |
+      |        | git stash pop                                 |
     And the current branch is still "good"
+    And branch "dead" is now checked out in the worktree "development_worktree"
     And the uncommitted file still exists
+    And the initial branches and lineage exist
+
+  Scenario: the other worktree contains uncommitted changes
+    Given I run "git-town undo"
+    And an uncommitted file in the other worktree
+    When I run "git-town kill dead"
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | good   | git fetch --prune --tags |
+    And it prints the error:
+      """
+      which contains uncommitted changes, please commit or discard them first
+      """
+    And branch "dead" is still checked out in the worktree "development_worktree"
+    And the uncommitted file still exists in the other worktree
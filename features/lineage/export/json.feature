@@ -0,0 +1,34 @@
+Feature: export the lineage as JSON
+
+  Background:
+    Given a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And an observed branch "observed"
+
+  Scenario: export to STDOUT
+    When I run "git-town lineage export --format json"
+    Then it runs no commands
+    And it prints:
+      """
+      {
+        "contribution": [],
+        "lineage": {
+          "alpha": "main",
+          "beta": "alpha"
+        },
+        "observed": [
+          "observed"
+        ],
+        "parked": [],
+        "perennial": [],
+        "prototype": []
+      }
+      """
+
+  Scenario: unknown format
+    When I run "git-town lineage export --format yaml"
+    Then it runs no commands
+    And it prints the error:
+      """
+      unknown lineage file format "yaml", please use "toml" or "json"
+      """
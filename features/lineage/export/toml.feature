@@ -0,0 +1,44 @@
+Feature: export the lineage as TOML
+
+  Background:
+    Given a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And a parked branch "parked"
+    And a perennial branch "qa"
+    And a prototype branch "prototype"
+
+  Scenario: export to STDOUT
+    When I run "git-town lineage export"
+    Then it runs no commands
+    And it prints:
+      """
+      contribution = []
+      observed = []
+      parked = ["parked"]
+      perennial = ["qa"]
+      prototype = ["prototype"]
+
+      [lineage]
+        alpha = "main"
+        beta = "alpha"
+        parked = "main"
+        prototype = "main"
+      """
+
+  Scenario: export to a file
+    When I run "git-town lineage export lineage.toml"
+    Then it runs no commands
+    And file "lineage.toml" now has content:
+      """
+      contribution = []
+      observed = []
+      parked = ["parked"]
+      perennial = ["qa"]
+      prototype = ["prototype"]
+
+      [lineage]
+        alpha = "main"
+        beta = "alpha"
+        parked = "main"
+        prototype = "main"
+      """
@@ -0,0 +1,16 @@
+Feature: importing a lineage that contains cycles
+
+  Scenario: the imported lineage would make a branch its own ancestor
+    Given a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And a file "lineage.json" with content:
+      """
+      { "lineage": { "alpha": "beta" } }
+      """
+    When I run "git-town lineage import lineage.json"
+    Then it runs no commands
+    And it prints the error:
+      """
+      cannot import the lineage because branch "alpha" would be its own ancestor
+      """
+    And the initial lineage exists
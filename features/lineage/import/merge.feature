@@ -0,0 +1,39 @@
+Feature: import the lineage from a file
+
+  Background:
+    Given a feature branch "alpha"
+    And a feature branch "beta"
+    And a feature branch "gamma"
+    And Git Town parent setting for branch "gamma" doesn't exist
+    And a file "lineage.toml" with content:
+      """
+      parked = ["alpha"]
+
+      [lineage]
+        beta = "alpha"
+        gamma = "beta"
+      """
+    When I run "git-town lineage import lineage.toml"
+
+  Scenario: result
+    Then it runs no commands
+    And it prints:
+      """
+      Imported 2 lineage entries and 1 branch types.
+      """
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | alpha  | main   |
+      | beta   | alpha  |
+      | gamma  | beta   |
+    And branch "alpha" is now parked
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND       |
+      | main   | git add -A    |
+      |        | git stash     |
+      |        | git stash pop |
+    And the initial lineage exists
+    And there are now no parked branches
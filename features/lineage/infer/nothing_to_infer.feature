@@ -0,0 +1,12 @@
+Feature: all branches have a parent
+
+  Scenario: lineage is complete
+    Given a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    When I run "git-town lineage infer"
+    Then it runs no commands
+    And it prints:
+      """
+      All local branches have a known parent branch.
+      """
+    And the initial lineage exists
@@ -0,0 +1,70 @@
+Feature: infer the parents of branches that have no parent
+
+  Background:
+    Given a feature branch "alpha"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | alpha  | local, origin | alpha commit | alpha_file |
+    And a feature branch "beta" as a child of "alpha"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE     | FILE NAME |
+      | beta   | local, origin | beta commit | beta_file |
+    And a feature branch "gamma"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | gamma  | local, origin | gamma commit | gamma_file |
+    And Git Town parent setting for branch "alpha" doesn't exist
+    And Git Town parent setting for branch "beta" doesn't exist
+    And Git Town parent setting for branch "gamma" doesn't exist
+    And the current branch is "beta"
+
+  Scenario: accept the inferred lineage
+    When I run "git-town lineage infer" and enter into the dialog:
+      | DIALOG           | KEYS  |
+      | inferred lineage | enter |
+    Then it runs no commands
+    And it prints:
+      """
+      Git Town inferred this lineage:
+
+      main
+        alpha
+          beta
+        gamma
+      """
+    And the current branch is still "beta"
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | alpha  | main   |
+      | beta   | alpha  |
+      | gamma  | main   |
+
+  Scenario: edit the inferred lineage
+    When I run "git-town lineage infer" and enter into the dialog:
+      | DIALOG                  | KEYS       |
+      | inferred lineage        | down enter |
+      | parent for branch alpha | enter      |
+      | parent for branch beta  | enter      |
+      | parent for branch gamma | down enter |
+    Then it runs no commands
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | alpha  | main   |
+      | beta   | alpha  |
+      | gamma  | alpha  |
+
+  Scenario: skip the inferred lineage
+    When I run "git-town lineage infer" and enter into the dialog:
+      | DIALOG           | KEYS            |
+      | inferred lineage | down down enter |
+    Then it runs no commands
+    And no lineage exists now
+
+  Scenario: undo
+    Given I ran "git-town lineage infer" and enter into the dialog:
+      | DIALOG           | KEYS  |
+      | inferred lineage | enter |
+    When I run "git-town undo"
+    Then it runs no commands
+    And the current branch is still "beta"
+    And no lineage exists now
@@ -0,0 +1,54 @@
+Feature: handle conflicts while moving a branch
+
+  Background:
+    Given a feature branch "alpha"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | alpha  | local, origin | alpha commit | alpha_file |
+    And a feature branch "beta" as a child of "alpha"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE     | FILE NAME        | FILE CONTENT |
+      | beta   | local, origin | beta commit | conflicting_file | beta content |
+      | main   | local, origin | main commit | conflicting_file | main content |
+    And the current branch is "beta"
+    When I run "git-town move main"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                      |
+      | beta   | git rebase --onto main alpha |
+    And it prints the error:
+      """
+      To continue after having resolved conflicts, run "git town continue".
+      To go back to where you started, run "git town undo".
+      """
+    And a rebase is now in progress
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND            |
+      | beta   | git rebase --abort |
+    And the current branch is still "beta"
+    And no rebase is in progress
+    And the initial lineage exists
+
+  Scenario: resolve and continue
+    When I resolve the conflict in "conflicting_file"
+    And I run "git-town continue" and close the editor
+    Then it runs the commands
+      | BRANCH | COMMAND                                         |
+      | beta   | git rebase --continue                           |
+      |        | git push --force-with-lease --force-if-includes |
+    And the current branch is still "beta"
+    And no rebase is in progress
+    And these commits exist now
+      | BRANCH | LOCATION      | MESSAGE      |
+      | main   | local, origin | main commit  |
+      | alpha  | local, origin | alpha commit |
+      | beta   | local, origin | main commit  |
+      |        |               | beta commit  |
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | alpha  | main   |
+      | beta   | main   |
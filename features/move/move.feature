@@ -0,0 +1,64 @@
+Feature: move a branch and its descendants onto a new parent
+
+  Background:
+    Given a feature branch "alpha"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | alpha  | local, origin | alpha commit | alpha_file |
+    And a feature branch "beta" as a child of "alpha"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE     | FILE NAME |
+      | beta   | local, origin | beta commit | beta_file |
+    And a feature branch "gamma" as a child of "beta"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | gamma  | local, origin | gamma commit | gamma_file |
+    And the current branch is "beta"
+    When I run "git-town move main"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                                                   |
+      | beta   | git rebase --onto main alpha                              |
+      |        | git push --force-with-lease --force-if-includes           |
+      |        | git checkout gamma                                        |
+      | gamma  | git rebase --onto beta {{ sha-before-run 'beta commit' }} |
+      |        | git push --force-with-lease --force-if-includes           |
+      |        | git checkout beta                                         |
+    And it prints:
+      """
+      branch "beta" is now a child of "main"
+      """
+    And the current branch is still "beta"
+    And these commits exist now
+      | BRANCH | LOCATION      | MESSAGE      |
+      | alpha  | local, origin | alpha commit |
+      | beta   | local, origin | beta commit  |
+      | gamma  | local, origin | beta commit  |
+      |        |               | gamma commit |
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | alpha  | main   |
+      | beta   | main   |
+      | gamma  | beta   |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                         |
+      | beta   | git reset --hard {{ sha 'beta commit' }}        |
+      |        | git push --force-with-lease --force-if-includes |
+      |        | git checkout gamma                              |
+      | gamma  | git reset --hard {{ sha 'gamma commit' }}       |
+      |        | git push --force-with-lease --force-if-includes |
+      |        | git checkout beta                               |
+    And the current branch is still "beta"
+    And these commits exist now
+      | BRANCH | LOCATION      | MESSAGE      |
+      | alpha  | local, origin | alpha commit |
+      | beta   | local, origin | alpha commit |
+      |        |               | beta commit  |
+      | gamma  | local, origin | alpha commit |
+      |        |               | beta commit  |
+      |        |               | gamma commit |
+    And the initial lineage exists
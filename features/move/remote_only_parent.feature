@@ -0,0 +1,56 @@
+Feature: move a branch whose descendants have a parent that exists only at the remote
+
+  Background:
+    Given a feature branch "alpha"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | alpha  | local, origin | alpha commit | alpha_file |
+    And a feature branch "beta" as a child of "alpha"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE     | FILE NAME |
+      | beta   | local, origin | beta commit | beta_file |
+    And a feature branch "gamma" as a child of "beta"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | gamma  | local, origin | gamma commit | gamma_file |
+    And a feature branch "other"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | other  | local, origin | other commit | other_file |
+    And I ran "git branch -D beta"
+    And the current branch is "alpha"
+    When I run "git-town move other"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                                         |
+      | alpha  | git rebase --onto other main                    |
+      |        | git push --force-with-lease --force-if-includes |
+    And it prints:
+      """
+      branch "alpha" is now a child of "other"
+      """
+    And the current branch is still "alpha"
+    And these commits exist now
+      | BRANCH | LOCATION      | MESSAGE      |
+      | alpha  | local, origin | other commit |
+      |        |               | alpha commit |
+      | beta   | origin        | alpha commit |
+      |        |               | beta commit  |
+      | gamma  | local, origin | alpha commit |
+      |        |               | beta commit  |
+      |        |               | gamma commit |
+      | other  | local, origin | other commit |
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | alpha  | other  |
+      | other  | main   |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                         |
+      | alpha  | git reset --hard {{ sha 'alpha commit' }}       |
+      |        | git push --force-with-lease --force-if-includes |
+    And the current branch is still "alpha"
+    And the initial lineage exists
@@ -22,6 +22,7 @@ Feature: display all executed Git commands
       |        | backend  | git branch -vva --sort=refname                |
       |        | backend  | git rev-parse --verify --abbrev-ref @{-1}     |
       | old    | frontend | git checkout main                             |
+      |        | backend  | git config --get rerere.enabled               |
       | main   | frontend | git rebase origin/main                        |
       |        | backend  | git rev-list --left-right main...origin/main  |
       | main   | frontend | git checkout old                              |
@@ -41,7 +42,7 @@ Feature: display all executed Git commands
       |        | backend  | git stash list                                |
     And it prints:
       """
-      Ran 29 shell commands.
+      Ran 30 shell commands.
       """
     And the current branch is now "parent"
 
@@ -0,0 +1,40 @@
+Feature: prepend a branch in a new worktree
+
+  Background:
+    Given the current branch is a feature branch "old"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE    |
+      | old    | local, origin | old commit |
+    When I run "git-town prepend parent --worktree"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                                     |
+      | old    | git fetch --prune --tags                    |
+      |        | git checkout main                           |
+      | main   | git rebase origin/main                      |
+      |        | git checkout old                            |
+      | old    | git merge --no-edit origin/old              |
+      |        | git merge --no-edit main                    |
+      |        | git branch parent main                      |
+      |        | git worktree add ../developer-parent parent |
+    And the current branch is still "old"
+    And branch "parent" is now checked out in the worktree "developer-parent"
+    And these commits exist now
+      | BRANCH | LOCATION      | MESSAGE    |
+      | old    | local, origin | old commit |
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | old    | parent |
+      | parent | main   |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                 |
+      | old    | git worktree remove ../developer-parent |
+      |        | git branch -D parent                    |
+    And the current branch is still "old"
+    And the worktree "developer-parent" no longer exists
+    And the initial commits exist
+    And the initial lineage exists
@@ -23,6 +23,7 @@ Feature: display all executed Git commands
       | old    | frontend | git add -A                                    |
       |        | frontend | git stash                                     |
       |        | frontend | git checkout main                             |
+      |        | backend  | git config --get rerere.enabled               |
       | main   | frontend | git rebase origin/main                        |
       |        | backend  | git rev-list --left-right main...origin/main  |
       | main   | frontend | git checkout old                              |
@@ -44,7 +45,7 @@ Feature: display all executed Git commands
       |        | backend  | git stash list                                |
     And it prints:
       """
-      Ran 31 shell commands.
+      Ran 32 shell commands.
       """
     And the current branch is now "parent"
 
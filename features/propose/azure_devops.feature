@@ -0,0 +1,19 @@
+@skipWindows
+Feature: Azure DevOps support
+
+  Scenario Outline: normal origin
+    Given the current branch is a feature branch "feature"
+    And the origin is "<ORIGIN>"
+    And tool "open" is installed
+    When I run "git-town propose"
+    Then "open" launches a new proposal with this url in my browser:
+      """
+      https://dev.azure.com/git-town/docs/_git/git-town/pullrequestcreate?sourceRef=feature&targetRef=main
+      """
+
+    Examples:
+      | ORIGIN                                                     |
+      | https://dev.azure.com/git-town/docs/_git/git-town          |
+      | https://git-town@dev.azure.com/git-town/docs/_git/git-town |
+      | git@ssh.dev.azure.com:v3/git-town/docs/git-town            |
+      | ssh://git@ssh.dev.azure.com/v3/git-town/docs/git-town      |
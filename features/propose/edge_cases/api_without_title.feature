@@ -0,0 +1,21 @@
+Feature: creating a proposal via the API requires a title
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the origin is "git@github.com:git-town/git-town.git"
+    When I run "git-town propose --no-browser --draft"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                  |
+      | feature | git fetch --prune --tags |
+    And it prints the error:
+      """
+      creating a proposal via the API requires a title, please provide it using --title
+      """
+    And the current branch is still "feature"
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs no commands
+    And the current branch is still "feature"
@@ -0,0 +1,14 @@
+Feature: provide the proposal body either directly or via a file
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the origin is "git@github.com:git-town/git-town.git"
+    When I run "git-town propose --title=title --body=body --body-file=body.md"
+
+  Scenario: result
+    Then it runs no commands
+    And it prints the error:
+      """
+      please provide the proposal body either via --body or via --body-file
+      """
+    And the current branch is still "feature"
@@ -14,4 +14,5 @@ Feature: unsupported hosting platform
       * GitHub
       * GitLab
       * Gitea
+      * Azure DevOps
       """
@@ -20,6 +20,7 @@ Feature: display all executed Git commands
       |         | backend  | git branch -vva --sort=refname                                     |
       |         | backend  | git rev-parse --verify --abbrev-ref @{-1}                          |
       | feature | frontend | git checkout main                                                  |
+      |         | backend  | git config --get rerere.enabled                                    |
       | main    | frontend | git rebase origin/main                                             |
       |         | backend  | git rev-list --left-right main...origin/main                       |
       | main    | frontend | git checkout feature                                               |
@@ -38,7 +39,7 @@ Feature: display all executed Git commands
       |         | backend  | git stash list                                                     |
     And it prints:
       """
-      Ran 28 shell commands.
+      Ran 29 shell commands.
       """
     And "open" launches a new proposal with this url in my browser:
       """
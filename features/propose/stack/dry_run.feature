@@ -0,0 +1,30 @@
+Feature: dry-run proposing an entire stack
+
+  Background:
+    Given feature branch "alpha" with these commits
+      | LOCATION | MESSAGE | FILE NAME | FILE CONTENT |
+      | local    | alpha 1 | alpha_1   | alpha 1      |
+    And feature branch "beta" as a child of "alpha" has these commits
+      | LOCATION | MESSAGE | FILE NAME | FILE CONTENT |
+      | local    | beta 1  | beta_1    | beta 1       |
+    And the current branch is "alpha"
+    And the origin is "git@github.com:git-town/git-town.git"
+    When I run "git-town propose --stack --dry-run"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | alpha  | git fetch --prune --tags         |
+      |        | git checkout main                |
+      | main   | git rebase origin/main           |
+      |        | git checkout alpha               |
+      | alpha  | git merge --no-edit origin/alpha |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      |        | git checkout beta                |
+      | beta   | git merge --no-edit origin/beta  |
+      |        | git merge --no-edit alpha        |
+      |        | git push                         |
+    And the current branch is still "alpha"
+    And the initial commits exist
+    And the initial branches and lineage exist
@@ -0,0 +1,17 @@
+Feature: cannot propose the stack of the main branch
+
+  Background:
+    Given a feature branch "alpha"
+    And the current branch is "main"
+    And the origin is "git@github.com:git-town/git-town.git"
+    When I run "git-town propose --stack"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | main   | git fetch --prune --tags |
+    And it prints the error:
+      """
+      cannot propose the main branch
+      """
+    And the current branch is still "main"
@@ -0,0 +1,18 @@
+Feature: cannot propose a stack that contains a prototype branch
+
+  Background:
+    Given a feature branch "alpha"
+    And a local prototype branch "beta" as a child of "alpha"
+    And the current branch is "alpha"
+    And the origin is "git@github.com:git-town/git-town.git"
+    When I run "git-town propose --stack"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | alpha  | git fetch --prune --tags |
+    And it prints the error:
+      """
+      cannot propose prototype branches, run "git town hack" to make it a feature branch first
+      """
+    And the current branch is still "alpha"
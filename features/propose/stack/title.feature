@@ -0,0 +1,17 @@
+Feature: proposing a stack does not accept a title
+
+  Background:
+    Given a feature branch "alpha"
+    And the origin is "git@github.com:git-town/git-town.git"
+    And the current branch is "alpha"
+    When I run "git-town propose --stack --title=title"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | alpha  | git fetch --prune --tags |
+    And it prints the error:
+      """
+      cannot use --title, --body, or --body-file together with --stack because each branch gets its own proposal
+      """
+    And the current branch is still "alpha"
@@ -0,0 +1,13 @@
+Feature: make a prototype branch a prototype branch again
+
+  Background:
+    Given the current branch is a local prototype branch "branch"
+    When I run "git-town prototype"
+
+  Scenario: result
+    Then it runs no commands
+    And it prints the error:
+      """
+      branch "branch" is already a prototype branch
+      """
+    And branch "branch" is still a prototype branch
@@ -0,0 +1,13 @@
+Feature: cannot make the main branch a prototype branch
+
+  Background:
+    When I run "git-town prototype"
+
+  Scenario: result
+    Then it runs no commands
+    And it prints the error:
+      """
+      cannot make the main branch a prototype branch
+      """
+    And the current branch is still "main"
+    And there are still no prototype branches
@@ -0,0 +1,20 @@
+Feature: make a parked branch a prototype branch
+
+  Background:
+    Given the current branch is a parked branch "branch"
+    When I run "git-town prototype"
+
+  Scenario: result
+    Then it runs no commands
+    And it prints:
+      """
+      branch "branch" is now a prototype branch
+      """
+    And branch "branch" is now a prototype branch
+    And there are now no parked branches
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs no commands
+    And branch "branch" is now parked
+    And there are now no prototype branches
@@ -0,0 +1,27 @@
+Feature: dry-run redoing an undone command
+
+  Scenario: redo a command that added configuration
+    Given the feature branches "alpha" and "beta"
+    And the current branch is "alpha"
+    And I ran "git-town park"
+    And I ran "git-town undo"
+    When I run "git-town redo --dry-run"
+    Then it runs no commands
+    And there are still no parked branches
+    When I run "git-town redo"
+    Then branch "alpha" is now parked
+
+  Scenario: redo a command that removed configuration
+    Given the current branch is a feature branch "current"
+    And a feature branch "other"
+    And the current branch is "current" and the previous branch is "other"
+    And I ran "git-town kill"
+    And I ran "git-town undo"
+    When I run "git-town redo --dry-run"
+    Then it runs the commands
+      | BRANCH  | COMMAND                  |
+      | current | git push origin :current |
+      |         | git checkout other       |
+      | other   | git branch -D current    |
+    And the current branch is still "current"
+    And the initial branches and lineage exist
@@ -0,0 +1,40 @@
+Feature: redo an undone kill
+
+  Background:
+    Given the current branch is a feature branch "current"
+    And a feature branch "other"
+    And the commits
+      | BRANCH  | LOCATION      | MESSAGE        |
+      | current | local, origin | current commit |
+      | other   | local, origin | other commit   |
+    And the current branch is "current" and the previous branch is "other"
+    And I ran "git-town kill"
+    And I ran "git-town undo"
+    When I run "git-town redo"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                  |
+      | current | git push origin :current |
+      |         | git checkout other       |
+      | other   | git branch -D current    |
+    And the current branch is now "other"
+    And the branches are now
+      | REPOSITORY    | BRANCHES    |
+      | local, origin | main, other |
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | other  | main   |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then the current branch is now "current"
+    And the initial commits exist
+    And the initial branches and lineage exist
+
+  Scenario: nothing left to redo
+    When I run "git-town redo"
+    Then it prints:
+      """
+      nothing to redo
+      """
@@ -0,0 +1,21 @@
+Feature: redo several undone Git Town commands one after the other
+
+  Background:
+    Given the feature branches "alpha" and "beta"
+    And the current branch is "alpha"
+    And I ran "git-town park"
+    And I ran "git-town observe beta"
+    And I ran "git-town undo"
+    And I ran "git-town undo"
+
+  Scenario: redo both commands
+    When I run "git-town redo"
+    Then branch "alpha" is now parked
+    And there are still no observed branches
+    When I run "git-town redo"
+    Then branch "beta" is now observed
+    When I run "git-town redo"
+    Then it prints:
+      """
+      nothing to redo
+      """
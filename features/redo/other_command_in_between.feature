@@ -0,0 +1,15 @@
+Feature: commands that run after an undo discard the redo information
+
+  Scenario:
+    Given the feature branches "alpha" and "beta"
+    And the current branch is "alpha"
+    And I ran "git-town park"
+    And I ran "git-town undo"
+    And I ran "git-town observe beta"
+    When I run "git-town redo"
+    Then it prints:
+      """
+      nothing to redo
+      """
+    And there are still no parked branches
+    And branch "beta" is still observed
@@ -0,0 +1,24 @@
+Feature: rename a branch that has a sync strategy override
+
+  Background:
+    Given the current branch is a local feature branch "old"
+    And branch "old" has the sync strategy "rebase"
+    When I run "git-town rename-branch new"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | old    | git fetch --prune --tags |
+      |        | git branch new old       |
+      |        | git checkout new         |
+      | new    | git branch -D old        |
+    And the current branch is now "new"
+    And branch "new" now has the sync strategy "rebase"
+    And branch "old" now has no sync strategy
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then the current branch is now "old"
+    And branch "old" now has the sync strategy "rebase"
+    And branch "new" now has no sync strategy
+    And the initial branches and lineage exist
@@ -0,0 +1,18 @@
+@skipWindows
+Feature: Azure DevOps
+
+  Scenario Outline:
+    Given the origin is "<ORIGIN>"
+    And tool "open" is installed
+    When I run "git-town repo"
+    Then "open" launches a new proposal with this url in my browser:
+      """
+      https://dev.azure.com/git-town/docs/_git/git-town
+      """
+
+    Examples:
+      | ORIGIN                                                     |
+      | https://dev.azure.com/git-town/docs/_git/git-town          |
+      | https://git-town@dev.azure.com/git-town/docs/_git/git-town |
+      | git@ssh.dev.azure.com:v3/git-town/docs/git-town            |
+      | ssh://git@ssh.dev.azure.com/v3/git-town/docs/git-town      |
@@ -11,4 +11,5 @@ Feature: unsupported hosting platform
       * GitHub
       * GitLab
       * Gitea
+      * Azure DevOps
       """
@@ -0,0 +1,43 @@
+Feature: provide the new parent as an argument
+
+  Background:
+    Given a feature branch "alpha"
+    And a feature branch "beta"
+    And a feature branch "child" as a child of "alpha"
+    And the current branch is "child"
+
+  Scenario: provide the new parent
+    When I run "git-town set-parent beta"
+    Then it runs no commands
+    And it prints:
+      """
+      branch "child" is now a child of "beta"
+      """
+    And the current branch is still "child"
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | alpha  | main   |
+      | beta   | main   |
+      | child  | beta   |
+
+  Scenario: provide the branch and the new parent
+    Given the current branch is "alpha"
+    When I run "git-town set-parent child main"
+    Then it runs no commands
+    And it prints:
+      """
+      branch "child" is now a child of "main"
+      """
+    And the current branch is still "alpha"
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | alpha  | main   |
+      | beta   | main   |
+      | child  | main   |
+
+  Scenario: undo
+    Given I ran "git-town set-parent beta"
+    When I run "git-town undo"
+    Then it runs no commands
+    And the current branch is still "child"
+    And the initial lineage exists
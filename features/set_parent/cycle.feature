@@ -0,0 +1,34 @@
+Feature: does not allow cycles in the lineage
+
+  Background:
+    Given a feature branch "parent"
+    And a feature branch "child" as a child of "parent"
+    And a feature branch "grandchild" as a child of "child"
+    And the current branch is "parent"
+
+  Scenario: make a branch a child of its descendant
+    When I run "git-town set-parent grandchild"
+    Then it runs no commands
+    And it prints the error:
+      """
+      cannot make "parent" a child of its descendant "grandchild"
+      """
+    And the initial lineage exists
+
+  Scenario: make a branch its own parent
+    When I run "git-town set-parent parent"
+    Then it runs no commands
+    And it prints the error:
+      """
+      cannot make branch "parent" its own parent
+      """
+    And the initial lineage exists
+
+  Scenario: non-existing parent
+    When I run "git-town set-parent zonk"
+    Then it runs no commands
+    And it prints the error:
+      """
+      there is no branch "zonk"
+      """
+    And the initial lineage exists
@@ -0,0 +1,49 @@
+Feature: rebase the branch onto its new parent
+
+  Background:
+    Given a feature branch "alpha"
+    And a feature branch "beta"
+    And a feature branch "child" as a child of "alpha"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | alpha  | local, origin | alpha commit | alpha_file |
+      | beta   | local, origin | beta commit  | beta_file  |
+      | child  | local, origin | child commit | child_file |
+    And the current branch is "alpha"
+    When I run "git-town set-parent child beta --rebase"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                                         |
+      | alpha  | git checkout child                              |
+      | child  | git rebase --onto beta alpha                    |
+      |        | git push --force-with-lease --force-if-includes |
+      |        | git checkout alpha                              |
+    And it prints:
+      """
+      branch "child" is now a child of "beta"
+      """
+    And the current branch is still "alpha"
+    And these commits exist now
+      | BRANCH | LOCATION      | MESSAGE      |
+      | alpha  | local, origin | alpha commit |
+      | beta   | local, origin | beta commit  |
+      | child  | local, origin | beta commit  |
+      |        |               | child commit |
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | alpha  | main   |
+      | beta   | main   |
+      | child  | beta   |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                         |
+      | alpha  | git checkout child                              |
+      | child  | git reset --hard {{ sha 'child commit' }}       |
+      |        | git push --force-with-lease --force-if-includes |
+      |        | git checkout alpha                              |
+    And the current branch is still "alpha"
+    And the initial commits exist
+    And the initial lineage exists
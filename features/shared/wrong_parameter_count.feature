@@ -22,7 +22,7 @@ Feature: too few or many parameters
       | rename-branch                | accepts between 1 and 2 arg(s), received 0         |
       | rename-branch arg1 arg2 arg3 | accepts between 1 and 2 arg(s), received 3         |
       | repo arg1                    | unknown command "arg1" for "git-town repo"         |
-      | set-parent arg1              | unknown command "arg1" for "git-town set-parent"   |
+      | set-parent arg1 arg2 arg3    | accepts at most 2 arg(s), received 3               |
       | ship arg1 arg2               | accepts at most 1 arg(s), received 2               |
       | sync arg1                    | unknown command "arg1" for "git-town sync"         |
       | --version arg1               | unknown command "arg1" for "git-town"              |
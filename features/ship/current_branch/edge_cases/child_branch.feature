@@ -19,7 +19,7 @@ Feature: does not ship a child branch
     And it prints the error:
       """
       shipping this branch would ship "alpha" and "beta" as well,
-      please ship "alpha" first
+      please ship "alpha" first or use "git town ship --stack"
       """
     And the current branch is still "gamma"
     And the initial commits exist
@@ -0,0 +1,19 @@
+Feature: does not ship prototype branches
+
+  Background:
+    Given the current branch is a local prototype branch "prototype"
+    And the commits
+      | BRANCH    | LOCATION | MESSAGE          |
+      | prototype | local    | prototype commit |
+    When I run "git-town ship -m done"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH    | COMMAND                  |
+      | prototype | git fetch --prune --tags |
+    And it prints the error:
+      """
+      cannot ship prototype branches, run "git town hack" to make it a feature branch first
+      """
+    And the current branch is still "prototype"
+    And branch "prototype" is still a prototype branch
@@ -0,0 +1,20 @@
+Feature: ship a branch that has a sync strategy override
+
+  Background:
+    Given the current branch is a local feature branch "feature"
+    And branch "feature" has the sync strategy "rebase"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE        |
+      | feature | local    | feature commit |
+    When I run "git-town ship -m 'feature done'"
+
+  Scenario: result
+    Then the current branch is now "main"
+    And no lineage exists now
+    And branch "feature" now has no sync strategy
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then the current branch is now "feature"
+    And branch "feature" now has the sync strategy "rebase"
+    And the initial branches and lineage exist
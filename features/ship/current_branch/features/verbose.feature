@@ -25,6 +25,7 @@ Feature: display all executed Git commands
       |         | backend  | git remote get-url origin                         |
       |         | backend  | git status --long --ignore-submodules             |
       | feature | frontend | git checkout main                                 |
+      |         | backend  | git config --get rerere.enabled                   |
       | main    | frontend | git rebase origin/main                            |
       |         | backend  | git rev-list --left-right main...origin/main      |
       | main    | frontend | git checkout feature                              |
@@ -48,7 +49,7 @@ Feature: display all executed Git commands
       |         | backend  | git stash list                                    |
     And it prints:
       """
-      Ran 35 shell commands.
+      Ran 36 shell commands.
       """
     And the current branch is now "main"
 
@@ -0,0 +1,18 @@
+Feature: does not ship a stack that contains a prototype branch
+
+  Background:
+    Given a local prototype branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And the current branch is "beta"
+    When I run "git-town ship --stack"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | beta   | git fetch --prune --tags |
+    And it prints the error:
+      """
+      cannot ship prototype branches, run "git town hack" to make it a feature branch first
+      """
+    And the current branch is still "beta"
+    And the initial branches and lineage exist
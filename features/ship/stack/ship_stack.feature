@@ -0,0 +1,71 @@
+@skipWindows
+Feature: ship a branch together with all its ancestor branches
+
+  Background:
+    Given a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And a feature branch "gamma" as a child of "beta"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | alpha  | local, origin | alpha commit | alpha_file |
+      | beta   | local, origin | beta commit  | beta_file  |
+      | gamma  | local, origin | gamma commit | gamma_file |
+    And the current branch is "beta"
+    When I run "git-town ship --stack" and enter these commit messages:
+      | alpha done |
+      | beta done  |
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | beta   | git fetch --prune --tags |
+      |        | git checkout main        |
+      | main   | git merge --squash alpha |
+      |        | git commit               |
+      |        | git push                 |
+      |        | git branch -D alpha      |
+      |        | git merge --squash beta  |
+      |        | git commit               |
+      |        | git push                 |
+      |        | git branch -D beta       |
+    And it prints:
+      """
+      branch "beta" is now a child of "main"
+      """
+    And it prints:
+      """
+      branch "gamma" is now a child of "main"
+      """
+    And the current branch is now "main"
+    And these commits exist now
+      | BRANCH | LOCATION      | MESSAGE      |
+      | main   | local, origin | alpha done   |
+      |        |               | beta done    |
+      | alpha  | origin        | alpha commit |
+      | beta   | origin        | beta commit  |
+      | gamma  | local, origin | gamma commit |
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | gamma  | main   |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                   |
+      | main   | git revert {{ sha 'beta done' }}          |
+      |        | git revert {{ sha 'alpha done' }}         |
+      |        | git push                                  |
+      |        | git branch alpha {{ sha 'alpha commit' }} |
+      |        | git branch beta {{ sha 'beta commit' }}   |
+      |        | git checkout beta                         |
+    And the current branch is now "beta"
+    And these commits exist now
+      | BRANCH | LOCATION      | MESSAGE             |
+      | main   | local, origin | alpha done          |
+      |        |               | beta done           |
+      |        |               | Revert "beta done"  |
+      |        |               | Revert "alpha done" |
+      | alpha  | local, origin | alpha commit        |
+      | beta   | local, origin | beta commit         |
+      | gamma  | local, origin | gamma commit        |
+    And the initial branches and lineage exist
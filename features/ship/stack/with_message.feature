@@ -0,0 +1,16 @@
+Feature: does not allow a commit message when shipping a stack
+
+  Background:
+    Given a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And the current branch is "beta"
+    When I run "git-town ship --stack -m done"
+
+  Scenario: result
+    Then it runs no commands
+    And it prints the error:
+      """
+      cannot use --message together with --stack because each shipped branch gets its own commit message
+      """
+    And the current branch is still "beta"
+    And the initial branches and lineage exist
@@ -18,7 +18,7 @@ Feature: does not ship a child branch
     And it prints the error:
       """
       shipping this branch would ship "alpha" and "beta" as well,
-      please ship "alpha" first
+      please ship "alpha" first or use "git town ship --stack"
       """
     And the current branch is now "alpha"
     And the initial commits exist
@@ -8,24 +8,62 @@ Feature: ship a feature branch that is active in another worktree
     And the current branch is "other"
     And branch "feature" is active in another worktree
     And an uncommitted file
-    When I run "git-town ship feature"
+    When I run "git-town ship feature -m 'feature done'"
 
   Scenario: result
     Then it runs the commands
-      | BRANCH | COMMAND                  |
-      | other  | git fetch --prune --tags |
-    And it prints the error:
-      """
-      branch "feature" is active in another worktree
-      """
+      | BRANCH | COMMAND                                     |
+      | other  | git fetch --prune --tags                    |
+      |        | git add -A                                  |
+      |        | git stash                                   |
+      |        | git checkout main                           |
+      | main   | git merge --squash feature                  |
+      |        | git commit -m "feature done"                |
+      |        | git push                                    |
+      |        | git push origin :feature                    |
+      |        | git worktree remove ../development_worktree |
+      |        | git branch -D feature                       |
+      |        | git checkout other                          |
+      | other  | git stash pop                               |
     And the current branch is still "other"
+    And the worktree "development_worktree" no longer exists
     And the uncommitted file still exists
+    And the branches are now
+      | REPOSITORY    | BRANCHES    |
+      | local, origin | main, other |
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | other  | main   |
 
   Scenario: undo
     When I run "git-town undo"
-    Then it runs no commands
-    And it prints:
+    Then it runs the commands
+      | BRANCH | COMMAND                                          |
+      | other  | git add -A                                       |
+      |        | git stash                                        |
+      |        | git checkout main                                |
+      | main   | git revert {{ sha 'feature done' }}              |
+      |        | git push                                         |
+      |        | git branch feature {{ sha 'feature commit' }}    |
+      |        | git push -u origin feature                       |
+      |        | git checkout other                               |
+      | other  | git worktree add ../development_worktree feature |
+      |        | git stash pop                                    |
+    And the current branch is still "other"
+    And branch "feature" is now checked out in the worktree "development_worktree"
+    And the uncommitted file still exists
+    And the initial branches and lineage exist
+
+  Scenario: the other worktree contains uncommitted changes
+    Given I run "git-town undo"
+    And an uncommitted file in the other worktree
+    When I run "git-town ship feature -m 'feature done'"
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | other  | git fetch --prune --tags |
+    And it prints the error:
       """
-      nothing to undo
+      which contains uncommitted changes, please commit or discard them first
       """
-    And the current branch is still "other"
+    And branch "feature" is still checked out in the worktree "development_worktree"
+    And the uncommitted file still exists in the other worktree
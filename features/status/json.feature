@@ -0,0 +1,56 @@
+Feature: describe the status of the current/last Git Town command as JSON
+
+  Scenario: Git Town command ran successfully
+    Given I ran "git-town sync"
+    When I run "git-town status --format=json"
+    Then it prints:
+      """
+      {
+        "runState": {
+          "canContinue": false,
+          "canSkip": false,
+          "canUndo": true,
+          "command": "sync",
+          "finished": true,
+          "unfinishedDetails": null
+        },
+        "schemaVersion": 1
+      }
+      """
+
+  Scenario: Git Town command in progress
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE                    | FILE NAME        | FILE CONTENT    |
+      | main    | local    | conflicting main commit    | conflicting_file | main content    |
+      | feature | local    | conflicting feature commit | conflicting_file | feature content |
+    And I run "git-town sync"
+    When I run "git-town status --format=json"
+    Then it prints something like:
+      """
+      {
+        "runState": {
+          "canContinue": true,
+          "canSkip": true,
+          "canUndo": true,
+          "command": "sync",
+          "finished": false,
+          "unfinishedDetails": {
+            "canSkip": true,
+            "endBranch": "feature",
+            "endTime": ".+"
+          }
+        },
+        "schemaVersion": 1
+      }
+      """
+
+  Scenario: no runstate exists
+    When I run "git-town status --format=json"
+    Then it prints:
+      """
+      {
+        "runState": null,
+        "schemaVersion": 1
+      }
+      """
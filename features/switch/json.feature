@@ -0,0 +1,49 @@
+Feature: list the local branches as JSON
+
+  Scenario: branch hierarchy
+    Given a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And a perennial branch "production"
+    And the current branch is "beta"
+    When I run "git-town switch --format=json"
+    Then it runs no commands
+    And it prints:
+      """
+      {
+        "branches": [
+          {
+            "name": "alpha",
+            "parent": "main",
+            "type": "feature"
+          },
+          {
+            "name": "beta",
+            "parent": "alpha",
+            "type": "feature"
+          },
+          {
+            "name": "main",
+            "parent": "",
+            "type": "main"
+          },
+          {
+            "name": "production",
+            "parent": "",
+            "type": "perennial"
+          }
+        ],
+        "currentBranch": "beta",
+        "lineage": [
+          {
+            "branch": "alpha",
+            "parent": "main"
+          },
+          {
+            "branch": "beta",
+            "parent": "alpha"
+          }
+        ],
+        "schemaVersion": 1
+      }
+      """
+    And the current branch is still "beta"
@@ -0,0 +1,133 @@
+Feature: handle merge conflicts in branches checked out in another worktree
+
+  Background:
+    Given the feature branches "alpha", "beta", and "gamma"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE            | FILE NAME        | FILE CONTENT        |
+      | main   | origin        | main commit        | main_file        | main content        |
+      | alpha  | local, origin | alpha commit       | feature1_file    | alpha content       |
+      | beta   | local         | local beta commit  | conflicting_file | local beta content  |
+      |        | origin        | origin beta commit | conflicting_file | origin beta content |
+      | gamma  | local, origin | gamma commit       | feature3_file    | gamma content       |
+    And branch "beta" is active in another worktree
+    And the current branch is "main"
+    And an uncommitted file
+    When I run "git-town sync --all"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | main   | git fetch --prune --tags         |
+      |        | git add -A                       |
+      |        | git stash                        |
+      |        | git rebase origin/main           |
+      |        | git checkout alpha               |
+      | alpha  | git merge --no-edit origin/alpha |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      | beta   | git merge --no-edit origin/beta  |
+    And it prints the error:
+      """
+      CONFLICT (add/add): Merge conflict in conflicting_file
+      """
+    And it prints something like:
+      """
+      This happened in the worktree at .*development_worktree.
+      Please resolve the problem there and run the commands below in .*developer.
+      """
+    And the current branch is now "alpha"
+    And the current branch in the other worktree is still "beta"
+    And the uncommitted file is stashed
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                         |
+      | beta   | git merge --abort                               |
+      | alpha  | git reset --hard {{ sha 'alpha commit' }}       |
+      |        | git push --force-with-lease --force-if-includes |
+      |        | git checkout main                               |
+      | main   | git reset --hard {{ sha 'initial commit' }}     |
+      |        | git stash pop                                   |
+    And the current branch is now "main"
+    And the current branch in the other worktree is still "beta"
+    And the uncommitted file still exists
+    And these commits exist now
+      | BRANCH | LOCATION                | MESSAGE            | FILE NAME        | FILE CONTENT        |
+      | main   | origin                  | main commit        | main_file        | main content        |
+      | alpha  | local, origin, worktree | alpha commit       | feature1_file    | alpha content       |
+      | beta   | origin                  | origin beta commit | conflicting_file | origin beta content |
+      |        | worktree                | local beta commit  | conflicting_file | local beta content  |
+      | gamma  | local, origin, worktree | gamma commit       | feature3_file    | gamma content       |
+    And the initial branches and lineage exist
+
+  Scenario: skip
+    When I run "git-town skip"
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | beta   | git merge --abort                |
+      | alpha  | git checkout gamma               |
+      | gamma  | git merge --no-edit origin/gamma |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      |        | git checkout main                |
+      | main   | git push --tags                  |
+      |        | git stash pop                    |
+    And the current branch is now "main"
+    And the current branch in the other worktree is still "beta"
+    And the uncommitted file still exists
+    And these commits exist now
+      | BRANCH | LOCATION                | MESSAGE                        |
+      | main   | local, origin           | main commit                    |
+      | alpha  | local, origin, worktree | alpha commit                   |
+      |        |                         | main commit                    |
+      |        |                         | Merge branch 'main' into alpha |
+      | beta   | origin                  | origin beta commit             |
+      |        | worktree                | local beta commit              |
+      | gamma  | local, origin, worktree | gamma commit                   |
+      |        |                         | main commit                    |
+      |        |                         | Merge branch 'main' into gamma |
+
+  Scenario: continue with unresolved conflict
+    When I run "git-town continue"
+    Then it runs no commands
+    And it prints the error:
+      """
+      you must resolve the conflicts before continuing
+      """
+    And the current branch is still "alpha"
+    And the current branch in the other worktree is still "beta"
+    And the uncommitted file is stashed
+
+  Scenario: resolve in the other worktree and continue
+    When I resolve the conflict in "conflicting_file" in the other worktree
+    And I run "git-town continue"
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | beta   | git commit --no-edit             |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      | alpha  | git checkout gamma               |
+      | gamma  | git merge --no-edit origin/gamma |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      |        | git checkout main                |
+      | main   | git push --tags                  |
+      |        | git stash pop                    |
+    And the current branch is now "main"
+    And the current branch in the other worktree is still "beta"
+    And the uncommitted file still exists
+    And these commits exist now
+      | BRANCH | LOCATION                | MESSAGE                                              |
+      | main   | local, origin           | main commit                                          |
+      | alpha  | local, origin, worktree | alpha commit                                         |
+      |        |                         | main commit                                          |
+      |        |                         | Merge branch 'main' into alpha                       |
+      | beta   | origin, worktree        | local beta commit                                    |
+      |        |                         | origin beta commit                                   |
+      |        |                         | Merge remote-tracking branch 'origin/beta' into beta |
+      |        |                         | main commit                                          |
+      |        |                         | Merge branch 'main' into beta                        |
+      | gamma  | local, origin, worktree | gamma commit                                         |
+      |        |                         | main commit                                          |
+      |        |                         | Merge branch 'main' into gamma                       |
@@ -0,0 +1,133 @@
+Feature: replay recorded conflict resolutions
+
+  Background:
+    Given the feature branches "alpha" and "beta"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME        | FILE CONTENT    |
+      | main   | origin        | main commit  | conflicting_file | main content    |
+      | alpha  | local, origin | alpha commit | conflicting_file | feature content |
+      | beta   | local, origin | beta commit  | conflicting_file | feature content |
+    And the current branch is "main"
+
+  Scenario: merge sync strategy
+    When I run "git-town sync --all"
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | main   | git fetch --prune --tags         |
+      |        | git rebase origin/main           |
+      |        | git checkout alpha               |
+      | alpha  | git merge --no-edit origin/alpha |
+      |        | git merge --no-edit main         |
+    And it prints the error:
+      """
+      CONFLICT (add/add): Merge conflict in conflicting_file
+      """
+    When I resolve the conflict in "conflicting_file"
+    And I run "git-town continue" and close the editor
+    Then it runs the commands
+      | BRANCH | COMMAND                         |
+      | alpha  | git commit --no-edit            |
+      |        | git push                        |
+      |        | git checkout beta               |
+      | beta   | git merge --no-edit origin/beta |
+      |        | git merge --no-edit main        |
+      |        | git add conflicting_file        |
+      |        | git commit --no-edit            |
+      |        | git push                        |
+      |        | git checkout main               |
+      | main   | git push --tags                 |
+    And it prints:
+      """
+      replayed the recorded conflict resolutions for conflicting_file in branch "beta"
+      """
+    And the current branch is now "main"
+    And these committed files exist now
+      | BRANCH | NAME             | CONTENT          |
+      | main   | conflicting_file | main content     |
+      | alpha  | conflicting_file | resolved content |
+      | beta   | conflicting_file | resolved content |
+
+  Scenario: rebase sync strategy
+    Given Git Town setting "sync-feature-strategy" is "rebase"
+    When I run "git-town sync --all"
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | main   | git fetch --prune --tags |
+      |        | git rebase origin/main   |
+      |        | git checkout alpha       |
+      | alpha  | git rebase main          |
+    And it prints the error:
+      """
+      CONFLICT (add/add): Merge conflict in conflicting_file
+      """
+    When I resolve the conflict in "conflicting_file"
+    And I run "git-town continue" and close the editor
+    Then it runs the commands
+      | BRANCH | COMMAND                                         |
+      | alpha  | git rebase --continue                           |
+      |        | git push --force-with-lease --force-if-includes |
+      |        | git checkout beta                               |
+      | beta   | git rebase main                                 |
+      |        | git add conflicting_file                        |
+      |        | git -c core.editor=true rebase --continue       |
+      |        | git push --force-with-lease --force-if-includes |
+      |        | git checkout main                               |
+      | main   | git push --tags                                 |
+    And it prints:
+      """
+      replayed the recorded conflict resolutions for conflicting_file in branch "beta"
+      """
+    And the current branch is now "main"
+    And these committed files exist now
+      | BRANCH | NAME             | CONTENT          |
+      | main   | conflicting_file | main content     |
+      | alpha  | conflicting_file | resolved content |
+      | beta   | conflicting_file | resolved content |
+
+  Scenario: Git replays recorded resolutions in merges outside of Git Town
+    When I run "git-town sync --all"
+    And I resolve the conflict in "conflicting_file"
+    And I run "git-town continue" and close the editor
+    And I run "git checkout -b gamma main~1"
+    And a file "conflicting_file" with content:
+      """
+      feature content
+      """
+    And I run "git add conflicting_file"
+    And I run "git commit -m gamma"
+    And I run "git merge --no-edit main"
+    Then it prints the error:
+      """
+      Resolved 'conflicting_file' using previous resolution.
+      """
+    And file "conflicting_file" now has content "resolved content"
+
+  Scenario: rerere disabled by the user
+    Given I ran "git config rerere.enabled false"
+    When I run "git-town sync --all"
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | main   | git fetch --prune --tags         |
+      |        | git rebase origin/main           |
+      |        | git checkout alpha               |
+      | alpha  | git merge --no-edit origin/alpha |
+      |        | git merge --no-edit main         |
+    And it prints the error:
+      """
+      CONFLICT (add/add): Merge conflict in conflicting_file
+      """
+    When I resolve the conflict in "conflicting_file"
+    And I run "git-town continue" and close the editor
+    Then it runs the commands
+      | BRANCH | COMMAND                         |
+      | alpha  | git commit --no-edit            |
+      |        | git push                        |
+      |        | git checkout beta               |
+      | beta   | git merge --no-edit origin/beta |
+      |        | git merge --no-edit main        |
+    And it prints the error:
+      """
+      CONFLICT (add/add): Merge conflict in conflicting_file
+      """
+    And the current branch is now "beta"
+    And a merge is now in progress
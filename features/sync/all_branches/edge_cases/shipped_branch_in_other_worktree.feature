@@ -0,0 +1,44 @@
+Feature: sync all branches when a shipped branch is checked out in another worktree
+
+  Background:
+    Given the feature branches "alpha" and "beta"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      |
+      | alpha  | local, origin | alpha commit |
+      | beta   | local, origin | beta commit  |
+    And origin ships the "beta" branch
+    And branch "beta" is active in another worktree
+    And the current branch is "alpha"
+    When I run "git-town sync --all"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | alpha  | git fetch --prune --tags         |
+      |        | git checkout main                |
+      | main   | git rebase origin/main           |
+      |        | git checkout alpha               |
+      | alpha  | git merge --no-edit origin/alpha |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      |        | git push --tags                  |
+    And it prints something like:
+      """
+      branch "beta" was deleted at the remote but is checked out in the worktree at .*development_worktree, please remove that worktree to delete the branch
+      """
+    And the current branch is still "alpha"
+    And the current branch in the other worktree is still "beta"
+    And the initial branches and lineage exist
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                         |
+      | alpha  | git reset --hard {{ sha 'alpha commit' }}       |
+      |        | git push --force-with-lease --force-if-includes |
+      |        | git checkout main                               |
+      | main   | git reset --hard {{ sha 'initial commit' }}     |
+      |        | git checkout alpha                              |
+    And the current branch is still "alpha"
+    And the current branch in the other worktree is still "beta"
+    And the initial branches and lineage exist
@@ -0,0 +1,149 @@
+Feature: check whether syncing would cause conflicts
+
+  Background:
+    Given the feature branches "alpha", "beta", and "delta"
+    And a feature branch "child" as a child of "beta"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE             | FILE NAME        | FILE CONTENT         |
+      | main   | origin        | main commit         | conflicting_file | main content         |
+      | alpha  | local         | local alpha commit  | alpha_file       | local alpha content  |
+      |        | origin        | origin alpha commit | alpha_file_2     | origin alpha content |
+      | beta   | local, origin | beta commit         | conflicting_file | beta content         |
+      | child  | local, origin | child commit        | child_file       | child content        |
+      | delta  | local, origin | delta commit        | delta_file       | delta content        |
+    And the current branch is "alpha"
+    And I ran "git fetch"
+
+  Scenario: merge sync strategy
+    When I run "git-town sync --all --check"
+    Then it runs no commands
+    And it prints the error:
+      """
+      main: syncs without conflicts
+      alpha: syncs without conflicts
+      beta: conflicts in conflicting_file
+      child: cannot be checked because its parent branch "beta" has conflicts
+      delta: syncs without conflicts
+      """
+    And it prints the error:
+      """
+      syncing would cause conflicts in beta
+      """
+    And the current branch is still "alpha"
+    And the initial commits exist
+    And the initial branches and lineage exist
+
+  Scenario: rebase sync strategy
+    Given Git Town setting "sync-feature-strategy" is "rebase"
+    When I run "git-town sync --all --check"
+    Then it runs no commands
+    And it prints the error:
+      """
+      main: syncs without conflicts
+      alpha: syncs without conflicts (approximate)
+      beta: conflicts in conflicting_file (approximate)
+      child: cannot be checked because its parent branch "beta" has conflicts
+      delta: syncs without conflicts, needs a force-push (approximate)
+      """
+    And it prints the error:
+      """
+      Rebases are simulated as a whole rather than commit by commit, so the outcomes marked as approximate can differ from the actual sync.
+      """
+    And it prints the error:
+      """
+      syncing would cause conflicts in beta
+      """
+    And the initial commits exist
+    And the initial branches and lineage exist
+
+  Scenario: no conflicts
+    When I run "git-town sync --check"
+    Then it runs no commands
+    And it prints:
+      """
+      main: syncs without conflicts
+      alpha: syncs without conflicts
+      """
+    And the current branch is still "alpha"
+    And the initial commits exist
+
+  Scenario: updates at origin that haven't been fetched
+    Given the commits
+      | BRANCH | LOCATION | MESSAGE             | FILE NAME        | FILE CONTENT         |
+      | delta  | origin   | origin delta commit | conflicting_file | origin delta content |
+    When I run "git-town sync --all --check"
+    Then it runs no commands
+    And it prints the error:
+      """
+      main: syncs without conflicts
+      alpha: syncs without conflicts
+      beta: conflicts in conflicting_file
+      child: cannot be checked because its parent branch "beta" has conflicts
+      delta: syncs without conflicts
+      """
+    And the current branch is still "alpha"
+    And these commits exist now
+      | BRANCH | LOCATION      | MESSAGE             | FILE NAME        | FILE CONTENT         |
+      | main   | origin        | main commit         | conflicting_file | main content         |
+      | alpha  | local         | local alpha commit  | alpha_file       | local alpha content  |
+      |        | origin        | origin alpha commit | alpha_file_2     | origin alpha content |
+      | beta   | local, origin | beta commit         | conflicting_file | beta content         |
+      | child  | local, origin | child commit        | child_file       | child content        |
+      | delta  | local, origin | delta commit        | delta_file       | delta content        |
+      |        | origin        | origin delta commit | conflicting_file | origin delta content |
+
+  Scenario: Git version without merge-tree --write-tree
+    Given Git has version "2.37.0"
+    When I run "git-town sync --all --check"
+    Then it runs no commands
+    And it prints the error:
+      """
+      --check requires Git 2.38 or higher
+      """
+    And the current branch is still "alpha"
+    And the initial commits exist
+
+  Scenario: unknown parent branch
+    Given Git Town parent setting for branch "delta" doesn't exist
+    When I run "git-town sync --all --check"
+    Then it runs no commands
+    And it prints the error:
+      """
+      main: syncs without conflicts
+      alpha: syncs without conflicts
+      beta: conflicts in conflicting_file
+      child: cannot be checked because its parent branch "beta" has conflicts
+      delta: up to date
+      """
+    And the current branch is still "alpha"
+    And the initial commits exist
+
+  Scenario Outline: combined with flags that change branches
+    When I run "git-town sync --check <FLAG>"
+    Then it runs no commands
+    And it prints the error:
+      """
+      cannot use --check together with --dry-run or --parallel
+      """
+    And the current branch is still "alpha"
+    And the initial commits exist
+
+    Examples:
+      | FLAG       |
+      | --dry-run  |
+      | --parallel |
+
+  Scenario: unfinished Git Town command
+    Given I ran "git-town sync --all"
+    When I run "git-town sync --all --check"
+    Then it runs no commands
+    And it prints the error:
+      """
+      main: up to date
+      alpha: up to date
+      beta: conflicts in conflicting_file
+      child: cannot be checked because its parent branch "beta" has conflicts
+      delta: syncs without conflicts
+      """
+    And the current branch is still "beta"
+    And a merge is now in progress
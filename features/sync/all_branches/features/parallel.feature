@@ -0,0 +1,88 @@
+Feature: sync all branches in parallel
+
+  Background:
+    Given the feature branches "alpha" and "gamma"
+    And a feature branch "beta" as a child of "alpha"
+    And a perennial branch "production"
+    And the commits
+      | BRANCH     | LOCATION      | MESSAGE                  | FILE NAME        | FILE CONTENT         |
+      | main       | origin        | main commit              | main_file        | main content         |
+      | alpha      | local         | local alpha commit       | alpha_file       | alpha content        |
+      |            | origin        | origin alpha commit      | alpha_file_2     | alpha content 2      |
+      | beta       | local, origin | beta commit              | beta_file        | beta content         |
+      | gamma      | local         | local gamma commit       | conflicting_file | local gamma content  |
+      |            | origin        | origin gamma commit      | conflicting_file | origin gamma content |
+      | production | origin        | origin production commit | production_file  | production content   |
+    And the current branch is "main"
+    When I run "git-town sync --all --parallel"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH     | COMMAND                          |
+      | main       | git fetch --prune --tags         |
+      |            | git rebase origin/main           |
+      |            | git checkout production          |
+      | production | git rebase origin/production     |
+      |            | git push origin alpha beta       |
+      |            | git checkout gamma               |
+      | gamma      | git merge --no-edit origin/gamma |
+    And it prints the error:
+      """
+      CONFLICT (add/add): Merge conflict in conflicting_file
+      """
+    And the current branch is now "gamma"
+    And a merge is now in progress
+
+  Scenario: resolve and continue
+    When I resolve the conflict in "conflicting_file"
+    And I run "git-town continue" and close the editor
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | gamma  | git commit --no-edit     |
+      |        | git merge --no-edit main |
+      |        | git push                 |
+      |        | git checkout main        |
+      | main   | git push --tags          |
+    And the current branch is now "main"
+    And all branches are now synchronized
+    And these commits exist now
+      | BRANCH     | LOCATION      | MESSAGE                                                |
+      | main       | local, origin | main commit                                            |
+      | alpha      | local, origin | local alpha commit                                     |
+      |            |               | origin alpha commit                                    |
+      |            |               | Merge remote-tracking branch 'origin/alpha' into alpha |
+      |            |               | main commit                                            |
+      |            |               | Merge branch 'main' into alpha                         |
+      | beta       | local, origin | beta commit                                            |
+      |            |               | local alpha commit                                     |
+      |            |               | origin alpha commit                                    |
+      |            |               | Merge remote-tracking branch 'origin/alpha' into alpha |
+      |            |               | main commit                                            |
+      |            |               | Merge branch 'main' into alpha                         |
+      |            |               | Merge branch 'alpha' into beta                         |
+      | gamma      | local, origin | local gamma commit                                     |
+      |            |               | origin gamma commit                                    |
+      |            |               | Merge remote-tracking branch 'origin/gamma' into gamma |
+      |            |               | main commit                                            |
+      |            |               | Merge branch 'main' into gamma                         |
+      | production | local, origin | origin production commit                               |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH     | COMMAND                                                                            |
+      | gamma      | git merge --abort                                                                  |
+      |            | git checkout beta                                                                  |
+      | beta       | git reset --hard {{ sha 'beta commit' }}                                           |
+      |            | git push --force-with-lease --force-if-includes                                    |
+      |            | git checkout alpha                                                                 |
+      | alpha      | git reset --hard {{ sha 'local alpha commit' }}                                    |
+      |            | git push --force-with-lease origin {{ sha-in-origin 'origin alpha commit' }}:alpha |
+      |            | git checkout main                                                                  |
+      | main       | git reset --hard {{ sha 'initial commit' }}                                        |
+      |            | git checkout production                                                            |
+      | production | git reset --hard {{ sha 'initial commit' }}                                        |
+      |            | git checkout main                                                                  |
+    And the current branch is now "main"
+    And the initial commits exist
+    And the initial branches and lineage exist
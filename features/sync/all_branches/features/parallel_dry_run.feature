@@ -0,0 +1,31 @@
+Feature: dry-run syncing all branches in parallel
+
+  Background:
+    Given the feature branches "alpha" and "beta"
+    And the commits
+      | BRANCH | LOCATION | MESSAGE             | FILE NAME    | FILE CONTENT    |
+      | main   | origin   | main commit         | main_file    | main content    |
+      | alpha  | local    | local alpha commit  | alpha_file   | alpha content   |
+      |        | origin   | origin alpha commit | alpha_file_2 | alpha content 2 |
+      | beta   | local    | beta commit         | beta_file    | beta content    |
+    And the current branch is "main"
+    When I run "git-town sync --all --parallel --dry-run"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | main   | git fetch --prune --tags         |
+      |        | git rebase origin/main           |
+      |        | git checkout alpha               |
+      | alpha  | git merge --no-edit origin/alpha |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      |        | git checkout beta                |
+      | beta   | git merge --no-edit origin/beta  |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      |        | git checkout main                |
+      | main   | git push --tags                  |
+    And the current branch is still "main"
+    And the initial commits exist
+    And the initial branches and lineage exist
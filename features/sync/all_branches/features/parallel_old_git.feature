@@ -0,0 +1,28 @@
+Feature: sync all branches in parallel with a Git version that cannot merge in memory
+
+  Background:
+    Given Git has version "2.37.0"
+    And a feature branch "alpha"
+    And the commits
+      | BRANCH | LOCATION | MESSAGE      | FILE NAME  |
+      | alpha  | local    | alpha commit | alpha_file |
+    And the current branch is "main"
+    When I run "git-town sync --all --parallel"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | main   | git fetch --prune --tags         |
+      |        | git rebase origin/main           |
+      |        | git checkout alpha               |
+      | alpha  | git merge --no-edit origin/alpha |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      |        | git checkout main                |
+      | main   | git push --tags                  |
+    And it prints:
+      """
+      Syncing the branches one at a time because --parallel requires Git 2.38 or higher.
+      """
+    And the current branch is still "main"
+    And all branches are now synchronized
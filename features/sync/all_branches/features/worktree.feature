@@ -0,0 +1,75 @@
+Feature: sync all branches including a branch checked out in another worktree
+
+  Background:
+    Given the feature branches "alpha" and "beta"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE            |
+      | main   | origin        | main commit        |
+      | alpha  | local, origin | alpha commit       |
+      | beta   | local         | local beta commit  |
+      |        | origin        | origin beta commit |
+    And branch "beta" is active in another worktree
+    And an uncommitted file in the other worktree
+    And the current branch is "alpha"
+    When I run "git-town sync --all"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | alpha  | git fetch --prune --tags         |
+      |        | git checkout main                |
+      | main   | git rebase origin/main           |
+      |        | git checkout alpha               |
+      | alpha  | git merge --no-edit origin/alpha |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      | beta   | git add -A                       |
+      |        | git stash                        |
+      |        | git merge --no-edit origin/beta  |
+      |        | git merge --no-edit main         |
+      |        | git push                         |
+      |        | git stash pop                    |
+      | alpha  | git push --tags                  |
+    And it prints something like:
+      """
+      synced branch "beta" in the worktree at .*development_worktree
+      """
+    And the current branch is still "alpha"
+    And the current branch in the other worktree is still "beta"
+    And the uncommitted file still exists in the other worktree
+    And these commits exist now
+      | BRANCH | LOCATION                | MESSAGE                                              |
+      | main   | local, origin, worktree | main commit                                          |
+      | alpha  | local, origin           | alpha commit                                         |
+      |        |                         | main commit                                          |
+      |        |                         | Merge branch 'main' into alpha                       |
+      | beta   | origin, worktree        | local beta commit                                    |
+      |        |                         | origin beta commit                                   |
+      |        |                         | Merge remote-tracking branch 'origin/beta' into beta |
+      |        |                         | main commit                                          |
+      |        |                         | Merge branch 'main' into beta                        |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                                                          |
+      | alpha  | git reset --hard {{ sha 'alpha commit' }}                                        |
+      |        | git push --force-with-lease --force-if-includes                                  |
+      | beta   | git add -A                                                                       |
+      |        | git stash                                                                        |
+      |        | git reset --hard {{ sha 'local beta commit' }}                                   |
+      |        | git push --force-with-lease origin {{ sha-in-origin 'origin beta commit' }}:beta |
+      |        | git stash pop                                                                    |
+      | alpha  | git checkout main                                                                |
+      | main   | git reset --hard {{ sha 'initial commit' }}                                      |
+      |        | git checkout alpha                                                               |
+    And the current branch is still "alpha"
+    And the current branch in the other worktree is still "beta"
+    And the uncommitted file still exists in the other worktree
+    And these commits exist now
+      | BRANCH | LOCATION      | MESSAGE            |
+      | main   | origin        | main commit        |
+      | alpha  | local, origin | alpha commit       |
+      | beta   | origin        | origin beta commit |
+      |        | worktree      | local beta commit  |
+    And the initial branches and lineage exist
@@ -0,0 +1,73 @@
+@skipWindows
+Feature: handle conflicts between the current feature branch and the main branch using the "compress" sync-feature strategy
+
+  Background:
+    Given Git Town setting "sync-feature-strategy" is "compress"
+    And my repo does not have an origin
+    And the current branch is a local feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE                      | FILE NAME        | FILE CONTENT    |
+      | main    | local    | conflicting main commit      | conflicting_file | main content    |
+      | feature | local    | conflicting feature commit 1 | conflicting_file | feature content |
+      |         | local    | conflicting feature commit 2 | conflicting_file | more content    |
+    And an uncommitted file
+    When I run "git-town sync"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                                          |
+      | feature | git add -A                                       |
+      |         | git stash                                        |
+      |         | git reset --soft {{ full-sha 'initial commit' }} |
+      |         | git commit -m "conflicting feature commit 1"     |
+      |         | git rebase main                                  |
+    And it prints the error:
+      """
+      CONFLICT (add/add): Merge conflict in conflicting_file
+      """
+    And it prints the error:
+      """
+      To continue after having resolved conflicts, run "git town continue".
+      To go back to where you started, run "git town undo".
+      To continue by skipping the current branch, run "git town skip".
+      """
+    And the current branch is still "feature"
+    And the uncommitted file is stashed
+    And a rebase is now in progress
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH  | COMMAND                                                              |
+      | feature | git rebase --abort                                                   |
+      |         | git reset --hard {{ sha-before-run 'conflicting feature commit 2' }} |
+      |         | git stash pop                                                        |
+    And the current branch is still "feature"
+    And the uncommitted file still exists
+    And no rebase is in progress
+    And these commits exist now
+      | BRANCH  | LOCATION | MESSAGE                      |
+      | main    | local    | conflicting main commit      |
+      | feature | local    | conflicting feature commit 1 |
+      |         |          | conflicting feature commit 2 |
+
+  Scenario: resolve and continue
+    When I resolve the conflict in "conflicting_file"
+    And I run "git-town continue" and enter "resolved commit" for the commit message
+    Then it runs the commands
+      | BRANCH  | COMMAND               |
+      | feature | git rebase --continue |
+      |         | git stash pop         |
+    And all branches are now synchronized
+    And the current branch is still "feature"
+    And no rebase is in progress
+    And the uncommitted file still exists
+    And these commits exist now
+      | BRANCH  | LOCATION | MESSAGE                 |
+      | main    | local    | conflicting main commit |
+      | feature | local    | conflicting main commit |
+      |         |          | resolved commit         |
+    And these committed files exist now
+      | BRANCH  | NAME             | CONTENT          |
+      | main    | conflicting_file | main content     |
+      | feature | conflicting_file | resolved content |
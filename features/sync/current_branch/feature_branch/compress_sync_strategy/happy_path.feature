@@ -0,0 +1,60 @@
+Feature: sync the current feature branch using the "compress" sync-feature strategy
+
+  Background:
+    Given Git Town setting "sync-feature-strategy" is "compress"
+    And the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE                | FILE NAME    | FILE CONTENT    |
+      | main    | local    | local main commit      | main_file    | main content    |
+      |         | origin   | origin main commit     | origin_file  | origin content  |
+      | feature | local    | local feature commit 1 | feature_file | feature content |
+      |         | local    | local feature commit 2 | feature_file | more content    |
+      |         | origin   | origin feature commit  | remote_file  | remote content  |
+    When I run "git-town sync"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                                          |
+      | feature | git fetch --prune --tags                         |
+      |         | git checkout main                                |
+      | main    | git rebase origin/main                           |
+      |         | git push                                         |
+      |         | git checkout feature                             |
+      | feature | git merge --no-edit origin/feature               |
+      |         | git reset --soft {{ full-sha 'initial commit' }} |
+      |         | git commit -m "local feature commit 1"           |
+      |         | git rebase main                                  |
+      |         | git push --force-with-lease --force-if-includes  |
+    And all branches are now synchronized
+    And the current branch is still "feature"
+    And these commits exist now
+      | BRANCH  | LOCATION      | MESSAGE                |
+      | main    | local, origin | origin main commit     |
+      |         |               | local main commit      |
+      | feature | local, origin | origin main commit     |
+      |         |               | local main commit      |
+      |         |               | local feature commit 1 |
+    And these committed files exist now
+      | BRANCH  | NAME         | CONTENT        |
+      | main    | main_file    | main content   |
+      |         | origin_file  | origin content |
+      | feature | feature_file | more content   |
+      |         | main_file    | main content   |
+      |         | origin_file  | origin content |
+      |         | remote_file  | remote content |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH  | COMMAND                                                                                           |
+      | feature | git reset --hard {{ sha-before-run 'local feature commit 2' }}                                    |
+      |         | git push --force-with-lease origin {{ sha-in-origin-before-run 'origin feature commit' }}:feature |
+    And the current branch is still "feature"
+    And these commits exist now
+      | BRANCH  | LOCATION      | MESSAGE                |
+      | main    | local, origin | origin main commit     |
+      |         |               | local main commit      |
+      | feature | local         | local feature commit 1 |
+      |         |               | local feature commit 2 |
+      |         | origin        | origin feature commit  |
+    And the initial branches and lineage exist
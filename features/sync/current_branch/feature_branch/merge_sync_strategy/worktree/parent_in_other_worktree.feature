@@ -22,30 +22,50 @@ Feature: sync a branch whose parent is active in another worktree
       |        | git checkout main                 |
       | main   | git rebase origin/main            |
       |        | git push                          |
-      |        | git checkout child                |
+      | parent | git merge --no-edit origin/parent |
+      |        | git merge --no-edit main          |
+      |        | git push                          |
+      | main   | git checkout child                |
       | child  | git merge --no-edit origin/child  |
-      |        | git merge --no-edit origin/parent |
+      |        | git merge --no-edit parent        |
       |        | git push                          |
+    And it prints something like:
+      """
+      synced branch "parent" in the worktree at .*development_worktree
+      """
     And the current branch is still "child"
+    And the current branch in the other worktree is still "parent"
     And these commits exist now
-      | BRANCH | LOCATION                | MESSAGE                                                 |
-      | main   | local, origin, worktree | origin main commit                                      |
-      |        |                         | local main commit                                       |
-      | child  | local, origin           | local child commit                                      |
-      |        |                         | origin child commit                                     |
-      |        |                         | Merge remote-tracking branch 'origin/child' into child  |
-      |        |                         | origin parent commit                                    |
-      |        |                         | Merge remote-tracking branch 'origin/parent' into child |
-      | parent | origin                  | origin parent commit                                    |
-      |        | worktree                | local parent commit                                     |
+      | BRANCH | LOCATION                | MESSAGE                                                  |
+      | main   | local, origin, worktree | origin main commit                                       |
+      |        |                         | local main commit                                        |
+      | child  | local, origin           | local child commit                                       |
+      |        |                         | origin child commit                                      |
+      |        |                         | Merge remote-tracking branch 'origin/child' into child   |
+      |        |                         | local parent commit                                      |
+      |        |                         | origin parent commit                                     |
+      |        |                         | Merge remote-tracking branch 'origin/parent' into parent |
+      |        |                         | origin main commit                                       |
+      |        |                         | local main commit                                        |
+      |        |                         | Merge branch 'main' into parent                          |
+      |        |                         | Merge branch 'parent' into child                         |
+      | parent | origin, worktree        | local parent commit                                      |
+      |        |                         | origin parent commit                                     |
+      |        |                         | Merge remote-tracking branch 'origin/parent' into parent |
+      |        |                         | origin main commit                                       |
+      |        |                         | local main commit                                        |
+      |        |                         | Merge branch 'main' into parent                          |
 
   Scenario: undo
     When I run "git-town undo"
     Then it runs the commands
-      | BRANCH | COMMAND                                                                            |
-      | child  | git reset --hard {{ sha 'local child commit' }}                                    |
-      |        | git push --force-with-lease origin {{ sha-in-origin 'origin child commit' }}:child |
+      | BRANCH | COMMAND                                                                              |
+      | child  | git reset --hard {{ sha 'local child commit' }}                                      |
+      |        | git push --force-with-lease origin {{ sha-in-origin 'origin child commit' }}:child   |
+      | parent | git reset --hard {{ sha 'local parent commit' }}                                     |
+      |        | git push --force-with-lease origin {{ sha-in-origin 'origin parent commit' }}:parent |
     And the current branch is still "child"
+    And the current branch in the other worktree is still "parent"
     And these commits exist now
       | BRANCH | LOCATION                | MESSAGE              |
       | main   | local, origin, worktree | origin main commit   |
@@ -25,6 +25,7 @@ Feature: display all executed Git commands
       |          | backend  | git branch -vva --sort=refname                     |
       |          | backend  | git rev-parse --verify --abbrev-ref @{-1}          |
       | branch-2 | frontend | git checkout main                                  |
+      |          | backend  | git config --get rerere.enabled                    |
       | main     | frontend | git rebase origin/main                             |
       |          | backend  | git rev-list --left-right main...origin/main       |
       | main     | frontend | git checkout branch-2                              |
@@ -40,7 +41,7 @@ Feature: display all executed Git commands
       |          | backend  | git stash list                                     |
     And it prints:
       """
-      Ran 25 shell commands.
+      Ran 26 shell commands.
       """
     And the current branch is now "main"
     And the branches are now
@@ -16,17 +16,25 @@ Feature: Sync a feature branch that is in another worktree than the main branch
     Then it runs the commands
       | BRANCH  | COMMAND                                         |
       | feature | git fetch --prune --tags                        |
-      |         | git rebase origin/main                          |
+      | main    | git rebase origin/main                          |
+      |         | git push                                        |
+      | feature | git rebase main                                 |
       |         | git push --force-with-lease --force-if-includes |
       |         | git rebase origin/feature                       |
       |         | git push --force-with-lease --force-if-includes |
+    And it prints something like:
+      """
+      synced branch "main" in the worktree at .*developer
+      """
+    And the current branch is still "main"
     And the current branch in the other worktree is still "feature"
     And these commits exist now
       | BRANCH  | LOCATION         | MESSAGE               |
-      | main    | local            | local main commit     |
-      |         | origin           | origin main commit    |
+      | main    | local, origin    | origin main commit    |
+      |         |                  | local main commit     |
       | feature | origin, worktree | origin feature commit |
       |         |                  | origin main commit    |
+      |         |                  | local main commit     |
       |         |                  | local feature commit  |
 
   Scenario: undo
@@ -37,9 +45,9 @@ Feature: Sync a feature branch that is in another worktree than the main branch
       |         | git push --force-with-lease origin {{ sha-in-origin 'origin feature commit' }}:feature |
     And the current branch in the other worktree is still "feature"
     And these commits exist now
-      | BRANCH  | LOCATION | MESSAGE               |
-      | main    | local    | local main commit     |
-      |         | origin   | origin main commit    |
-      | feature | origin   | origin feature commit |
-      |         | worktree | local feature commit  |
+      | BRANCH  | LOCATION      | MESSAGE               |
+      | main    | local, origin | origin main commit    |
+      |         |               | local main commit     |
+      | feature | origin        | origin feature commit |
+      |         | worktree      | local feature commit  |
     And the initial branches and lineage exist
@@ -21,21 +21,35 @@ Feature: sync a branch whose parent is active in another worktree
       |        | git checkout main                               |
       | main   | git rebase origin/main                          |
       |        | git push                                        |
-      |        | git checkout child                              |
-      | child  | git rebase origin/parent                        |
+      | parent | git rebase main                                 |
+      |        | git push --force-with-lease --force-if-includes |
+      |        | git rebase origin/parent                        |
+      |        | git push --force-with-lease --force-if-includes |
+      | main   | git checkout child                              |
+      | child  | git rebase parent                               |
       |        | git push --force-with-lease --force-if-includes |
       |        | git rebase origin/child                         |
       |        | git push --force-with-lease --force-if-includes |
+    And it prints something like:
+      """
+      synced branch "parent" in the worktree at .*development_worktree
+      """
     And the current branch is still "child"
+    And the current branch in the other worktree is still "parent"
     And these commits exist now
       | BRANCH | LOCATION                | MESSAGE              |
       | main   | local, origin, worktree | origin main commit   |
       |        |                         | local main commit    |
       | child  | local, origin           | origin child commit  |
       |        |                         | origin parent commit |
+      |        |                         | origin main commit   |
+      |        |                         | local main commit    |
+      |        |                         | local parent commit  |
       |        |                         | local child commit   |
-      | parent | origin                  | origin parent commit |
-      |        | worktree                | local parent commit  |
+      | parent | origin, worktree        | origin parent commit |
+      |        |                         | origin main commit   |
+      |        |                         | local main commit    |
+      |        |                         | local parent commit  |
 
   Scenario: undo
     When I run "git-town undo"
@@ -0,0 +1,40 @@
+Feature: sync a prototype branch without pushing it
+
+  Background:
+    Given the current branch is a local prototype branch "prototype"
+    And the commits
+      | BRANCH    | LOCATION | MESSAGE                |
+      | main      | origin   | origin main commit     |
+      | prototype | local    | local prototype commit |
+    When I run "git-town sync"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH    | COMMAND                  |
+      | prototype | git fetch --prune --tags |
+      |           | git checkout main        |
+      | main      | git rebase origin/main   |
+      |           | git checkout prototype   |
+      | prototype | git merge --no-edit main |
+    And the current branch is still "prototype"
+    And these commits exist now
+      | BRANCH    | LOCATION      | MESSAGE                            |
+      | main      | local, origin | origin main commit                 |
+      | prototype | local         | local prototype commit             |
+      |           |               | origin main commit                 |
+      |           |               | Merge branch 'main' into prototype |
+    And the branches are now
+      | REPOSITORY | BRANCHES        |
+      | local      | main, prototype |
+      | origin     | main            |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH    | COMMAND                                             |
+      | prototype | git checkout main                                   |
+      | main      | git reset --hard {{ sha 'initial commit' }}         |
+      |           | git checkout prototype                              |
+      | prototype | git reset --hard {{ sha 'local prototype commit' }} |
+    And the current branch is still "prototype"
+    And the initial branches and lineage exist
@@ -0,0 +1,51 @@
+Feature: adopt the lineage shared through the origin remote
+
+  Background:
+    Given Git Town setting "share-lineage" is "true"
+    And a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And a feature branch "gamma"
+    And Git Town parent setting for branch "beta" doesn't exist
+    And the current branch is "beta"
+    And origin shares this lineage
+      | BRANCH | PARENT |
+      | alpha  | main   |
+      | beta   | alpha  |
+      | gamma  | alpha  |
+    When I run "git-town sync"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                                                                                                                                                          |
+      | beta   | git fetch --prune --tags                                                                                                                                         |
+      |        | git fetch origin +refs/git-town/*:refs/git-town/remotes/origin/*                                                                                                 |
+      |        | git checkout main                                                                                                                                                |
+      | main   | git rebase origin/main                                                                                                                                           |
+      |        | git checkout alpha                                                                                                                                               |
+      | alpha  | git merge --no-edit origin/alpha                                                                                                                                 |
+      |        | git merge --no-edit main                                                                                                                                         |
+      |        | git checkout beta                                                                                                                                                |
+      | beta   | git merge --no-edit origin/beta                                                                                                                                  |
+      |        | git merge --no-edit alpha                                                                                                                                        |
+      |        | git push --force-with-lease=refs/git-town/lineage:7bfb7b08d54f36e8d0a9fa50cc7845408f2649fa origin 2f33930b6106f0d18090634113395fe7994cb01a:refs/git-town/lineage |
+    And it prints:
+      """
+      Using the shared parent branch "alpha" for branch "beta".
+      """
+    And the current branch is still "beta"
+    And this lineage exists now
+      | BRANCH | PARENT |
+      | alpha  | main   |
+      | beta   | alpha  |
+      | gamma  | main   |
+    And origin now shares this lineage
+      | BRANCH | PARENT |
+      | alpha  | main   |
+      | beta   | alpha  |
+      | gamma  | main   |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs no commands
+    And the current branch is still "beta"
+    And the initial branches and lineage exist
@@ -0,0 +1,21 @@
+Feature: don't share the lineage in offline mode
+
+  Scenario: offline mode is enabled
+    Given Git Town setting "share-lineage" is "true"
+    And offline mode is enabled
+    And the current branch is a feature branch "feature"
+    And origin shares this lineage
+      | BRANCH | PARENT |
+      | other  | main   |
+    When I run "git-town sync"
+    Then it runs the commands
+      | BRANCH  | COMMAND                            |
+      | feature | git checkout main                  |
+      | main    | git rebase origin/main             |
+      |         | git checkout feature               |
+      | feature | git merge --no-edit origin/feature |
+      |         | git merge --no-edit main           |
+    And the current branch is still "feature"
+    And origin still shares this lineage
+      | BRANCH | PARENT |
+      | other  | main   |
@@ -0,0 +1,62 @@
+Feature: publish the lineage through the origin remote
+
+  Background:
+    Given Git Town setting "share-lineage" is "true"
+    And a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | alpha  | local, origin | alpha commit | alpha_file |
+      | beta   | local         | beta commit  | beta_file  |
+    And the current branch is "beta"
+    When I run "git-town sync"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                                                                                                                  |
+      | beta   | git fetch --prune --tags                                                                                                 |
+      |        | git fetch origin +refs/git-town/*:refs/git-town/remotes/origin/*                                                         |
+      |        | git checkout main                                                                                                        |
+      | main   | git rebase origin/main                                                                                                   |
+      |        | git checkout alpha                                                                                                       |
+      | alpha  | git merge --no-edit origin/alpha                                                                                         |
+      |        | git merge --no-edit main                                                                                                 |
+      |        | git checkout beta                                                                                                        |
+      | beta   | git merge --no-edit origin/beta                                                                                          |
+      |        | git merge --no-edit alpha                                                                                                |
+      |        | git push                                                                                                                 |
+      |        | git push --force-with-lease=refs/git-town/lineage: origin eeaf0f86e8afc3067a0e16069ba0a66af1ef4f3d:refs/git-town/lineage |
+    And the current branch is still "beta"
+    And origin now shares this lineage
+      | BRANCH | PARENT |
+      | alpha  | main   |
+      | beta   | alpha  |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                                                      |
+      | beta   | git reset --hard {{ sha 'beta commit' }}                                     |
+      |        | git push --force-with-lease origin {{ sha-in-origin 'initial commit' }}:beta |
+    And the current branch is still "beta"
+    And the initial commits exist
+    And the initial branches and lineage exist
+    And origin still shares this lineage
+      | BRANCH | PARENT |
+      | alpha  | main   |
+      | beta   | alpha  |
+
+  Scenario: nothing changed since the last sync
+    When I run "git-town sync"
+    Then it runs the commands
+      | BRANCH | COMMAND                                                          |
+      | beta   | git fetch --prune --tags                                         |
+      |        | git fetch origin +refs/git-town/*:refs/git-town/remotes/origin/* |
+      |        | git checkout main                                                |
+      | main   | git rebase origin/main                                           |
+      |        | git checkout alpha                                               |
+      | alpha  | git merge --no-edit origin/alpha                                 |
+      |        | git merge --no-edit main                                         |
+      |        | git checkout beta                                                |
+      | beta   | git merge --no-edit origin/beta                                  |
+      |        | git merge --no-edit alpha                                        |
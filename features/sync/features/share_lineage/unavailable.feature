@@ -0,0 +1,30 @@
+Feature: sync with the local lineage if the shared lineage cannot be loaded
+
+  Background:
+    Given Git Town setting "share-lineage" is "true"
+    And the current branch is a feature branch "feature"
+    And I ran "git push origin main:refs/git-town/lineage"
+    When I run "git-town sync"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                                                          |
+      | feature | git fetch --prune --tags                                         |
+      |         | git fetch origin +refs/git-town/*:refs/git-town/remotes/origin/* |
+      |         | git checkout main                                                |
+      | main    | git rebase origin/main                                           |
+      |         | git checkout feature                                             |
+      | feature | git merge --no-edit origin/feature                               |
+      |         | git merge --no-edit main                                         |
+    And it prints:
+      """
+      Cannot load the shared lineage, continuing with the local lineage:
+      """
+    And the current branch is still "feature"
+    And the initial branches and lineage exist
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs no commands
+    And the current branch is still "feature"
+    And the initial branches and lineage exist
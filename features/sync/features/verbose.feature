@@ -25,6 +25,7 @@ Feature: display all executed Git commands
       |         | backend  | git branch -vva --sort=refname                     |
       |         | backend  | git rev-parse --verify --abbrev-ref @{-1}          |
       | feature | frontend | git checkout main                                  |
+      |         | backend  | git config --get rerere.enabled                    |
       | main    | frontend | git rebase origin/main                             |
       |         | backend  | git rev-list --left-right main...origin/main       |
       | main    | frontend | git push                                           |
@@ -40,6 +41,6 @@ Feature: display all executed Git commands
       |         | backend  | git stash list                                     |
     And it prints:
       """
-      Ran 25 shell commands.
+      Ran 26 shell commands.
       """
     And all branches are now synchronized
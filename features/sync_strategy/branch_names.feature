@@ -0,0 +1,23 @@
+Feature: set the sync strategy of the given branches
+
+  Background:
+    Given the local feature branches "alpha" and "beta"
+    And a parked branch "parked"
+    When I run "git-town sync-strategy compress alpha parked"
+
+  Scenario: result
+    Then it runs no commands
+    And it prints:
+      """
+      branch "alpha" now syncs using the "compress" strategy
+      branch "parked" now syncs using the "compress" strategy
+      """
+    And branch "alpha" now has the sync strategy "compress"
+    And branch "parked" now has the sync strategy "compress"
+    And branch "beta" still has no sync strategy
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs no commands
+    And branch "alpha" now has no sync strategy
+    And branch "parked" now has no sync strategy
@@ -0,0 +1,27 @@
+Feature: set the sync strategy of the current branch
+
+  Background:
+    Given the current branch is a feature branch "branch"
+    And an uncommitted file
+    When I run "git-town sync-strategy rebase"
+
+  Scenario: result
+    Then it runs no commands
+    And it prints:
+      """
+      branch "branch" now syncs using the "rebase" strategy
+      """
+    And the current branch is still "branch"
+    And branch "branch" now has the sync strategy "rebase"
+    And the uncommitted file still exists
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND       |
+      | branch | git add -A    |
+      |        | git stash     |
+      |        | git stash pop |
+    And the current branch is still "branch"
+    And branch "branch" now has no sync strategy
+    And the uncommitted file still exists
@@ -0,0 +1,19 @@
+Feature: remove the sync strategy of a branch
+
+  Background:
+    Given the current branch is a feature branch "branch"
+    And branch "branch" has the sync strategy "rebase"
+    When I run "git-town sync-strategy default"
+
+  Scenario: result
+    Then it runs no commands
+    And it prints:
+      """
+      branch "branch" now syncs using the sync-feature-strategy
+      """
+    And branch "branch" now has no sync strategy
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs no commands
+    And branch "branch" now has the sync strategy "rebase"
@@ -0,0 +1,10 @@
+Feature: cannot set the sync strategy of the main branch
+
+  Scenario: on the main branch
+    When I run "git-town sync-strategy rebase"
+    Then it runs no commands
+    And it prints the error:
+      """
+      branch "main" is a main branch, only feature, parked, and prototype branches can have their own sync strategy
+      """
+    And branch "main" still has no sync strategy
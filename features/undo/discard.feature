@@ -0,0 +1,46 @@
+Feature: undo after discarding an unfinished command
+
+  Background:
+    Given the feature branches "feature" and "other"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE                    | FILE NAME        | FILE CONTENT    |
+      | main    | local    | conflicting main commit    | conflicting_file | main content    |
+      | feature | local    | conflicting feature commit | conflicting_file | feature content |
+    And the current branch is "feature"
+    And I ran "git-town observe other"
+    And I ran "git-town sync"
+    And I ran "git merge --abort"
+
+  Scenario: discard the unfinished command
+    When I run "git-town diff-parent" and enter into the dialog:
+      | DIALOG            | KEYS    |
+      | choose what to do | 4 enter |
+    Then it prints:
+      """
+      Handle unfinished command: discard
+      """
+    When I run "git-town undo"
+    Then branch "other" is now a feature branch
+    When I run "git-town undo"
+    Then it prints:
+      """
+      nothing to undo
+      """
+
+  Scenario: reset the Git Town status
+    When I run "git-town status reset"
+    Then it prints:
+      """
+      Runstate file deleted.
+      """
+    When I run "git-town undo"
+    Then it prints:
+      """
+      nothing to undo
+      """
+    And branch "other" is still observed
+    When I run "git-town undo --list"
+    Then it prints:
+      """
+      nothing to undo
+      """
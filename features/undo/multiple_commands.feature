@@ -0,0 +1,32 @@
+Feature: undo several Git Town commands one after the other
+
+  Background:
+    Given the feature branches "alpha" and "beta"
+    And the current branch is "alpha"
+    And I ran "git-town park"
+    And I ran "git-town observe beta"
+
+  Scenario: list the commands that can be undone
+    When I run "git-town undo --list"
+    Then it prints something like:
+      """
+      1. observe \(.+\): no branch changes
+      2. park \(.+\): no branch changes
+      """
+
+  Scenario: undo the most recent command
+    When I run "git-town undo"
+    Then branch "alpha" is now parked
+    And branch "beta" is now a feature branch
+    And there are now no observed branches
+
+  Scenario: undo both commands
+    When I run "git-town undo"
+    And I run "git-town undo"
+    Then there are now no parked branches
+    And there are now no observed branches
+    When I run "git-town undo"
+    Then it prints:
+      """
+      nothing to undo
+      """
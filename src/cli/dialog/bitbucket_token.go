@@ -0,0 +1,36 @@
+package dialog
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v14/src/cli/dialog/components"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+)
+
+const (
+	bitbucketTokenTitle = `Bitbucket API token`
+	bitbucketTokenHelp  = `
+If you have an access token or app password for Bitbucket,
+and want to ship branches from the CLI,
+please enter it now.
+
+Enter app passwords in the format "username:app-password".
+
+It's okay to leave this empty.
+
+`
+)
+
+// BitbucketToken lets the user enter the Bitbucket API token.
+func BitbucketToken(oldValue configdomain.BitbucketToken, inputs components.TestInput) (configdomain.BitbucketToken, bool, error) {
+	token, aborted, err := components.TextField(components.TextFieldArgs{
+		ExistingValue: oldValue.String(),
+		Help:          bitbucketTokenHelp,
+		Prompt:        "Your Bitbucket API token: ",
+		TestInput:     inputs,
+		Title:         bitbucketTokenTitle,
+	})
+	fmt.Printf(messages.BitbucketToken, components.FormattedSecret(token, aborted))
+	return configdomain.BitbucketToken(token), aborted, err
+}
@@ -27,6 +27,7 @@ func HostingPlatform(existingValue configdomain.HostingPlatform, inputs componen
 		hostingPlatformGitea,
 		hostingPlatformGitHub,
 		hostingPlatformGitLab,
+		hostingPlatformAzureDevOps,
 	}
 	cursor := indexOfHostingPlatform(existingValue, entries)
 	newValue, aborted, err := components.RadioList(entries, cursor, hostingPlatformTitle, HostingPlatformHelp, inputs)
@@ -37,17 +38,20 @@ func HostingPlatform(existingValue configdomain.HostingPlatform, inputs componen
 type hostingPlatformEntry string
 
 const (
-	hostingPlatformAutoDetect hostingPlatformEntry = "auto-detect"
-	hostingPlatformBitBucket  hostingPlatformEntry = "BitBucket"
-	hostingPlatformGitea      hostingPlatformEntry = "Gitea"
-	hostingPlatformGitHub     hostingPlatformEntry = "Github"
-	hostingPlatformGitLab     hostingPlatformEntry = "GitLab"
+	hostingPlatformAutoDetect  hostingPlatformEntry = "auto-detect"
+	hostingPlatformAzureDevOps hostingPlatformEntry = "Azure DevOps"
+	hostingPlatformBitBucket   hostingPlatformEntry = "BitBucket"
+	hostingPlatformGitea       hostingPlatformEntry = "Gitea"
+	hostingPlatformGitHub      hostingPlatformEntry = "Github"
+	hostingPlatformGitLab      hostingPlatformEntry = "GitLab"
 )
 
 func (self hostingPlatformEntry) HostingPlatform() configdomain.HostingPlatform {
 	switch self {
 	case hostingPlatformAutoDetect:
 		return configdomain.HostingPlatformNone
+	case hostingPlatformAzureDevOps:
+		return configdomain.HostingPlatformAzureDevOps
 	case hostingPlatformBitBucket:
 		return configdomain.HostingPlatformBitbucket
 	case hostingPlatformGitea:
@@ -73,6 +77,8 @@ func newHostingPlatformEntry(hosting configdomain.HostingPlatform) hostingPlatfo
 	switch hosting {
 	case configdomain.HostingPlatformNone:
 		return hostingPlatformAutoDetect
+	case configdomain.HostingPlatformAzureDevOps:
+		return hostingPlatformAzureDevOps
 	case configdomain.HostingPlatformBitbucket:
 		return hostingPlatformBitBucket
 	case configdomain.HostingPlatformGitea:
@@ -0,0 +1,108 @@
+package dialog
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/git-town/git-town/v14/src/cli/dialog/components"
+	"github.com/git-town/git-town/v14/src/cli/format"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+)
+
+const (
+	inferredLineageTitle        = `Inferred lineage`
+	inferredLineageHelpTemplate = `
+Some of your local branches have no parent branch yet.
+Based on the commits in your branches and your open proposals,
+Git Town proposes this lineage:
+
+%s
+
+`
+)
+
+const (
+	InferredLineageOptionAccept InferredLineageOption = `yes, save the inferred lineage`
+	InferredLineageOptionEdit   InferredLineageOption = `edit the parent of each branch`
+	InferredLineageOptionSkip   InferredLineageOption = `no, I will enter the parent branches when needed`
+)
+
+// InferredLineage lets the user accept, edit, or reject the given inferred lineage.
+// Provides the lineage entries to save and the branches that the user wants to make perennial.
+func InferredLineage(args InferredLineageArgs) (configdomain.Lineage, gitdomain.LocalBranchNames, bool, error) {
+	combined := maps.Clone(args.Lineage)
+	if combined == nil {
+		combined = configdomain.Lineage{}
+	}
+	maps.Copy(combined, args.Inferred)
+	entries := []InferredLineageOption{
+		InferredLineageOptionAccept,
+		InferredLineageOptionEdit,
+		InferredLineageOptionSkip,
+	}
+	help := fmt.Sprintf(inferredLineageHelpTemplate, format.BranchLineage(combined))
+	selection, aborted, err := components.RadioList(entries, 0, inferredLineageTitle, help, args.DialogTestInputs.Next())
+	fmt.Printf(messages.LineageInferredSelection, components.FormattedSelection(selection.Short(), aborted))
+	if err != nil || aborted {
+		return configdomain.Lineage{}, gitdomain.LocalBranchNames{}, aborted, err
+	}
+	switch selection {
+	case InferredLineageOptionAccept:
+		return args.Inferred, gitdomain.LocalBranchNames{}, false, nil
+	case InferredLineageOptionEdit:
+		return editInferredLineage(args, combined)
+	case InferredLineageOptionSkip:
+		return configdomain.Lineage{}, gitdomain.LocalBranchNames{}, false, nil
+	}
+	panic("unhandled inferred lineage option: " + selection)
+}
+
+type InferredLineageArgs struct {
+	DialogTestInputs *components.TestInputs
+	Inferred         configdomain.Lineage // the inferred lineage entries
+	Lineage          configdomain.Lineage // the already existing lineage entries
+	LocalBranches    gitdomain.LocalBranchNames
+	MainBranch       gitdomain.LocalBranchName
+}
+
+type InferredLineageOption string
+
+func (self InferredLineageOption) Short() string {
+	start, _, _ := strings.Cut(self.String(), ",")
+	return start
+}
+
+func (self InferredLineageOption) String() string {
+	return string(self)
+}
+
+// editInferredLineage lets the user select the parent of each branch in the given inferred lineage,
+// with the inferred parent preselected.
+func editInferredLineage(args InferredLineageArgs, combined configdomain.Lineage) (configdomain.Lineage, gitdomain.LocalBranchNames, bool, error) {
+	result := configdomain.Lineage{}
+	perennials := gitdomain.LocalBranchNames{}
+	for _, branch := range args.Inferred.BranchNames() {
+		parent, aborted, err := Parent(ParentArgs{
+			Branch:          branch,
+			DefaultChoice:   args.Inferred[branch],
+			DialogTestInput: args.DialogTestInputs.Next(),
+			Lineage:         combined,
+			LocalBranches:   args.LocalBranches,
+			MainBranch:      args.MainBranch,
+		})
+		if err != nil || aborted {
+			return configdomain.Lineage{}, gitdomain.LocalBranchNames{}, aborted, err
+		}
+		if parent == PerennialBranchOption {
+			perennials = append(perennials, branch)
+			delete(combined, branch)
+			continue
+		}
+		result[branch] = parent
+		combined[branch] = parent
+	}
+	return result, perennials, false, nil
+}
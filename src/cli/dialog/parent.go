@@ -26,7 +26,7 @@ Most of the time this is the main development branch (%v).
 // Parent lets the user select the parent branch for the given branch.
 func Parent(args ParentArgs) (gitdomain.LocalBranchName, bool, error) {
 	entries := ParentEntries(args)
-	cursor := stringers.IndexOrStart(entries, args.MainBranch)
+	cursor := stringers.IndexOrStart(entries, args.DefaultChoice)
 	title := fmt.Sprintf(parentBranchTitleTemplate, args.Branch)
 	help := fmt.Sprintf(parentBranchHelpTemplate, args.Branch, args.MainBranch)
 	selection, aborted, err := components.RadioList(entries, cursor, title, help, args.DialogTestInput)
@@ -36,6 +36,7 @@ func Parent(args ParentArgs) (gitdomain.LocalBranchName, bool, error) {
 
 type ParentArgs struct {
 	Branch          gitdomain.LocalBranchName
+	DefaultChoice   gitdomain.LocalBranchName // the entry to preselect
 	DialogTestInput components.TestInput
 	Lineage         configdomain.Lineage
 	LocalBranches   gitdomain.LocalBranchNames
@@ -0,0 +1,49 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/git-town/git-town/v14/src/cli/dialog/components"
+	"github.com/git-town/git-town/v14/src/messages"
+)
+
+const (
+	repairConfigTitle = `Repair configuration`
+	RepairConfigHelp  = `
+Should Git Town fix the problems listed above?
+
+You can undo these changes by running "git town undo".
+
+`
+)
+
+const (
+	RepairConfigEntryYes repairConfigEntry = `yes, repair the configuration`
+	RepairConfigEntryNo  repairConfigEntry = `no, keep the configuration as it is`
+)
+
+// RepairConfig asks the user whether to fix the problems found in the Git Town configuration.
+func RepairConfig(inputs components.TestInput) (bool, bool, error) {
+	entries := []repairConfigEntry{
+		RepairConfigEntryYes,
+		RepairConfigEntryNo,
+	}
+	selection, aborted, err := components.RadioList(entries, 0, repairConfigTitle, RepairConfigHelp, inputs)
+	if err != nil || aborted {
+		return false, aborted, err
+	}
+	fmt.Printf(messages.RepairConfirm, components.FormattedSelection(selection.Short(), aborted))
+	return selection == RepairConfigEntryYes, aborted, err
+}
+
+type repairConfigEntry string
+
+func (self repairConfigEntry) Short() string {
+	start, _, _ := strings.Cut(self.String(), ",")
+	return start
+}
+
+func (self repairConfigEntry) String() string {
+	return string(self)
+}
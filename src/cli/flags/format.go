@@ -0,0 +1,28 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v14/src/cli/format"
+	"github.com/spf13/cobra"
+)
+
+const formatLong = "format" // long form of the "format" CLI flag
+
+// Format provides type-safe access to the "--format" Cobra command-line flag.
+func Format() (AddFunc, ReadFormatFlagFunc) {
+	addFlag := func(cmd *cobra.Command) {
+		cmd.Flags().String(formatLong, format.OutputFormatText.String(), `output format: "text" or "json"`)
+	}
+	readFlag := func(cmd *cobra.Command) (format.OutputFormat, error) {
+		value, err := cmd.Flags().GetString(formatLong)
+		if err != nil {
+			panic(fmt.Sprintf("command %q does not have a string %q flag", cmd.Name(), formatLong))
+		}
+		return format.ParseOutputFormat(value)
+	}
+	return addFlag, readFlag
+}
+
+// ReadFormatFlagFunc defines the type signature for helper functions that provide the value of the "--format" CLI flag associated with a Cobra command.
+type ReadFormatFlagFunc func(*cobra.Command) (format.OutputFormat, error)
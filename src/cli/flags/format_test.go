@@ -0,0 +1,49 @@
+package flags_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cli/format"
+	"github.com/shoenig/test/must"
+	"github.com/spf13/cobra"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		cmd := cobra.Command{}
+		addFlag, readFlag := flags.Format()
+		addFlag(&cmd)
+		err := cmd.ParseFlags([]string{})
+		must.NoError(t, err)
+		have, err := readFlag(&cmd)
+		must.NoError(t, err)
+		must.EqOp(t, format.OutputFormatText, have)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+		cmd := cobra.Command{}
+		addFlag, readFlag := flags.Format()
+		addFlag(&cmd)
+		err := cmd.ParseFlags([]string{"--format=json"})
+		must.NoError(t, err)
+		have, err := readFlag(&cmd)
+		must.NoError(t, err)
+		must.EqOp(t, format.OutputFormatJSON, have)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		t.Parallel()
+		cmd := cobra.Command{}
+		addFlag, readFlag := flags.Format()
+		addFlag(&cmd)
+		err := cmd.ParseFlags([]string{"--format=yaml"})
+		must.NoError(t, err)
+		_, err = readFlag(&cmd)
+		must.EqError(t, err, `unknown output format "yaml", supported formats are "text" and "json"`)
+	})
+}
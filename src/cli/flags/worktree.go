@@ -0,0 +1,39 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/spf13/cobra"
+)
+
+const (
+	worktreeLong        = "worktree" // long form of the "worktree" CLI flag
+	worktreeNoPathGiven = "\x00"     // the value of the "worktree" CLI flag when the user provides it without a path
+)
+
+// Worktree provides type-safe access to the "--worktree" CLI flag.
+// Users can provide this flag with a path ("--worktree=path") or without one ("--worktree").
+func Worktree() (AddFunc, ReadWorktreeFlagFunc) {
+	addFlag := func(cmd *cobra.Command) {
+		cmd.Flags().String(worktreeLong, "", "check out the new branch in a separate worktree, optionally at the given path")
+		cmd.Flags().Lookup(worktreeLong).NoOptDefVal = worktreeNoPathGiven
+	}
+	readFlag := func(cmd *cobra.Command) configdomain.NewBranchWorktree {
+		value, err := cmd.Flags().GetString(worktreeLong)
+		if err != nil {
+			panic(fmt.Sprintf("command %q does not have a string %q flag", cmd.Name(), worktreeLong))
+		}
+		switch value {
+		case "":
+			return configdomain.NewBranchWorktree{Enabled: false, Path: ""}
+		case worktreeNoPathGiven:
+			return configdomain.NewBranchWorktree{Enabled: true, Path: ""}
+		}
+		return configdomain.NewBranchWorktree{Enabled: true, Path: configdomain.WorktreePath(value)}
+	}
+	return addFlag, readFlag
+}
+
+// ReadWorktreeFlagFunc defines the type signature for helper functions that provide the value of the "--worktree" CLI flag associated with a Cobra command.
+type ReadWorktreeFlagFunc func(*cobra.Command) configdomain.NewBranchWorktree
@@ -0,0 +1,47 @@
+package flags_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/shoenig/test/must"
+	"github.com/spf13/cobra"
+)
+
+func TestWorktree(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with path", func(t *testing.T) {
+		t.Parallel()
+		cmd := cobra.Command{}
+		addFlag, readFlag := flags.Worktree()
+		addFlag(&cmd)
+		err := cmd.ParseFlags([]string{"--worktree=../feature"})
+		must.NoError(t, err)
+		want := configdomain.NewBranchWorktree{Enabled: true, Path: "../feature"}
+		must.EqOp(t, want, readFlag(&cmd))
+	})
+
+	t.Run("without path", func(t *testing.T) {
+		t.Parallel()
+		cmd := cobra.Command{}
+		addFlag, readFlag := flags.Worktree()
+		addFlag(&cmd)
+		err := cmd.ParseFlags([]string{"--worktree"})
+		must.NoError(t, err)
+		want := configdomain.NewBranchWorktree{Enabled: true, Path: ""}
+		must.EqOp(t, want, readFlag(&cmd))
+	})
+
+	t.Run("not provided", func(t *testing.T) {
+		t.Parallel()
+		cmd := cobra.Command{}
+		addFlag, readFlag := flags.Worktree()
+		addFlag(&cmd)
+		err := cmd.ParseFlags([]string{})
+		must.NoError(t, err)
+		want := configdomain.NewBranchWorktree{Enabled: false, Path: ""}
+		must.EqOp(t, want, readFlag(&cmd))
+	})
+}
@@ -7,10 +7,16 @@ import (
 
 // BranchTree provids a printable version of the given branch tree.
 func BranchTree(branch gitdomain.LocalBranchName, lineage configdomain.Lineage) string {
-	result := branch.String()
+	return BranchTreeWithDetails(branch, lineage, gitdomain.LocalBranchName.String)
+}
+
+// BranchTreeWithDetails provides a printable version of the given branch tree
+// that uses the given function to render the entry for each branch.
+func BranchTreeWithDetails(branch gitdomain.LocalBranchName, lineage configdomain.Lineage, formatBranch func(gitdomain.LocalBranchName) string) string {
+	result := formatBranch(branch)
 	childBranches := lineage.Children(branch)
 	for _, childBranch := range childBranches {
-		result += "\n" + Indent(BranchTree(childBranch, lineage))
+		result += "\n" + Indent(BranchTreeWithDetails(childBranch, lineage, formatBranch))
 	}
 	return result
 }
@@ -0,0 +1,46 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/cli/format"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/shoenig/test/must"
+)
+
+func TestBranchTree(t *testing.T) {
+	t.Parallel()
+	lineage := configdomain.Lineage{
+		gitdomain.NewLocalBranchName("feature-1"):   gitdomain.NewLocalBranchName("main"),
+		gitdomain.NewLocalBranchName("feature-1a"):  gitdomain.NewLocalBranchName("feature-1"),
+		gitdomain.NewLocalBranchName("feature-2"):   gitdomain.NewLocalBranchName("main"),
+		gitdomain.NewLocalBranchName("production"):  gitdomain.NewLocalBranchName("main"),
+		gitdomain.NewLocalBranchName("hotfix"):      gitdomain.NewLocalBranchName("production"),
+		gitdomain.NewLocalBranchName("unrelated-1"): gitdomain.NewLocalBranchName("unrelated"),
+	}
+
+	t.Run("BranchTree", func(t *testing.T) {
+		t.Parallel()
+		have := format.BranchTree(gitdomain.NewLocalBranchName("main"), lineage)
+		want := `
+main
+  feature-1
+    feature-1a
+  feature-2
+  production
+    hotfix`[1:]
+		must.EqOp(t, want, have)
+	})
+
+	t.Run("BranchTreeWithDetails", func(t *testing.T) {
+		t.Parallel()
+		have := format.BranchTreeWithDetails(gitdomain.NewLocalBranchName("feature-1"), lineage, func(branch gitdomain.LocalBranchName) string {
+			return branch.String() + " (details)"
+		})
+		want := `
+feature-1 (details)
+  feature-1a (details)`[1:]
+		must.EqOp(t, want, have)
+	})
+}
@@ -0,0 +1,41 @@
+package format
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v14/src/messages"
+)
+
+// OutputFormat defines the formats in which read-only commands can display their results.
+type OutputFormat string
+
+const (
+	OutputFormatJSON OutputFormat = "json" // structured output for tooling
+	OutputFormatText OutputFormat = "text" // human-readable output
+)
+
+// ParseOutputFormat provides the OutputFormat with the given name.
+func ParseOutputFormat(text string) (OutputFormat, error) {
+	for _, outputFormat := range OutputFormats() {
+		if text == outputFormat.String() {
+			return outputFormat, nil
+		}
+	}
+	return OutputFormatText, fmt.Errorf(messages.OutputFormatUnknown, text)
+}
+
+// OutputFormats provides all possible output formats.
+func OutputFormats() []OutputFormat {
+	return []OutputFormat{
+		OutputFormatText,
+		OutputFormatJSON,
+	}
+}
+
+func (self OutputFormat) IsJSON() bool {
+	return self == OutputFormatJSON
+}
+
+func (self OutputFormat) String() string {
+	return string(self)
+}
@@ -0,0 +1,125 @@
+package jsonoutput
+
+import (
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+)
+
+// RedactedToken is displayed in place of configured API tokens.
+const RedactedToken = "(redacted)"
+
+// Config is the JSON output of "git town config".
+type Config struct {
+	BranchTypes   map[string]string `json:"branchTypes"`
+	Config        Settings          `json:"config"`
+	Lineage       []LineageEntry    `json:"lineage"`
+	SchemaVersion int               `json:"schemaVersion"`
+}
+
+// Settings contains the Git Town settings, with API tokens redacted.
+type Settings struct {
+	Aliases                  map[string]string `json:"aliases"`
+	AzureDevOpsToken         *string           `json:"azureDevOpsToken"`
+	BitbucketToken           *string           `json:"bitbucketToken"`
+	BranchSyncStrategies     map[string]string `json:"branchSyncStrategies"`
+	ContributionBranches     []string          `json:"contributionBranches"`
+	GitHubToken              *string           `json:"githubToken"`
+	GitLabToken              *string           `json:"gitlabToken"`
+	GitUserEmail             string            `json:"gitUserEmail"`
+	GitUserName              string            `json:"gitUserName"`
+	GiteaToken               *string           `json:"giteaToken"`
+	HostingOriginHostname    string            `json:"hostingOriginHostname"`
+	HostingPlatform          string            `json:"hostingPlatform"`
+	MainBranch               string            `json:"mainBranch"`
+	ObservedBranches         []string          `json:"observedBranches"`
+	Offline                  bool              `json:"offline"`
+	ParkedBranches           []string          `json:"parkedBranches"`
+	PerennialBranches        []string          `json:"perennialBranches"`
+	PerennialRegex           string            `json:"perennialRegex"`
+	PrototypeBranches        []string          `json:"prototypeBranches"`
+	PushHook                 bool              `json:"pushHook"`
+	PushNewBranches          bool              `json:"pushNewBranches"`
+	ShareLineage             bool              `json:"shareLineage"`
+	ShipDeleteTrackingBranch bool              `json:"shipDeleteTrackingBranch"`
+	SyncBeforeShip           bool              `json:"syncBeforeShip"`
+	SyncFeatureStrategy      string            `json:"syncFeatureStrategy"`
+	SyncPerennialStrategy    string            `json:"syncPerennialStrategy"`
+	SyncUpstream             bool              `json:"syncUpstream"`
+	WorktreePath             string            `json:"worktreePath"`
+}
+
+func NewConfig(config *configdomain.FullConfig) Config {
+	return Config{
+		BranchTypes:   NewBranchTypes(config, configuredBranches(config)),
+		Config:        NewSettings(config),
+		Lineage:       NewLineage(config.Lineage),
+		SchemaVersion: SchemaVersion,
+	}
+}
+
+func NewSettings(config *configdomain.FullConfig) Settings {
+	aliases := make(map[string]string, len(config.Aliases))
+	for command, alias := range config.Aliases {
+		aliases[command.String()] = alias
+	}
+	branchSyncStrategies := make(map[string]string, len(config.BranchSyncStrategies))
+	for branch, strategy := range config.BranchSyncStrategies {
+		branchSyncStrategies[branch.String()] = strategy.String()
+	}
+	return Settings{
+		Aliases:                  aliases,
+		AzureDevOpsToken:         redact(config.AzureDevOpsToken.String()),
+		BitbucketToken:           redact(config.BitbucketToken.String()),
+		BranchSyncStrategies:     branchSyncStrategies,
+		ContributionBranches:     names(config.ContributionBranches.Strings()),
+		GitHubToken:              redact(config.GitHubToken.String()),
+		GitLabToken:              redact(config.GitLabToken.String()),
+		GitUserEmail:             config.GitUserEmail,
+		GitUserName:              config.GitUserName,
+		GiteaToken:               redact(config.GiteaToken.String()),
+		HostingOriginHostname:    config.HostingOriginHostname.String(),
+		HostingPlatform:          config.HostingPlatform.String(),
+		MainBranch:               config.MainBranch.String(),
+		ObservedBranches:         names(config.ObservedBranches.Strings()),
+		Offline:                  config.Offline.Bool(),
+		ParkedBranches:           names(config.ParkedBranches.Strings()),
+		PerennialBranches:        names(config.PerennialBranches.Strings()),
+		PerennialRegex:           config.PerennialRegex.String(),
+		PrototypeBranches:        names(config.PrototypeBranches.Strings()),
+		PushHook:                 config.PushHook.Bool(),
+		PushNewBranches:          config.PushNewBranches.Bool(),
+		ShareLineage:             config.ShareLineage.Bool(),
+		ShipDeleteTrackingBranch: config.ShipDeleteTrackingBranch.Bool(),
+		SyncBeforeShip:           config.SyncBeforeShip.Bool(),
+		SyncFeatureStrategy:      config.SyncFeatureStrategy.String(),
+		SyncPerennialStrategy:    config.SyncPerennialStrategy.String(),
+		SyncUpstream:             config.SyncUpstream.Bool(),
+		WorktreePath:             config.WorktreePath.String(),
+	}
+}
+
+// configuredBranches provides all branches that the given configuration mentions.
+func configuredBranches(config *configdomain.FullConfig) gitdomain.LocalBranchNames {
+	result := gitdomain.LocalBranchNames{}
+	if !config.MainBranch.IsEmpty() {
+		result = append(result, config.MainBranch)
+	}
+	result = result.AppendAllMissing(config.PerennialBranches...)
+	result = result.AppendAllMissing(config.ContributionBranches...)
+	result = result.AppendAllMissing(config.ObservedBranches...)
+	result = result.AppendAllMissing(config.ParkedBranches...)
+	result = result.AppendAllMissing(config.PrototypeBranches...)
+	for _, branch := range config.Lineage.BranchNames() {
+		result = result.AppendAllMissing(branch, config.Lineage.Parent(branch))
+	}
+	return result
+}
+
+// redact provides the placeholder for the given API token, or nil if the token isn't configured.
+func redact(token string) *string {
+	if token == "" {
+		return nil
+	}
+	result := RedactedToken
+	return &result
+}
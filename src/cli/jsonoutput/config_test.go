@@ -0,0 +1,57 @@
+package jsonoutput_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/cli/jsonoutput"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/shoenig/test/must"
+)
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NewConfig", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.DefaultConfig()
+		config.MainBranch = gitdomain.NewLocalBranchName("main")
+		config.PerennialBranches = gitdomain.NewLocalBranchNames("production")
+		config.ParkedBranches = gitdomain.NewLocalBranchNames("parked")
+		config.GitHubToken = "secret-token"
+		config.Lineage = configdomain.Lineage{
+			gitdomain.NewLocalBranchName("feature"): gitdomain.NewLocalBranchName("main"),
+			gitdomain.NewLocalBranchName("hotfix"):  gitdomain.NewLocalBranchName("production"),
+		}
+		have := jsonoutput.NewConfig(&config)
+		must.EqOp(t, jsonoutput.SchemaVersion, have.SchemaVersion)
+		wantBranchTypes := map[string]string{
+			"feature":    "feature",
+			"hotfix":     "feature",
+			"main":       "main",
+			"parked":     "parked",
+			"production": "perennial",
+		}
+		must.Eq(t, wantBranchTypes, have.BranchTypes)
+		wantLineage := []jsonoutput.LineageEntry{
+			{Branch: "feature", Parent: "main"},
+			{Branch: "hotfix", Parent: "production"},
+		}
+		must.Eq(t, wantLineage, have.Lineage)
+		must.EqOp(t, "main", have.Config.MainBranch)
+		must.Eq(t, []string{"production"}, have.Config.PerennialBranches)
+		must.Eq(t, []string{}, have.Config.ContributionBranches)
+	})
+
+	t.Run("redacts API tokens", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.DefaultConfig()
+		config.GitHubToken = "secret-token"
+		have, err := json.Marshal(jsonoutput.NewConfig(&config))
+		must.NoError(t, err)
+		must.StrNotContains(t, string(have), "secret-token")
+		must.StrContains(t, string(have), `"githubToken":"(redacted)"`)
+		must.StrContains(t, string(have), `"gitlabToken":null`)
+	})
+}
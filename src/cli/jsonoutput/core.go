@@ -0,0 +1,31 @@
+// Package jsonoutput defines the machine-readable output of Git Town's read-only commands.
+// External tools rely on this data format.
+// Changes to the data structures in this package must therefore be backwards compatible
+// or increment SchemaVersion.
+package jsonoutput
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersion is the version of the data format defined in this package.
+const SchemaVersion = 1
+
+// Print prints the given data structure as JSON.
+func Print(data any) error {
+	text, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(text))
+	return nil
+}
+
+// names provides the given branch names as a list that serializes to an empty JSON array instead of null.
+func names(branches []string) []string {
+	if branches == nil {
+		return []string{}
+	}
+	return branches
+}
@@ -0,0 +1,20 @@
+package jsonoutput
+
+import "github.com/git-town/git-town/v14/src/git/gitdomain"
+
+// DiffParent is the JSON output of "git town diff-parent".
+type DiffParent struct {
+	Branch        string `json:"branch"`
+	Diff          string `json:"diff"`
+	Parent        string `json:"parent"`
+	SchemaVersion int    `json:"schemaVersion"`
+}
+
+func NewDiffParent(branch, parent gitdomain.LocalBranchName, diff string) DiffParent {
+	return DiffParent{
+		Branch:        branch.String(),
+		Diff:          diff,
+		Parent:        parent.String(),
+		SchemaVersion: SchemaVersion,
+	}
+}
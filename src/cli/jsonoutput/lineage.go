@@ -0,0 +1,34 @@
+package jsonoutput
+
+import (
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+)
+
+// LineageEntry describes the parent of a branch.
+type LineageEntry struct {
+	Branch string `json:"branch"`
+	Parent string `json:"parent"`
+}
+
+// NewLineage provides the entries of the given lineage, sorted by branch name.
+func NewLineage(lineage configdomain.Lineage) []LineageEntry {
+	branches := lineage.BranchNames()
+	result := make([]LineageEntry, len(branches))
+	for b, branch := range branches {
+		result[b] = LineageEntry{
+			Branch: branch.String(),
+			Parent: lineage.Parent(branch).String(),
+		}
+	}
+	return result
+}
+
+// NewBranchTypes provides the types of the given branches, keyed by branch name.
+func NewBranchTypes(config *configdomain.FullConfig, branches gitdomain.LocalBranchNames) map[string]string {
+	result := make(map[string]string, len(branches))
+	for _, branch := range branches {
+		result[branch.String()] = config.BranchType(branch).Name()
+	}
+	return result
+}
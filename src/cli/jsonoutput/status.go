@@ -0,0 +1,65 @@
+package jsonoutput
+
+import (
+	"time"
+
+	"github.com/git-town/git-town/v14/src/vm/runstate"
+)
+
+// Status is the JSON output of "git town status".
+type Status struct {
+	RunState      *RunState `json:"runState"` // nil if no Git Town command ran in this repository
+	SchemaVersion int       `json:"schemaVersion"`
+}
+
+// RunState describes the most recently executed Git Town command.
+type RunState struct {
+	CanContinue       bool               `json:"canContinue"`
+	CanSkip           bool               `json:"canSkip"`
+	CanUndo           bool               `json:"canUndo"`
+	Command           string             `json:"command"`
+	Finished          bool               `json:"finished"`
+	UnfinishedDetails *UnfinishedDetails `json:"unfinishedDetails"` // nil if the command finished
+}
+
+// UnfinishedDetails describes a Git Town command that hit a problem.
+type UnfinishedDetails struct {
+	CanSkip   bool      `json:"canSkip"`
+	EndBranch string    `json:"endBranch"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+func NewStatus(state *runstate.RunState) Status {
+	return Status{
+		RunState:      NewRunState(state),
+		SchemaVersion: SchemaVersion,
+	}
+}
+
+func NewRunState(state *runstate.RunState) *RunState {
+	if state == nil {
+		return nil
+	}
+	if state.IsFinished() {
+		return &RunState{
+			CanContinue:       false,
+			CanSkip:           false,
+			CanUndo:           true,
+			Command:           state.Command,
+			Finished:          true,
+			UnfinishedDetails: nil,
+		}
+	}
+	return &RunState{
+		CanContinue: state.HasRunProgram(),
+		CanSkip:     state.UnfinishedDetails.CanSkip,
+		CanUndo:     state.HasAbortProgram(),
+		Command:     state.Command,
+		Finished:    false,
+		UnfinishedDetails: &UnfinishedDetails{
+			CanSkip:   state.UnfinishedDetails.CanSkip,
+			EndBranch: state.UnfinishedDetails.EndBranch.String(),
+			EndTime:   state.UnfinishedDetails.EndTime,
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package jsonoutput_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/git-town/git-town/v14/src/cli/jsonoutput"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/opcodes"
+	"github.com/git-town/git-town/v14/src/vm/program"
+	"github.com/git-town/git-town/v14/src/vm/runstate"
+	"github.com/shoenig/test/must"
+)
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no runstate", func(t *testing.T) {
+		t.Parallel()
+		have := jsonoutput.NewStatus(nil)
+		must.Nil(t, have.RunState)
+		must.EqOp(t, jsonoutput.SchemaVersion, have.SchemaVersion)
+	})
+
+	t.Run("finished runstate", func(t *testing.T) {
+		t.Parallel()
+		state := runstate.EmptyRunState()
+		state.Command = "sync"
+		have := jsonoutput.NewStatus(&state)
+		want := &jsonoutput.RunState{
+			CanContinue:       false,
+			CanSkip:           false,
+			CanUndo:           true,
+			Command:           "sync",
+			Finished:          true,
+			UnfinishedDetails: nil,
+		}
+		must.Eq(t, want, have.RunState)
+	})
+
+	t.Run("unfinished runstate", func(t *testing.T) {
+		t.Parallel()
+		endTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		state := runstate.EmptyRunState()
+		state.Command = "sync"
+		state.AbortProgram = program.Program{}
+		state.AbortProgram.Add(&opcodes.AbortMerge{})
+		state.RunProgram = program.Program{}
+		state.RunProgram.Add(&opcodes.ContinueMerge{})
+		state.UnfinishedDetails = &runstate.UnfinishedRunStateDetails{
+			CanSkip:   true,
+			EndBranch: gitdomain.NewLocalBranchName("feature"),
+			EndTime:   endTime,
+		}
+		have := jsonoutput.NewStatus(&state)
+		want := &jsonoutput.RunState{
+			CanContinue: true,
+			CanSkip:     true,
+			CanUndo:     true,
+			Command:     "sync",
+			Finished:    false,
+			UnfinishedDetails: &jsonoutput.UnfinishedDetails{
+				CanSkip:   true,
+				EndBranch: "feature",
+				EndTime:   endTime,
+			},
+		}
+		must.Eq(t, want, have.RunState)
+	})
+}
@@ -0,0 +1,38 @@
+package jsonoutput
+
+import (
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+)
+
+// Switch is the JSON output of "git town switch".
+type Switch struct {
+	Branches      []Branch       `json:"branches"`
+	CurrentBranch string         `json:"currentBranch"`
+	Lineage       []LineageEntry `json:"lineage"`
+	SchemaVersion int            `json:"schemaVersion"`
+}
+
+// Branch describes a local branch.
+type Branch struct {
+	Name   string `json:"name"`
+	Parent string `json:"parent"`
+	Type   string `json:"type"`
+}
+
+func NewSwitch(config *configdomain.FullConfig, branches gitdomain.LocalBranchNames, currentBranch gitdomain.LocalBranchName) Switch {
+	entries := make([]Branch, len(branches))
+	for b, branch := range branches {
+		entries[b] = Branch{
+			Name:   branch.String(),
+			Parent: config.Lineage.Parent(branch).String(),
+			Type:   config.BranchType(branch).Name(),
+		}
+	}
+	return Switch{
+		Branches:      entries,
+		CurrentBranch: currentBranch.String(),
+		Lineage:       NewLineage(config.Lineage),
+		SchemaVersion: SchemaVersion,
+	}
+}
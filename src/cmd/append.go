@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"slices"
 
@@ -25,11 +26,14 @@ const appendDesc = "Creates a new feature branch as a child of the current branc
 const appendHelp = `
 Syncs the current branch, forks a new feature branch with the given name off the current branch, makes the new branch a child of the current branch, pushes the new feature branch to the origin repository (if and only if "push-new-branches" is true), and brings over all uncommitted changes to the new feature branch.
 
+With the "--worktree" flag, checks out the new branch in a new worktree and leaves the current worktree and its uncommitted changes as they are. The location of that worktree is the given path or the configured "worktree-path".
+
 See "sync" for information regarding upstream remotes.`
 
 func appendCmd() *cobra.Command {
 	addVerboseFlag, readVerboseFlag := flags.Verbose()
 	addDryRunFlag, readDryRunFlag := flags.DryRun()
+	addWorktreeFlag, readWorktreeFlag := flags.Worktree()
 	cmd := cobra.Command{
 		Use:     "append <branch>",
 		GroupID: "lineage",
@@ -37,15 +41,16 @@ func appendCmd() *cobra.Command {
 		Short:   appendDesc,
 		Long:    cmdhelpers.Long(appendDesc, appendHelp),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return executeAppend(args[0], readDryRunFlag(cmd), readVerboseFlag(cmd))
+			return executeAppend(args[0], readDryRunFlag(cmd), readVerboseFlag(cmd), readWorktreeFlag(cmd))
 		},
 	}
 	addDryRunFlag(&cmd)
 	addVerboseFlag(&cmd)
+	addWorktreeFlag(&cmd)
 	return &cmd
 }
 
-func executeAppend(arg string, dryRun, verbose bool) error {
+func executeAppend(arg string, dryRun, verbose bool, worktree configdomain.NewBranchWorktree) error {
 	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
 		DryRun:           dryRun,
 		OmitBranchNames:  false,
@@ -57,7 +62,7 @@ func executeAppend(arg string, dryRun, verbose bool) error {
 	if err != nil {
 		return err
 	}
-	config, initialBranchesSnapshot, initialStashSize, exit, err := determineAppendConfig(gitdomain.NewLocalBranchName(arg), repo, dryRun, verbose)
+	config, initialBranchesSnapshot, initialStashSize, exit, err := determineAppendConfig(gitdomain.NewLocalBranchName(arg), repo, dryRun, verbose, worktree)
 	if err != nil || exit {
 		return err
 	}
@@ -100,9 +105,10 @@ type appendConfig struct {
 	previousBranch            gitdomain.LocalBranchName
 	remotes                   gitdomain.Remotes
 	targetBranch              gitdomain.LocalBranchName
+	worktree                  gitdomain.RepoRootDir // the worktree in which to check out the new branch, empty to check it out in the current worktree
 }
 
-func determineAppendConfig(targetBranch gitdomain.LocalBranchName, repo *execute.OpenRepoResult, dryRun, verbose bool) (*appendConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
+func determineAppendConfig(targetBranch gitdomain.LocalBranchName, repo *execute.OpenRepoResult, dryRun, verbose bool, worktree configdomain.NewBranchWorktree) (*appendConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
 	fc := execute.FailureCollector{}
 	dialogTestInputs := components.LoadTestInputs(os.Environ())
 	repoStatus, err := repo.Runner.Backend.RepoStatus()
@@ -131,6 +137,10 @@ func determineAppendConfig(targetBranch gitdomain.LocalBranchName, repo *execute
 	if branchesSnapshot.Branches.HasMatchingTrackingBranchFor(targetBranch) {
 		fc.Fail(messages.BranchAlreadyExistsRemotely, targetBranch)
 	}
+	worktreePath, err := determineNewBranchWorktree(worktree, repo, targetBranch)
+	if err != nil {
+		return nil, branchesSnapshot, stashSize, false, err
+	}
 	err = execute.EnsureKnownBranchAncestry(branchesSnapshot.Active, execute.EnsureKnownBranchAncestryArgs{
 		Config:           &repo.Runner.Config.FullConfig,
 		AllBranches:      branchesSnapshot.Branches,
@@ -158,6 +168,7 @@ func determineAppendConfig(targetBranch gitdomain.LocalBranchName, repo *execute
 		previousBranch:            previousBranch,
 		remotes:                   remotes,
 		targetBranch:              targetBranch,
+		worktree:                  worktreePath,
 	}, branchesSnapshot, stashSize, false, fc.Err
 }
 
@@ -172,13 +183,22 @@ func appendProgram(config *appendConfig) program.Program {
 				Program:       &prog,
 				Remotes:       config.remotes,
 				PushBranch:    true,
+				RootDir:       gitdomain.EmptyRepoRootDir(),
+				Worktrees:     gitdomain.Worktrees{},
 			})
 		}
 	}
-	prog.Add(&opcodes.CreateAndCheckoutBranchExistingParent{
-		Ancestors: config.newBranchParentCandidates,
-		Branch:    config.targetBranch,
-	})
+	if config.worktree.IsEmpty() {
+		prog.Add(&opcodes.CreateAndCheckoutBranchExistingParent{
+			Ancestors: config.newBranchParentCandidates,
+			Branch:    config.targetBranch,
+		})
+	} else {
+		prog.Add(&opcodes.CreateBranchExistingParent{
+			Ancestors: config.newBranchParentCandidates,
+			Branch:    config.targetBranch,
+		})
+	}
 	if config.remotes.HasOrigin() && config.ShouldPushNewBranches() && config.IsOnline() {
 		prog.Add(&opcodes.CreateTrackingBranch{Branch: config.targetBranch})
 	}
@@ -186,11 +206,32 @@ func appendProgram(config *appendConfig) program.Program {
 		Branch:    config.targetBranch,
 		Ancestors: config.newBranchParentCandidates,
 	})
+	if !config.worktree.IsEmpty() {
+		prog.Add(&opcodes.Checkout{Branch: config.initialBranch})
+		prog.Add(&opcodes.AddWorktree{Branch: config.targetBranch, Path: config.worktree})
+	}
 	cmdhelpers.Wrap(&prog, cmdhelpers.WrapOptions{
 		DryRun:                   config.dryRun,
 		RunInGitRoot:             true,
-		StashOpenChanges:         config.hasOpenChanges,
+		StashOpenChanges:         config.hasOpenChanges && config.worktree.IsEmpty(),
 		PreviousBranchCandidates: gitdomain.LocalBranchNames{config.initialBranch, config.previousBranch},
 	})
 	return prog
 }
+
+// determineNewBranchWorktree provides the location of the worktree in which to check out the given new branch,
+// or an empty path if the new branch should be checked out in the current worktree.
+func determineNewBranchWorktree(worktree configdomain.NewBranchWorktree, repo *execute.OpenRepoResult, branch gitdomain.LocalBranchName) (gitdomain.RepoRootDir, error) {
+	if !worktree.Enabled {
+		return gitdomain.EmptyRepoRootDir(), nil
+	}
+	template := worktree.Path
+	if template == "" {
+		template = repo.Runner.Config.FullConfig.WorktreePath
+	}
+	result := template.Resolve(repo.RootDir, branch)
+	if _, err := os.Stat(result.String()); err == nil {
+		return result, fmt.Errorf(messages.WorktreePathExists, result)
+	}
+	return result, nil
+}
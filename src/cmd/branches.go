@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/git-town/git-town/v14/src/cli/dialog/components"
+	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cli/format"
+	"github.com/git-town/git-town/v14/src/cli/print"
+	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/execute"
+	"github.com/git-town/git-town/v14/src/git"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/hosting"
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+	"github.com/spf13/cobra"
+)
+
+const branchesDesc = "Displays the branch hierarchy with the status of each branch"
+
+const branchesHelp = `
+Displays all branches in the branch lineage as a tree.
+For each branch, shows its type, its sync status,
+how many commits it is ahead of and behind its parent and tracking branch,
+and the proposal for it at the code hosting platform.
+
+The "--offline" flag skips looking up proposals at the code hosting platform.
+This also happens when Git Town is in offline mode.`
+
+func branchesCmd() *cobra.Command {
+	addVerboseFlag, readVerboseFlag := flags.Verbose()
+	addOfflineFlag, readOfflineFlag := flags.Bool("offline", "", "don't look up proposals at the code hosting platform", flags.FlagTypeNonPersistent)
+	cmd := cobra.Command{
+		Use:     "branches",
+		GroupID: "lineage",
+		Args:    cobra.NoArgs,
+		Short:   branchesDesc,
+		Long:    cmdhelpers.Long(branchesDesc, branchesHelp),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeBranches(readOfflineFlag(cmd), readVerboseFlag(cmd))
+		},
+	}
+	addOfflineFlag(&cmd)
+	addVerboseFlag(&cmd)
+	return &cmd
+}
+
+func executeBranches(offline, verbose bool) error {
+	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
+		DryRun:           false,
+		OmitBranchNames:  true,
+		PrintCommands:    true,
+		ValidateGitRepo:  true,
+		ValidateIsOnline: false,
+		Verbose:          verbose,
+	})
+	if err != nil {
+		return err
+	}
+	config, exit, err := determineBranchesConfig(repo, offline || repo.IsOffline.Bool(), verbose)
+	if err != nil || exit {
+		return err
+	}
+	entries := make([]string, len(config.roots))
+	for r, root := range config.roots {
+		entries[r] = format.BranchTreeWithDetails(root, config.FullConfig.Lineage, func(branch gitdomain.LocalBranchName) string {
+			return config.branchDetails[branch]
+		})
+	}
+	fmt.Println(strings.Join(entries, "\n\n"))
+	print.Footer(verbose, repo.Runner.CommandsCounter.Count(), repo.Runner.FinalMessages.Result())
+	return nil
+}
+
+type branchesConfig struct {
+	*configdomain.FullConfig
+	branchDetails map[gitdomain.LocalBranchName]string // the printable description of each branch
+	roots         gitdomain.LocalBranchNames           // the branches at the root of the displayed branch trees
+}
+
+func determineBranchesConfig(repo *execute.OpenRepoResult, offline, verbose bool) (*branchesConfig, bool, error) {
+	dialogTestInputs := components.LoadTestInputs(os.Environ())
+	repoStatus, err := repo.Runner.Backend.RepoStatus()
+	if err != nil {
+		return nil, false, err
+	}
+	branchesSnapshot, _, exit, err := execute.LoadRepoSnapshot(execute.LoadRepoSnapshotArgs{
+		DialogTestInputs:      dialogTestInputs,
+		Fetch:                 false,
+		FullConfig:            &repo.Runner.Config.FullConfig,
+		HandleUnfinishedState: false,
+		Repo:                  repo,
+		RepoStatus:            repoStatus,
+		ValidateIsConfigured:  true,
+		ValidateNoOpenChanges: false,
+		Verbose:               verbose,
+	})
+	if err != nil || exit {
+		return nil, exit, err
+	}
+	fullConfig := &repo.Runner.Config.FullConfig
+	var connector hostingdomain.Connector
+	if !offline {
+		connector, err = hosting.NewConnector(hosting.NewConnectorArgs{
+			FullConfig:      fullConfig,
+			HostingPlatform: fullConfig.HostingPlatform,
+			Log:             print.Logger{},
+			OriginURL:       repo.Runner.Config.OriginURL(),
+		})
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	roots := branchesRoots(fullConfig, branchesSnapshot.Branches)
+	branchDetails := map[gitdomain.LocalBranchName]string{}
+	for _, root := range roots {
+		for _, branch := range append(gitdomain.LocalBranchNames{root}, fullConfig.Lineage.Descendants(root)...) {
+			branchDetails[branch], err = describeBranch(describeBranchArgs{
+				backend:   &repo.Runner.Backend,
+				branch:    branch,
+				branches:  branchesSnapshot.Branches,
+				config:    fullConfig,
+				connector: connector,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	return &branchesConfig{
+		FullConfig:    fullConfig,
+		branchDetails: branchDetails,
+		roots:         roots,
+	}, false, nil
+}
+
+// branchesRoots provides the branches at the root of the branch trees to display:
+// the main branch, the perennial branches, the roots of the lineage,
+// and the local branches that are not part of the lineage.
+func branchesRoots(config *configdomain.FullConfig, branches gitdomain.BranchInfos) gitdomain.LocalBranchNames {
+	result := gitdomain.LocalBranchNames{}
+	if !config.MainBranch.IsEmpty() {
+		result = append(result, config.MainBranch)
+	}
+	result = result.AppendAllMissing(config.PerennialBranches...)
+	result = result.AppendAllMissing(config.Lineage.Roots()...)
+	for _, branch := range branches.LocalBranches().Names() {
+		if !config.Lineage.HasParents(branch) {
+			result = result.AppendAllMissing(branch)
+		}
+	}
+	return result
+}
+
+type describeBranchArgs struct {
+	backend   *git.BackendCommands
+	branch    gitdomain.LocalBranchName
+	branches  gitdomain.BranchInfos
+	config    *configdomain.FullConfig
+	connector hostingdomain.Connector
+}
+
+// describeBranch provides the printable description of the given branch.
+func describeBranch(args describeBranchArgs) (string, error) {
+	details := []string{args.config.BranchType(args.branch).String()}
+	branchInfo := args.branches.FindByLocalName(args.branch)
+	if branchInfo == nil {
+		branchInfo = args.branches.FindByRemoteName(args.branch.AtRemote(gitdomain.RemoteOrigin))
+	}
+	if branchInfo == nil {
+		details = append(details, messages.BranchesNotFound)
+		return fmt.Sprintf("%s (%s)", args.branch, strings.Join(details, ", ")), nil
+	}
+	details = append(details, branchInfo.SyncStatus.String())
+	if branchInfo.HasLocalBranch() {
+		parent := args.config.Lineage.Parent(args.branch)
+		if !parent.IsEmpty() && args.branches.HasLocalBranch(parent) {
+			ahead, behind, err := args.backend.AheadBehind(args.branch.BranchName(), parent.BranchName())
+			if err != nil {
+				return "", err
+			}
+			details = append(details, fmt.Sprintf(messages.BranchesAheadBehind, ahead, behind, parent))
+		}
+	}
+	if branchInfo.HasTrackingBranch() {
+		ahead, behind, err := args.backend.AheadBehind(args.branch.BranchName(), branchInfo.RemoteName.BranchName())
+		if err != nil {
+			return "", err
+		}
+		if ahead > 0 || behind > 0 {
+			details = append(details, fmt.Sprintf(messages.BranchesAheadBehind, ahead, behind, branchInfo.RemoteName))
+		}
+	}
+	if args.connector != nil && branchInfo.HasRemoteBranch() {
+		parent := args.config.Lineage.Parent(args.branch)
+		if !parent.IsEmpty() {
+			proposal, err := args.connector.FindProposal(args.branch, parent)
+			if err != nil {
+				details = append(details, messages.BranchesProposalUnknown)
+			} else if proposal != nil {
+				details = append(details, fmt.Sprintf(messages.BranchesProposal, proposal.Number, proposal.Title))
+			}
+		}
+	}
+	return fmt.Sprintf("%s (%s)", args.branch, strings.Join(details, ", ")), nil
+}
@@ -202,7 +202,7 @@ func compressBranchProgram(prog *program.Program, branch compressBranchConfig, o
 		return
 	}
 	prog.Add(&opcodes.Checkout{Branch: branch.branchInfo.LocalName})
-	prog.Add(&opcodes.ResetCommitsInCurrentBranch{Parent: branch.parentBranch})
+	prog.Add(&opcodes.ResetCommitsInCurrentBranch{Parent: branch.parentBranch.Location()})
 	prog.Add(&opcodes.CommitSquashedChanges{Message: branch.newCommitMessage})
 	if branch.branchInfo.HasRemoteBranch() && online.Bool() {
 		prog.Add(&opcodes.ForcePushCurrentBranch{})
@@ -229,7 +229,7 @@ func validateCompressBranchesConfig(config *compressBranchesConfig) error {
 
 func validateCanCompressBranchType(branchName gitdomain.LocalBranchName, branchType configdomain.BranchType) error {
 	switch branchType {
-	case configdomain.BranchTypeParkedBranch, configdomain.BranchTypeFeatureBranch:
+	case configdomain.BranchTypeParkedBranch, configdomain.BranchTypeFeatureBranch, configdomain.BranchTypePrototypeBranch:
 		return nil
 	case configdomain.BranchTypeMainBranch, configdomain.BranchTypePerennialBranch:
 		return errors.New(messages.CompressIsPerennial)
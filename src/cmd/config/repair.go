@@ -0,0 +1,117 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/git-town/git-town/v14/src/cli/dialog"
+	"github.com/git-town/git-town/v14/src/cli/dialog/components"
+	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
+	"github.com/git-town/git-town/v14/src/config/configrepair"
+	"github.com/git-town/git-town/v14/src/execute"
+	"github.com/git-town/git-town/v14/src/messages"
+	"github.com/git-town/git-town/v14/src/undo/undoconfig"
+	configInterpreter "github.com/git-town/git-town/v14/src/vm/interpreter/config"
+	"github.com/spf13/cobra"
+)
+
+const repairConfigDesc = "Fixes configuration entries for branches that don't exist anymore"
+
+const repairConfigHelp = `
+Branches that get deleted outside of Git Town,
+for example by the code hosting platform after shipping them
+or manually via "git branch -D",
+leave their entries in the Git Town configuration behind.
+
+This command finds and fixes:
+- lineage entries for branches that don't exist anymore
+- branches whose parent branch doesn't exist
+- branches that are their own ancestors
+- branches whose parent was deleted at the remote
+- perennial, contribution, observed, parked, and prototype branches that don't exist
+
+Git Town asks for confirmation before fixing the problems it found,
+unless you provide the "--yes" flag.
+You can undo the changes via "git town undo".`
+
+func repairConfigCommand() *cobra.Command {
+	addVerboseFlag, readVerboseFlag := flags.Verbose()
+	addYesFlag, readYesFlag := flags.Bool("yes", "y", "Repair without asking for confirmation", flags.FlagTypeNonPersistent)
+	cmd := cobra.Command{
+		Use:   "repair",
+		Args:  cobra.NoArgs,
+		Short: repairConfigDesc,
+		Long:  cmdhelpers.Long(repairConfigDesc, repairConfigHelp),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeRepairConfig(readYesFlag(cmd), readVerboseFlag(cmd))
+		},
+	}
+	addVerboseFlag(&cmd)
+	addYesFlag(&cmd)
+	return &cmd
+}
+
+func executeRepairConfig(yes, verbose bool) error {
+	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
+		DryRun:           false,
+		OmitBranchNames:  false,
+		PrintCommands:    true,
+		ValidateGitRepo:  true,
+		ValidateIsOnline: false,
+		Verbose:          verbose,
+	})
+	if err != nil {
+		return err
+	}
+	dialogTestInputs := components.LoadTestInputs(os.Environ())
+	repoStatus, err := repo.Runner.Backend.RepoStatus()
+	if err != nil {
+		return err
+	}
+	branchesSnapshot, _, exit, err := execute.LoadRepoSnapshot(execute.LoadRepoSnapshotArgs{
+		DialogTestInputs:      dialogTestInputs,
+		Fetch:                 true,
+		FullConfig:            &repo.Runner.Config.FullConfig,
+		HandleUnfinishedState: true,
+		Repo:                  repo,
+		RepoStatus:            repoStatus,
+		ValidateIsConfigured:  false,
+		ValidateNoOpenChanges: false,
+		Verbose:               verbose,
+	})
+	if err != nil || exit {
+		return err
+	}
+	if repo.Runner.Config.FullConfig.MainBranch.IsEmpty() {
+		return errors.New(messages.RepairNoMainBranch)
+	}
+	problems := configrepair.Problems(&repo.Runner.Config.FullConfig, branchesSnapshot.Branches)
+	if len(problems) == 0 {
+		fmt.Println(messages.RepairNoProblems)
+		return nil
+	}
+	fmt.Println(messages.RepairProblemsFound)
+	for _, problem := range problems {
+		fmt.Println("- " + problem.Description)
+	}
+	fmt.Println()
+	if !yes {
+		confirmed, aborted, err := dialog.RepairConfig(dialogTestInputs.Next())
+		if err != nil || aborted || !confirmed {
+			return err
+		}
+	}
+	if err = configrepair.Fix(problems, repo.Runner.Config); err != nil {
+		return err
+	}
+	return configInterpreter.Finished(configInterpreter.FinishedArgs{
+		BeginConfigSnapshot: repo.ConfigSnapshot,
+		Command:             "config repair",
+		EndConfigSnapshot:   undoconfig.EmptyConfigSnapshot(),
+		RootDir:             repo.RootDir,
+		Runner:              repo.Runner,
+		Verbose:             verbose,
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/git-town/git-town/v14/src/cli/flags"
 	"github.com/git-town/git-town/v14/src/cli/format"
+	"github.com/git-town/git-town/v14/src/cli/jsonoutput"
 	"github.com/git-town/git-town/v14/src/cli/print"
 	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
 	"github.com/git-town/git-town/v14/src/config/configdomain"
@@ -15,6 +16,7 @@ import (
 const configDesc = "Displays your Git Town configuration"
 
 func RootCmd() *cobra.Command {
+	addFormatFlag, readFormatFlag := flags.Format()
 	addVerboseFlag, readVerboseFlag := flags.Verbose()
 	configCmd := cobra.Command{
 		Use:     "config",
@@ -23,16 +25,22 @@ func RootCmd() *cobra.Command {
 		Short:   configDesc,
 		Long:    cmdhelpers.Long(configDesc),
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return executeConfig(readVerboseFlag(cmd))
+			outputFormat, err := readFormatFlag(cmd)
+			if err != nil {
+				return err
+			}
+			return executeConfig(outputFormat, readVerboseFlag(cmd))
 		},
 	}
+	addFormatFlag(&configCmd)
 	addVerboseFlag(&configCmd)
 	configCmd.AddCommand(removeConfigCommand())
+	configCmd.AddCommand(repairConfigCommand())
 	configCmd.AddCommand(SetupCommand())
 	return &configCmd
 }
 
-func executeConfig(verbose bool) error {
+func executeConfig(outputFormat format.OutputFormat, verbose bool) error {
 	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
 		DryRun:           false,
 		OmitBranchNames:  true,
@@ -44,6 +52,9 @@ func executeConfig(verbose bool) error {
 	if err != nil {
 		return err
 	}
+	if outputFormat.IsJSON() {
+		return jsonoutput.Print(jsonoutput.NewConfig(&repo.Runner.Config.FullConfig))
+	}
 	printConfig(&repo.Runner.Config.FullConfig)
 	return nil
 }
@@ -57,23 +68,35 @@ func printConfig(config *configdomain.FullConfig) {
 	print.Entry("parked branches", format.StringsSetting((config.ParkedBranches.Join(", "))))
 	print.Entry("contribution branches", format.StringsSetting((config.ContributionBranches.Join(", "))))
 	print.Entry("observed branches", format.StringsSetting((config.ObservedBranches.Join(", "))))
+	print.Entry("prototype branches", format.StringsSetting((config.PrototypeBranches.Join(", "))))
 	fmt.Println()
 	print.Header("Configuration")
 	print.Entry("offline", format.Bool(config.Offline.Bool()))
 	print.Entry("run pre-push hook", format.Bool(bool(config.PushHook)))
 	print.Entry("push new branches", format.Bool(config.ShouldPushNewBranches()))
+	print.Entry("share lineage", format.Bool(config.ShareLineage.Bool()))
 	print.Entry("ship deletes the tracking branch", format.Bool(config.ShipDeleteTrackingBranch.Bool()))
 	print.Entry("sync-feature strategy", config.SyncFeatureStrategy.String())
 	print.Entry("sync-perennial strategy", config.SyncPerennialStrategy.String())
 	print.Entry("sync with upstream", format.Bool(config.SyncUpstream.Bool()))
 	print.Entry("sync before shipping", format.Bool(config.SyncBeforeShip.Bool()))
+	print.Entry("worktree path", format.StringSetting(config.WorktreePath.String()))
 	fmt.Println()
 	print.Header("Hosting")
 	print.Entry("hosting platform override", format.StringSetting(config.HostingPlatform.String()))
 	print.Entry("GitHub token", format.StringSetting(string(config.GitHubToken)))
 	print.Entry("GitLab token", format.StringSetting(string(config.GitLabToken)))
 	print.Entry("Gitea token", format.StringSetting(string(config.GiteaToken)))
+	print.Entry("Bitbucket token", format.StringSetting(string(config.BitbucketToken)))
+	print.Entry("Azure DevOps token", format.StringSetting(string(config.AzureDevOpsToken)))
 	fmt.Println()
+	if len(config.BranchSyncStrategies) > 0 {
+		print.Header("Branch Sync Strategies")
+		for _, branch := range config.BranchSyncStrategies.Branches() {
+			print.Entry(branch.String(), config.BranchSyncStrategies[branch].String())
+		}
+		fmt.Println()
+	}
 	if !config.MainBranch.IsEmpty() {
 		print.LabelAndValue("Branch Lineage", format.BranchLineage(config.Lineage))
 	}
@@ -1,19 +1,24 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"slices"
 
 	"github.com/git-town/git-town/v14/src/cli/dialog"
 	"github.com/git-town/git-town/v14/src/cli/dialog/components"
 	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cli/print"
 	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
 	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/config/configfile"
+	"github.com/git-town/git-town/v14/src/config/lineageinfer"
 	"github.com/git-town/git-town/v14/src/execute"
 	"github.com/git-town/git-town/v14/src/git"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
 	"github.com/git-town/git-town/v14/src/hosting"
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+	"github.com/git-town/git-town/v14/src/messages"
 	"github.com/git-town/git-town/v14/src/undo/undoconfig"
 	configInterpreter "github.com/git-town/git-town/v14/src/vm/interpreter/config"
 	"github.com/spf13/cobra"
@@ -122,13 +127,25 @@ func enterData(runner *git.ProdRunner, config *setupConfig) (aborted bool, err e
 	if err != nil || aborted {
 		return aborted, err
 	}
+	aborted, err = enterLineage(runner, config)
+	if err != nil || aborted {
+		return aborted, err
+	}
 	config.userInput.HostingPlatform, aborted, err = dialog.HostingPlatform(runner.Config.FullConfig.HostingPlatform, config.dialogInputs.Next())
 	if err != nil || aborted {
 		return aborted, err
 	}
 	switch determineHostingPlatform(runner, config.userInput.HostingPlatform) {
+	case configdomain.HostingPlatformAzureDevOps:
+		config.userInput.AzureDevOpsToken, aborted, err = dialog.AzureDevOpsToken(runner.Config.FullConfig.AzureDevOpsToken, config.dialogInputs.Next())
+		if err != nil || aborted {
+			return aborted, err
+		}
 	case configdomain.HostingPlatformBitbucket:
-		// BitBucket API isn't supported yet
+		config.userInput.BitbucketToken, aborted, err = dialog.BitbucketToken(runner.Config.FullConfig.BitbucketToken, config.dialogInputs.Next())
+		if err != nil || aborted {
+			return aborted, err
+		}
 	case configdomain.HostingPlatformGitea:
 		config.userInput.GiteaToken, aborted, err = dialog.GiteaToken(runner.Config.FullConfig.GiteaToken, config.dialogInputs.Next())
 		if err != nil || aborted {
@@ -185,6 +202,59 @@ func enterData(runner *git.ProdRunner, config *setupConfig) (aborted bool, err e
 	return false, nil
 }
 
+// enterLineage lets the user review the inferred parents of the local branches that don't have a parent branch yet.
+func enterLineage(runner *git.ProdRunner, config *setupConfig) (aborted bool, err error) {
+	fullConfig := runner.Config.FullConfig
+	fullConfig.MainBranch = config.userInput.MainBranch
+	fullConfig.PerennialBranches = config.userInput.PerennialBranches
+	fullConfig.PerennialRegex = config.userInput.PerennialRegex
+	localBranches := config.localBranches.LocalBranches().Names()
+	branchesToInfer := lineageinfer.BranchesToInfer(&fullConfig, localBranches)
+	if len(branchesToInfer) == 0 {
+		return false, nil
+	}
+	var connector hostingdomain.Connector
+	if fullConfig.IsOnline() && hosting.HasAnyAPIToken(&fullConfig) {
+		connector, err = hosting.NewAPIConnector(hosting.NewConnectorArgs{
+			FullConfig:      &fullConfig,
+			HostingPlatform: fullConfig.HostingPlatform,
+			Log:             print.Logger{},
+			OriginURL:       runner.Config.OriginURL(),
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	// proposals only improve the inferred lineage, so setup continues without them if they cannot be loaded
+	proposalTargets, err := lineageinfer.ProposalTargets(connector, branchesToInfer)
+	if err != nil {
+		fmt.Printf(messages.LineageInferProposalsProblem, err)
+		proposalTargets = map[gitdomain.LocalBranchName]gitdomain.LocalBranchName{}
+	}
+	inferred, err := lineageinfer.Infer(lineageinfer.InferArgs{
+		Backend:         &runner.Backend,
+		Config:          &fullConfig,
+		LocalBranches:   localBranches,
+		ProposalTargets: proposalTargets,
+	})
+	if err != nil {
+		return false, err
+	}
+	newLineage, newPerennials, aborted, err := dialog.InferredLineage(dialog.InferredLineageArgs{
+		DialogTestInputs: &config.dialogInputs,
+		Inferred:         inferred,
+		Lineage:          fullConfig.Lineage,
+		LocalBranches:    localBranches,
+		MainBranch:       fullConfig.MainBranch,
+	})
+	if err != nil || aborted {
+		return aborted, err
+	}
+	config.userInput.Lineage = newLineage
+	config.userInput.PerennialBranches = append(config.userInput.PerennialBranches, newPerennials...)
+	return false, nil
+}
+
 func loadSetupConfig(repo *execute.OpenRepoResult, verbose bool) (*setupConfig, bool, error) {
 	dialogTestInputs := components.LoadTestInputs(os.Environ())
 	repoStatus, err := repo.Runner.Backend.RepoStatus()
@@ -215,6 +285,14 @@ func saveAll(runner *git.ProdRunner, userInput userInput) error {
 	if err != nil {
 		return err
 	}
+	err = saveAzureDevOpsToken(runner, userInput.AzureDevOpsToken)
+	if err != nil {
+		return err
+	}
+	err = saveBitbucketToken(runner, userInput.BitbucketToken)
+	if err != nil {
+		return err
+	}
 	err = saveGiteaToken(runner, userInput.GiteaToken)
 	if err != nil {
 		return err
@@ -227,6 +305,10 @@ func saveAll(runner *git.ProdRunner, userInput userInput) error {
 	if err != nil {
 		return err
 	}
+	err = saveLineage(runner, userInput.Lineage)
+	if err != nil {
+		return err
+	}
 	switch userInput.configStorage {
 	case dialog.ConfigStorageOptionFile:
 		return saveToFile(userInput, runner)
@@ -305,6 +387,20 @@ func saveAliases(runner *git.ProdRunner, newAliases configdomain.Aliases) (err e
 	return nil
 }
 
+func saveAzureDevOpsToken(runner *git.ProdRunner, newToken configdomain.AzureDevOpsToken) error {
+	if newToken == runner.Config.FullConfig.AzureDevOpsToken {
+		return nil
+	}
+	return runner.Frontend.SetAzureDevOpsToken(newToken)
+}
+
+func saveBitbucketToken(runner *git.ProdRunner, newToken configdomain.BitbucketToken) error {
+	if newToken == runner.Config.FullConfig.BitbucketToken {
+		return nil
+	}
+	return runner.Frontend.SetBitbucketToken(newToken)
+}
+
 func saveGiteaToken(runner *git.ProdRunner, newToken configdomain.GiteaToken) error {
 	if newToken == runner.Config.FullConfig.GiteaToken {
 		return nil
@@ -339,6 +435,15 @@ func saveHostingPlatform(runner *git.ProdRunner, newValue configdomain.HostingPl
 	return nil
 }
 
+func saveLineage(runner *git.ProdRunner, newLineage configdomain.Lineage) error {
+	for _, branch := range newLineage.BranchNames() {
+		if err := runner.Config.SetParent(branch, newLineage[branch]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func saveMainBranch(runner *git.ProdRunner, newValue gitdomain.LocalBranchName) error {
 	if newValue == runner.Config.FullConfig.MainBranch {
 		return nil
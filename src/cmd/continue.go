@@ -16,6 +16,7 @@ import (
 	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
 	"github.com/git-town/git-town/v14/src/messages"
 	fullInterpreter "github.com/git-town/git-town/v14/src/vm/interpreter/full"
+	"github.com/git-town/git-town/v14/src/vm/opcodes"
 	"github.com/git-town/git-town/v14/src/vm/program"
 	"github.com/git-town/git-town/v14/src/vm/runstate"
 	"github.com/git-town/git-town/v14/src/vm/statefile"
@@ -132,6 +133,16 @@ func determineContinueRunstate(repo *execute.OpenRepoResult) (runstate.RunState,
 		fmt.Println(messages.ContinueNothingToDo)
 		return runstate.EmptyRunState(), true, nil
 	}
+	if changeWorktree, isChangeWorktree := runState.RunProgram.Peek().(*opcodes.ChangeWorktree); isChangeWorktree {
+		// the problem happened in another worktree, verify that the user resolved it there
+		worktreeStatus, err := repo.Runner.Backend.WorktreeStatus(changeWorktree.Path)
+		if err != nil {
+			return runstate.EmptyRunState(), true, err
+		}
+		if worktreeStatus.Conflicts {
+			return runstate.EmptyRunState(), true, errors.New(messages.ContinueUnresolvedConflicts)
+		}
+	}
 	runState.AbortProgram = program.Program{}
 	return *runState, false, nil
 }
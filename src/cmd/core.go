@@ -4,12 +4,14 @@ package cmd
 import (
 	"github.com/git-town/git-town/v14/src/cmd/config"
 	"github.com/git-town/git-town/v14/src/cmd/debug"
+	"github.com/git-town/git-town/v14/src/cmd/lineage"
 )
 
 // Execute runs the Cobra stack.
 func Execute() error {
 	rootCmd := rootCmd()
 	rootCmd.AddCommand(appendCmd())
+	rootCmd.AddCommand(branchesCmd())
 	rootCmd.AddCommand(completionsCmd(&rootCmd))
 	rootCmd.AddCommand(compressCmd())
 	rootCmd.AddCommand(config.RootCmd())
@@ -19,12 +21,16 @@ func Execute() error {
 	rootCmd.AddCommand(diffParentCommand())
 	rootCmd.AddCommand(hackCmd())
 	rootCmd.AddCommand(killCommand())
+	rootCmd.AddCommand(lineage.RootCmd())
+	rootCmd.AddCommand(moveCommand())
 	rootCmd.AddCommand(newPullRequestCommand())
 	rootCmd.AddCommand(observeCmd())
 	rootCmd.AddCommand(offlineCmd())
 	rootCmd.AddCommand(parkCmd())
 	rootCmd.AddCommand(proposeCommand())
+	rootCmd.AddCommand(prototypeCmd())
 	rootCmd.AddCommand(prependCommand())
+	rootCmd.AddCommand(redoCmd())
 	rootCmd.AddCommand(renameBranchCommand())
 	rootCmd.AddCommand(repoCommand())
 	rootCmd.AddCommand(statusCommand())
@@ -33,6 +39,7 @@ func Execute() error {
 	rootCmd.AddCommand(skipCmd())
 	rootCmd.AddCommand(switchCmd())
 	rootCmd.AddCommand(syncCmd())
+	rootCmd.AddCommand(syncStrategyCmd())
 	rootCmd.AddCommand(undoCmd())
 	return rootCmd.Execute()
 }
@@ -12,6 +12,8 @@ func RootCmd() *cobra.Command {
 	}
 	debugCommand.AddCommand(enterAliases())
 	debugCommand.AddCommand(enterHostingPlatform())
+	debugCommand.AddCommand(enterAzureDevOpsToken())
+	debugCommand.AddCommand(enterBitbucketToken())
 	debugCommand.AddCommand(enterGiteaToken())
 	debugCommand.AddCommand(enterGitHubToken())
 	debugCommand.AddCommand(enterGitLabToken())
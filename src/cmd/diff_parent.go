@@ -7,6 +7,8 @@ import (
 
 	"github.com/git-town/git-town/v14/src/cli/dialog/components"
 	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cli/format"
+	"github.com/git-town/git-town/v14/src/cli/jsonoutput"
 	"github.com/git-town/git-town/v14/src/cli/print"
 	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
 	"github.com/git-town/git-town/v14/src/execute"
@@ -24,6 +26,7 @@ Works on either the current branch or the branch name provided.
 Exits with error code 1 if the given branch is a perennial branch or the main branch.`
 
 func diffParentCommand() *cobra.Command {
+	addFormatFlag, readFormatFlag := flags.Format()
 	addVerboseFlag, readVerboseFlag := flags.Verbose()
 	cmd := cobra.Command{
 		Use:     "diff-parent [<branch>]",
@@ -32,14 +35,19 @@ func diffParentCommand() *cobra.Command {
 		Short:   diffParentDesc,
 		Long:    cmdhelpers.Long(diffParentDesc, diffParentHelp),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return executeDiffParent(args, readVerboseFlag(cmd))
+			outputFormat, err := readFormatFlag(cmd)
+			if err != nil {
+				return err
+			}
+			return executeDiffParent(args, outputFormat, readVerboseFlag(cmd))
 		},
 	}
+	addFormatFlag(&cmd)
 	addVerboseFlag(&cmd)
 	return &cmd
 }
 
-func executeDiffParent(args []string, verbose bool) error {
+func executeDiffParent(args []string, outputFormat format.OutputFormat, verbose bool) error {
 	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
 		DryRun:           false,
 		OmitBranchNames:  false,
@@ -55,6 +63,13 @@ func executeDiffParent(args []string, verbose bool) error {
 	if err != nil || exit {
 		return err
 	}
+	if outputFormat.IsJSON() {
+		diff, err := repo.Runner.Backend.DiffParent(config.branch, config.parentBranch)
+		if err != nil {
+			return err
+		}
+		return jsonoutput.Print(jsonoutput.NewDiffParent(config.branch, config.parentBranch, diff))
+	}
 	err = repo.Runner.Frontend.DiffParent(config.branch, config.parentBranch)
 	if err != nil {
 		return err
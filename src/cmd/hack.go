@@ -27,11 +27,14 @@ const hackDesc = "Creates a new feature branch off the main development branch"
 const hackHelp = `
 Syncs the main branch, forks a new feature branch with the given name off the main branch, pushes the new feature branch to origin (if and only if "push-new-branches" is true), and brings over all uncommitted changes to the new feature branch.
 
+With the "--worktree" flag, checks out the new branch in a new worktree and leaves the current worktree and its uncommitted changes as they are. The location of that worktree is the given path or the configured "worktree-path".
+
 See "sync" for information regarding upstream remotes.`
 
 func hackCmd() *cobra.Command {
 	addVerboseFlag, readVerboseFlag := flags.Verbose()
 	addDryRunFlag, readDryRunFlag := flags.DryRun()
+	addWorktreeFlag, readWorktreeFlag := flags.Worktree()
 	cmd := cobra.Command{
 		Use:     "hack <branch>",
 		GroupID: "basic",
@@ -39,15 +42,16 @@ func hackCmd() *cobra.Command {
 		Short:   hackDesc,
 		Long:    cmdhelpers.Long(hackDesc, hackHelp),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return executeHack(args, readDryRunFlag(cmd), readVerboseFlag(cmd))
+			return executeHack(args, readDryRunFlag(cmd), readVerboseFlag(cmd), readWorktreeFlag(cmd))
 		},
 	}
 	addDryRunFlag(&cmd)
 	addVerboseFlag(&cmd)
+	addWorktreeFlag(&cmd)
 	return &cmd
 }
 
-func executeHack(args []string, dryRun, verbose bool) error {
+func executeHack(args []string, dryRun, verbose bool, worktree configdomain.NewBranchWorktree) error {
 	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
 		DryRun:           dryRun,
 		OmitBranchNames:  false,
@@ -59,7 +63,7 @@ func executeHack(args []string, dryRun, verbose bool) error {
 	if err != nil {
 		return err
 	}
-	config, initialBranchesSnapshot, initialStashSize, exit, err := determineHackConfig(args, repo, dryRun, verbose)
+	config, initialBranchesSnapshot, initialStashSize, exit, err := determineHackConfig(args, repo, dryRun, verbose, worktree)
 	if err != nil || exit {
 		return err
 	}
@@ -136,7 +140,7 @@ type createBranchArgs struct {
 	verbose               bool
 }
 
-func determineHackConfig(args []string, repo *execute.OpenRepoResult, dryRun, verbose bool) (*hackConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
+func determineHackConfig(args []string, repo *execute.OpenRepoResult, dryRun, verbose bool, worktree configdomain.NewBranchWorktree) (*hackConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
 	fc := execute.FailureCollector{}
 	dialogTestInputs := components.LoadTestInputs(os.Environ())
 	repoStatus, err := repo.Runner.Backend.RepoStatus()
@@ -186,6 +190,10 @@ func determineHackConfig(args []string, repo *execute.OpenRepoResult, dryRun, ve
 	if branchesSnapshot.Branches.HasMatchingTrackingBranchFor(targetBranch) {
 		return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.BranchAlreadyExistsRemotely, targetBranch)
 	}
+	worktreePath, err := determineNewBranchWorktree(worktree, repo, targetBranch)
+	if err != nil {
+		return nil, branchesSnapshot, stashSize, false, err
+	}
 	branchNamesToSync := gitdomain.LocalBranchNames{repo.Runner.Config.FullConfig.MainBranch}
 	branchesToSync := fc.BranchInfos(branchesSnapshot.Branches.Select(branchNamesToSync))
 	return &hackConfig{
@@ -202,6 +210,7 @@ func determineHackConfig(args []string, repo *execute.OpenRepoResult, dryRun, ve
 			previousBranch:            previousBranch,
 			remotes:                   remotes,
 			targetBranch:              targetBranch,
+			worktree:                  worktreePath,
 		},
 		makeFeatureConfig: nil,
 	}, branchesSnapshot, stashSize, false, fc.Err
@@ -220,6 +229,8 @@ func makeFeatureBranch(args makeFeatureBranchArgs) error {
 			err = args.config.RemoveFromObservedBranches(branchName)
 		case configdomain.BranchTypeParkedBranch:
 			err = args.config.RemoveFromParkedBranches(branchName)
+		case configdomain.BranchTypePrototypeBranch:
+			err = args.config.RemoveFromPrototypeBranches(branchName)
 		case configdomain.BranchTypeFeatureBranch, configdomain.BranchTypeMainBranch, configdomain.BranchTypePerennialBranch:
 			panic(fmt.Sprintf("unchecked branch type: %s", branchType))
 		}
@@ -250,7 +261,7 @@ type makeFeatureBranchArgs struct {
 func validateMakeFeatureConfig(config *makeFeatureConfig) error {
 	for branchName, branchType := range config.targetBranches {
 		switch branchType {
-		case configdomain.BranchTypeContributionBranch, configdomain.BranchTypeObservedBranch, configdomain.BranchTypeParkedBranch:
+		case configdomain.BranchTypeContributionBranch, configdomain.BranchTypeObservedBranch, configdomain.BranchTypeParkedBranch, configdomain.BranchTypePrototypeBranch:
 			return nil
 		case configdomain.BranchTypeFeatureBranch:
 			return fmt.Errorf(messages.HackBranchIsAlreadyFeature, branchName)
@@ -25,7 +25,9 @@ import (
 const killDesc = "Removes an obsolete feature branch"
 
 const killHelp = `
-Deletes the current or provided branch from the local and origin repositories. Does not delete perennial branches nor the main branch.`
+Deletes the current or provided branch from the local and origin repositories. Does not delete perennial branches nor the main branch.
+
+If the branch is checked out in another worktree, removes that worktree as well. Refuses to do so if that worktree contains uncommitted changes.`
 
 func killCommand() *cobra.Command {
 	addVerboseFlag, readVerboseFlag := flags.Verbose()
@@ -97,6 +99,7 @@ type killConfig struct {
 	branchNameToKill gitdomain.BranchInfo
 	branchTypeToKill configdomain.BranchType
 	branchWhenDone   gitdomain.LocalBranchName
+	branchWorktree   gitdomain.RepoRootDir // the other worktree in which the branch to kill is checked out
 	dialogTestInputs components.TestInputs
 	dryRun           bool
 	hasOpenChanges   bool
@@ -129,8 +132,9 @@ func determineKillConfig(args []string, repo *execute.OpenRepoResult, dryRun, ve
 	if branchToKill == nil {
 		return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.BranchDoesntExist, branchNameToKill)
 	}
-	if branchToKill.SyncStatus == gitdomain.SyncStatusOtherWorktree {
-		return nil, branchesSnapshot, stashSize, exit, fmt.Errorf(messages.KillBranchOtherWorktree, branchNameToKill)
+	branchWorktree, err := determineBranchWorktree(branchNameToKill, branchesSnapshot.Worktrees, repo)
+	if err != nil {
+		return nil, branchesSnapshot, stashSize, false, err
 	}
 	if branchToKill.IsLocal() {
 		err = execute.EnsureKnownBranchAncestry(branchToKill.LocalName, execute.EnsureKnownBranchAncestryArgs{
@@ -157,6 +161,7 @@ func determineKillConfig(args []string, repo *execute.OpenRepoResult, dryRun, ve
 		branchNameToKill: *branchToKill,
 		branchTypeToKill: branchTypeToKill,
 		branchWhenDone:   branchWhenDone,
+		branchWorktree:   branchWorktree,
 		dialogTestInputs: dialogTestInputs,
 		dryRun:           dryRun,
 		hasOpenChanges:   repoStatus.OpenChanges,
@@ -165,6 +170,24 @@ func determineKillConfig(args []string, repo *execute.OpenRepoResult, dryRun, ve
 	}, branchesSnapshot, stashSize, false, nil
 }
 
+// determineBranchWorktree provides the path of the other worktree that has the given branch checked out,
+// or an empty path if no other worktree has it checked out.
+// Errors if that worktree contains uncommitted changes because removing it would lose them.
+func determineBranchWorktree(branch gitdomain.LocalBranchName, worktrees gitdomain.Worktrees, repo *execute.OpenRepoResult) (gitdomain.RepoRootDir, error) {
+	worktree := worktrees.FindByBranch(branch)
+	if worktree == nil {
+		return gitdomain.EmptyRepoRootDir(), nil
+	}
+	worktreeStatus, err := repo.Runner.Backend.WorktreeStatus(worktree.Path)
+	if err != nil {
+		return worktree.Path, err
+	}
+	if worktreeStatus.OpenChanges {
+		return worktree.Path, fmt.Errorf(messages.WorktreeOpenChanges, branch, worktree.Path)
+	}
+	return worktree.Path, nil
+}
+
 func (self killConfig) branchToKillParent() gitdomain.LocalBranchName {
 	return self.Lineage.Parent(self.branchNameToKill.LocalName)
 }
@@ -172,7 +195,7 @@ func (self killConfig) branchToKillParent() gitdomain.LocalBranchName {
 func killProgram(config *killConfig) (runProgram, finalUndoProgram program.Program) {
 	prog := program.Program{}
 	switch config.branchTypeToKill {
-	case configdomain.BranchTypeFeatureBranch, configdomain.BranchTypeParkedBranch:
+	case configdomain.BranchTypeFeatureBranch, configdomain.BranchTypeParkedBranch, configdomain.BranchTypePrototypeBranch:
 		killFeatureBranch(&prog, &finalUndoProgram, config)
 	case configdomain.BranchTypeObservedBranch, configdomain.BranchTypeContributionBranch:
 		killLocalBranch(&prog, &finalUndoProgram, config)
@@ -209,6 +232,9 @@ func killLocalBranch(prog *program.Program, finalUndoProgram *program.Program, c
 		}
 		prog.Add(&opcodes.Checkout{Branch: config.branchWhenDone})
 	}
+	if !config.branchWorktree.IsEmpty() {
+		prog.Add(&opcodes.RemoveWorktree{Path: config.branchWorktree})
+	}
 	prog.Add(&opcodes.DeleteLocalBranch{Branch: config.branchNameToKill.LocalName})
 	if !config.dryRun {
 		sync.RemoveBranchFromLineage(sync.RemoveBranchFromLineageArgs{
@@ -217,12 +243,13 @@ func killLocalBranch(prog *program.Program, finalUndoProgram *program.Program, c
 			Parent:  config.branchToKillParent(),
 			Program: prog,
 		})
+		prog.Add(&opcodes.RemoveBranchSyncStrategy{Branch: config.branchNameToKill.LocalName})
 	}
 }
 
 func validateKillConfig(killConfig *killConfig) error {
 	switch killConfig.branchTypeToKill {
-	case configdomain.BranchTypeContributionBranch, configdomain.BranchTypeFeatureBranch, configdomain.BranchTypeObservedBranch, configdomain.BranchTypeParkedBranch:
+	case configdomain.BranchTypeContributionBranch, configdomain.BranchTypeFeatureBranch, configdomain.BranchTypeObservedBranch, configdomain.BranchTypeParkedBranch, configdomain.BranchTypePrototypeBranch:
 		return nil
 	case configdomain.BranchTypeMainBranch:
 		return errors.New(messages.KillCannotKillMainBranch)
@@ -0,0 +1,69 @@
+package lineage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
+	"github.com/git-town/git-town/v14/src/config/lineagefile"
+	"github.com/git-town/git-town/v14/src/execute"
+	"github.com/spf13/cobra"
+)
+
+const exportDesc = "Writes the lineage and branch types to a file"
+
+const exportHelp = `
+Writes the parent branches of your feature branches
+as well as your perennial, contribution, observed, and parked branches
+into the given file or to STDOUT if no file is given.
+You can load this file into another clone of this repository
+via "git town lineage import".`
+
+func exportCommand() *cobra.Command {
+	addFormatFlag, readFormatFlag := flags.String("format", "f", `file format: "toml" (default) or "json"`, flags.FlagTypeNonPersistent)
+	addVerboseFlag, readVerboseFlag := flags.Verbose()
+	cmd := cobra.Command{
+		Use:   "export [<file>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: exportDesc,
+		Long:  cmdhelpers.Long(exportDesc, exportHelp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeExport(args, readFormatFlag(cmd), readVerboseFlag(cmd))
+		},
+	}
+	addFormatFlag(&cmd)
+	addVerboseFlag(&cmd)
+	return &cmd
+}
+
+func executeExport(args []string, formatName string, verbose bool) error {
+	format := lineagefile.FormatTOML
+	if formatName != "" {
+		var err error
+		format, err = lineagefile.ParseFormat(formatName)
+		if err != nil {
+			return err
+		}
+	}
+	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
+		DryRun:           false,
+		OmitBranchNames:  true,
+		PrintCommands:    true,
+		ValidateGitRepo:  true,
+		ValidateIsOnline: false,
+		Verbose:          verbose,
+	})
+	if err != nil {
+		return err
+	}
+	content, err := lineagefile.Render(lineagefile.New(&repo.Runner.Config.FullConfig), format)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fmt.Print(content)
+		return nil
+	}
+	return os.WriteFile(args[0], []byte(content), 0o600)
+}
@@ -0,0 +1,82 @@
+package lineage
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
+	"github.com/git-town/git-town/v14/src/config/lineagefile"
+	"github.com/git-town/git-town/v14/src/execute"
+	"github.com/git-town/git-town/v14/src/messages"
+	"github.com/git-town/git-town/v14/src/undo/undoconfig"
+	configInterpreter "github.com/git-town/git-town/v14/src/vm/interpreter/config"
+	"github.com/spf13/cobra"
+)
+
+const importDesc = "Loads the lineage and branch types from a file"
+
+const importHelp = `
+Reads a file created by "git town lineage export"
+and merges the parent branches and branch types it contains
+into your Git Town configuration.
+Entries in the file override the existing entries for the same branch.
+Reads from STDIN if the given file is "-".
+
+You can undo the changes via "git town undo".`
+
+func importCommand() *cobra.Command {
+	addVerboseFlag, readVerboseFlag := flags.Verbose()
+	cmd := cobra.Command{
+		Use:   "import <file>",
+		Args:  cobra.ExactArgs(1),
+		Short: importDesc,
+		Long:  cmdhelpers.Long(importDesc, importHelp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeImport(args[0], readVerboseFlag(cmd))
+		},
+	}
+	addVerboseFlag(&cmd)
+	return &cmd
+}
+
+func executeImport(file string, verbose bool) error {
+	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
+		DryRun:           false,
+		OmitBranchNames:  true,
+		PrintCommands:    true,
+		ValidateGitRepo:  true,
+		ValidateIsOnline: false,
+		Verbose:          verbose,
+	})
+	if err != nil {
+		return err
+	}
+	var content []byte
+	if file == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return err
+	}
+	data, err := lineagefile.Parse(string(content))
+	if err != nil {
+		return err
+	}
+	lineageChanges, typeChanges, err := lineagefile.Import(data, repo.Runner.Config)
+	if err != nil {
+		return err
+	}
+	fmt.Printf(messages.LineageImported, lineageChanges, typeChanges)
+	return configInterpreter.Finished(configInterpreter.FinishedArgs{
+		BeginConfigSnapshot: repo.ConfigSnapshot,
+		Command:             "lineage import",
+		EndConfigSnapshot:   undoconfig.EmptyConfigSnapshot(),
+		RootDir:             repo.RootDir,
+		Runner:              repo.Runner,
+		Verbose:             verbose,
+	})
+}
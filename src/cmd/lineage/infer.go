@@ -0,0 +1,153 @@
+package lineage
+
+import (
+	"fmt"
+	"maps"
+	"os"
+
+	"github.com/git-town/git-town/v14/src/cli/dialog"
+	"github.com/git-town/git-town/v14/src/cli/dialog/components"
+	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cli/format"
+	"github.com/git-town/git-town/v14/src/cli/print"
+	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/config/lineageinfer"
+	"github.com/git-town/git-town/v14/src/execute"
+	"github.com/git-town/git-town/v14/src/hosting"
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+	"github.com/git-town/git-town/v14/src/undo/undoconfig"
+	configInterpreter "github.com/git-town/git-town/v14/src/vm/interpreter/config"
+	"github.com/spf13/cobra"
+)
+
+const inferDesc = "Proposes parent branches for all local branches that don't have one"
+
+const inferHelp = `
+Analyzes the commits in your local branches
+and the target branches of your open proposals
+to determine the parent of each local branch
+that has no parent branch configured yet.
+Displays the resulting branch hierarchy
+and lets you accept or edit it before saving.`
+
+func inferCommand() *cobra.Command {
+	addVerboseFlag, readVerboseFlag := flags.Verbose()
+	cmd := cobra.Command{
+		Use:   "infer",
+		Args:  cobra.NoArgs,
+		Short: inferDesc,
+		Long:  cmdhelpers.Long(inferDesc, inferHelp),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeInfer(readVerboseFlag(cmd))
+		},
+	}
+	addVerboseFlag(&cmd)
+	return &cmd
+}
+
+func executeInfer(verbose bool) error {
+	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
+		DryRun:           false,
+		OmitBranchNames:  false,
+		PrintCommands:    true,
+		ValidateGitRepo:  true,
+		ValidateIsOnline: false,
+		Verbose:          verbose,
+	})
+	if err != nil {
+		return err
+	}
+	dialogTestInputs := components.LoadTestInputs(os.Environ())
+	repoStatus, err := repo.Runner.Backend.RepoStatus()
+	if err != nil {
+		return err
+	}
+	branchesSnapshot, _, exit, err := execute.LoadRepoSnapshot(execute.LoadRepoSnapshotArgs{
+		DialogTestInputs:      dialogTestInputs,
+		Fetch:                 false,
+		FullConfig:            &repo.Runner.Config.FullConfig,
+		HandleUnfinishedState: true,
+		Repo:                  repo,
+		RepoStatus:            repoStatus,
+		ValidateIsConfigured:  true,
+		ValidateNoOpenChanges: false,
+		Verbose:               verbose,
+	})
+	if err != nil || exit {
+		return err
+	}
+	config := &repo.Runner.Config.FullConfig
+	localBranches := branchesSnapshot.Branches.LocalBranches().Names()
+	branchesToInfer := lineageinfer.BranchesToInfer(config, localBranches)
+	if len(branchesToInfer) == 0 {
+		fmt.Println(messages.LineageInferNothing)
+		return nil
+	}
+	var connector hostingdomain.Connector
+	if config.IsOnline() && hosting.HasAnyAPIToken(config) {
+		connector, err = hosting.NewAPIConnector(hosting.NewConnectorArgs{
+			FullConfig:      config,
+			HostingPlatform: config.HostingPlatform,
+			Log:             print.Logger{},
+			OriginURL:       repo.Runner.Config.OriginURL(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	proposalTargets, err := lineageinfer.ProposalTargets(connector, branchesToInfer)
+	if err != nil {
+		return err
+	}
+	inferred, err := lineageinfer.Infer(lineageinfer.InferArgs{
+		Backend:         &repo.Runner.Backend,
+		Config:          config,
+		LocalBranches:   localBranches,
+		ProposalTargets: proposalTargets,
+	})
+	if err != nil {
+		return err
+	}
+	printInferredLineage(config.Lineage, inferred)
+	newLineage, newPerennials, aborted, err := dialog.InferredLineage(dialog.InferredLineageArgs{
+		DialogTestInputs: &dialogTestInputs,
+		Inferred:         inferred,
+		Lineage:          config.Lineage,
+		LocalBranches:    localBranches,
+		MainBranch:       config.MainBranch,
+	})
+	if err != nil || aborted {
+		return err
+	}
+	for _, branch := range newLineage.BranchNames() {
+		if err = repo.Runner.Config.SetParent(branch, newLineage[branch]); err != nil {
+			return err
+		}
+	}
+	if len(newPerennials) > 0 {
+		if err = repo.Runner.Config.AddToPerennialBranches(newPerennials...); err != nil {
+			return err
+		}
+	}
+	return configInterpreter.Finished(configInterpreter.FinishedArgs{
+		BeginConfigSnapshot: repo.ConfigSnapshot,
+		Command:             "lineage infer",
+		EndConfigSnapshot:   undoconfig.EmptyConfigSnapshot(),
+		RootDir:             repo.RootDir,
+		Runner:              repo.Runner,
+		Verbose:             verbose,
+	})
+}
+
+// printInferredLineage prints the branch hierarchy that results from adding the given inferred entries to the given lineage.
+func printInferredLineage(lineage, inferred configdomain.Lineage) {
+	combined := configdomain.Lineage{}
+	maps.Copy(combined, lineage)
+	maps.Copy(combined, inferred)
+	fmt.Println(messages.LineageInferred)
+	fmt.Println()
+	fmt.Println(format.BranchLineage(combined))
+	fmt.Println()
+}
@@ -0,0 +1,22 @@
+package lineage
+
+import (
+	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
+	"github.com/spf13/cobra"
+)
+
+const lineageDesc = "Manages the parent branches of your feature branches"
+
+func RootCmd() *cobra.Command {
+	lineageCmd := cobra.Command{
+		Use:     "lineage",
+		GroupID: "lineage",
+		Args:    cobra.NoArgs,
+		Short:   lineageDesc,
+		Long:    cmdhelpers.Long(lineageDesc),
+	}
+	lineageCmd.AddCommand(exportCommand())
+	lineageCmd.AddCommand(importCommand())
+	lineageCmd.AddCommand(inferCommand())
+	return &lineageCmd
+}
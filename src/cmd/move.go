@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/git-town/git-town/v14/src/cli/dialog/components"
+	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/execute"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+	"github.com/git-town/git-town/v14/src/undo/undoconfig"
+	fullInterpreter "github.com/git-town/git-town/v14/src/vm/interpreter/full"
+	"github.com/git-town/git-town/v14/src/vm/opcodes"
+	"github.com/git-town/git-town/v14/src/vm/program"
+	"github.com/git-town/git-town/v14/src/vm/runstate"
+	"github.com/spf13/cobra"
+)
+
+const moveDesc = "Moves a branch and its descendants onto a new parent branch"
+
+const moveHelp = `
+Makes the given parent branch the new parent of the current branch, or of <branch> if given, and moves the commits of the branch and all its descendant branches onto the new parent.
+
+- changes the parent of the branch in the lineage
+- rebases the commits that the branch has in addition to its old parent onto the new parent
+- rebases the commits of all descendant branches onto their rebased parent branches
+- force-pushes the rebased branches that have a tracking branch
+
+This removes the commits of the old parent from the branch, so that they no longer show up in its proposal. If the rebase encounters merge conflicts, resolve them and run "git town continue", or run "git town undo" to go back to where you started.`
+
+func moveCommand() *cobra.Command {
+	addVerboseFlag, readVerboseFlag := flags.Verbose()
+	cmd := cobra.Command{
+		Use:     "move [<branch>] <parent>",
+		GroupID: "lineage",
+		Args:    cobra.RangeArgs(1, 2),
+		Short:   moveDesc,
+		Long:    cmdhelpers.Long(moveDesc, moveHelp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeMove(args, readVerboseFlag(cmd))
+		},
+	}
+	addVerboseFlag(&cmd)
+	return &cmd
+}
+
+func executeMove(args []string, verbose bool) error {
+	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
+		DryRun:           false,
+		OmitBranchNames:  false,
+		PrintCommands:    true,
+		ValidateGitRepo:  true,
+		ValidateIsOnline: false,
+		Verbose:          verbose,
+	})
+	if err != nil {
+		return err
+	}
+	config, initialBranchesSnapshot, initialStashSize, exit, err := determineMoveConfig(args, repo, verbose)
+	if err != nil || exit {
+		return err
+	}
+	runState := runstate.RunState{
+		BeginBranchesSnapshot: initialBranchesSnapshot,
+		BeginConfigSnapshot:   repo.ConfigSnapshot,
+		BeginStashSize:        initialStashSize,
+		Command:               "move",
+		DryRun:                false,
+		EndBranchesSnapshot:   gitdomain.EmptyBranchesSnapshot(),
+		EndConfigSnapshot:     undoconfig.EmptyConfigSnapshot(),
+		EndStashSize:          0,
+		RunProgram:            moveProgram(config),
+	}
+	return fullInterpreter.Execute(fullInterpreter.ExecuteArgs{
+		Connector:               nil,
+		DialogTestInputs:        &config.dialogTestInputs,
+		FullConfig:              config.FullConfig,
+		HasOpenChanges:          config.hasOpenChanges,
+		InitialBranchesSnapshot: initialBranchesSnapshot,
+		InitialConfigSnapshot:   repo.ConfigSnapshot,
+		InitialStashSize:        initialStashSize,
+		RootDir:                 repo.RootDir,
+		Run:                     repo.Runner,
+		RunState:                &runState,
+		Verbose:                 verbose,
+	})
+}
+
+type moveConfig struct {
+	*configdomain.FullConfig
+	allBranches      gitdomain.BranchInfos
+	branch           gitdomain.LocalBranchName
+	branchesToMove   gitdomain.LocalBranchNames // the branch to move and its local descendants, parents before their children
+	dialogTestInputs components.TestInputs
+	hasOpenChanges   bool
+	initialBranch    gitdomain.LocalBranchName
+	newParent        gitdomain.LocalBranchName
+	oldParent        gitdomain.LocalBranchName
+	previousBranch   gitdomain.LocalBranchName
+	remotes          gitdomain.Remotes
+}
+
+func determineMoveConfig(args []string, repo *execute.OpenRepoResult, verbose bool) (*moveConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
+	dialogTestInputs := components.LoadTestInputs(os.Environ())
+	repoStatus, err := repo.Runner.Backend.RepoStatus()
+	if err != nil {
+		return nil, gitdomain.EmptyBranchesSnapshot(), 0, false, err
+	}
+	branchesSnapshot, stashSize, exit, err := execute.LoadRepoSnapshot(execute.LoadRepoSnapshotArgs{
+		DialogTestInputs:      dialogTestInputs,
+		Fetch:                 false,
+		FullConfig:            &repo.Runner.Config.FullConfig,
+		HandleUnfinishedState: true,
+		Repo:                  repo,
+		RepoStatus:            repoStatus,
+		ValidateIsConfigured:  true,
+		ValidateNoOpenChanges: false,
+		Verbose:               verbose,
+	})
+	if err != nil || exit {
+		return nil, branchesSnapshot, stashSize, exit, err
+	}
+	branch := branchesSnapshot.Active
+	if len(args) == 2 {
+		branch = gitdomain.NewLocalBranchName(args[0])
+	}
+	newParent := gitdomain.NewLocalBranchName(args[len(args)-1])
+	if !branchesSnapshot.Branches.HasLocalBranch(branch) {
+		return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.BranchDoesntExist, branch)
+	}
+	if repo.Runner.Config.FullConfig.IsMainOrPerennialBranch(branch) {
+		return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.SetParentNoFeatureBranch, branch)
+	}
+	err = execute.EnsureKnownBranchAncestry(branch, execute.EnsureKnownBranchAncestryArgs{
+		Config:           &repo.Runner.Config.FullConfig,
+		AllBranches:      branchesSnapshot.Branches,
+		DefaultBranch:    repo.Runner.Config.FullConfig.MainBranch,
+		DialogTestInputs: &dialogTestInputs,
+		Runner:           repo.Runner,
+	})
+	if err != nil {
+		return nil, branchesSnapshot, stashSize, false, err
+	}
+	lineage := repo.Runner.Config.FullConfig.Lineage
+	if err = validateNewParent(branch, newParent, branchesSnapshot.Branches, lineage); err != nil {
+		return nil, branchesSnapshot, stashSize, false, err
+	}
+	oldParent := lineage.Parent(branch)
+	if !branchesSnapshot.Branches.HasLocalBranch(oldParent) {
+		return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.BranchDoesntExist, oldParent)
+	}
+	branchesToMove := gitdomain.LocalBranchNames{branch}
+	for _, descendant := range lineage.Descendants(branch) {
+		// descendants whose parent has no local branch don't get moved
+		// because there are no commits to rebase them onto
+		if branchesSnapshot.Branches.HasLocalBranch(descendant) && branchesToMove.Contains(lineage.Parent(descendant)) {
+			branchesToMove = append(branchesToMove, descendant)
+		}
+	}
+	for _, branchToMove := range branchesToMove {
+		branchInfo := branchesSnapshot.Branches.FindByLocalName(branchToMove)
+		if branchInfo.SyncStatus == gitdomain.SyncStatusOtherWorktree {
+			return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.MoveBranchOtherWorktree, branchToMove)
+		}
+	}
+	remotes, err := repo.Runner.Backend.Remotes()
+	if err != nil {
+		return nil, branchesSnapshot, stashSize, false, err
+	}
+	return &moveConfig{
+		FullConfig:       &repo.Runner.Config.FullConfig,
+		allBranches:      branchesSnapshot.Branches,
+		branch:           branch,
+		branchesToMove:   branchesToMove,
+		dialogTestInputs: dialogTestInputs,
+		hasOpenChanges:   repoStatus.OpenChanges,
+		initialBranch:    branchesSnapshot.Active,
+		newParent:        newParent,
+		oldParent:        oldParent,
+		previousBranch:   repo.Runner.Backend.PreviouslyCheckedOutBranch(),
+		remotes:          remotes,
+	}, branchesSnapshot, stashSize, false, nil
+}
+
+func moveProgram(config *moveConfig) program.Program {
+	prog := program.Program{}
+	if config.newParent != config.oldParent {
+		prog.Add(&opcodes.ChangeParent{Branch: config.branch, Parent: config.newParent})
+	}
+	for _, branchToMove := range config.branchesToMove {
+		branchInfo := config.allBranches.FindByLocalName(branchToMove)
+		var newBase gitdomain.LocalBranchName
+		var oldBase gitdomain.Location
+		if branchToMove == config.branch {
+			newBase = config.newParent
+			oldBase = config.oldParent.Location()
+		} else {
+			// the parent of this descendant has been rebased already,
+			// so the commits to leave behind are the ones the parent had before the move
+			newBase = config.Lineage.Parent(branchToMove)
+			oldBase = config.allBranches.FindByLocalName(newBase).LocalSHA.Location()
+		}
+		prog.Add(&opcodes.Checkout{Branch: branchToMove})
+		prog.Add(&opcodes.RebaseOnto{
+			BranchToRebaseOnto: newBase.BranchName(),
+			Upstream:           oldBase,
+		})
+		if branchInfo.HasTrackingBranch() && config.remotes.HasOrigin() && config.IsOnline() {
+			prog.Add(&opcodes.ForcePushCurrentBranch{})
+		}
+	}
+	prog.Add(&opcodes.Checkout{Branch: config.initialBranch})
+	cmdhelpers.Wrap(&prog, cmdhelpers.WrapOptions{
+		DryRun:                   false,
+		RunInGitRoot:             true,
+		StashOpenChanges:         config.hasOpenChanges,
+		PreviousBranchCandidates: gitdomain.LocalBranchNames{config.previousBranch},
+	})
+	return prog
+}
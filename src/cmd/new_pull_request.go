@@ -23,7 +23,7 @@ func newPullRequestCommand() *cobra.Command {
 		Long:    cmdhelpers.Long(proposeDesc, fmt.Sprintf(proposeHelp, gitconfig.KeyHostingPlatform, gitconfig.KeyHostingOriginHostname)),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			printDeprecationNotice()
-			result := executePropose(readDryRunFlag(cmd), readVerboseFlag(cmd))
+			result := executePropose("", "", "", false, false, false, readDryRunFlag(cmd), readVerboseFlag(cmd))
 			printDeprecationNotice()
 			return result
 		},
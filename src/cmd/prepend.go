@@ -26,11 +26,14 @@ const prependDesc = "Creates a new feature branch as the parent of the current b
 const prependHelp = `
 Syncs the parent branch, cuts a new feature branch with the given name off the parent branch, makes the new branch the parent of the current branch, pushes the new feature branch to the origin repository (if "push-new-branches" is true), and brings over all uncommitted changes to the new feature branch.
 
+With the "--worktree" flag, checks out the new branch in a new worktree and leaves the current worktree and its uncommitted changes as they are. The location of that worktree is the given path or the configured "worktree-path".
+
 See "sync" for upstream remote options.`
 
 func prependCommand() *cobra.Command {
 	addVerboseFlag, readVerboseFlag := flags.Verbose()
 	addDryRunFlag, readDryRunFlag := flags.DryRun()
+	addWorktreeFlag, readWorktreeFlag := flags.Worktree()
 	cmd := cobra.Command{
 		Use:     "prepend <branch>",
 		GroupID: "lineage",
@@ -38,15 +41,16 @@ func prependCommand() *cobra.Command {
 		Short:   prependDesc,
 		Long:    cmdhelpers.Long(prependDesc, prependHelp),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return executePrepend(args, readDryRunFlag(cmd), readVerboseFlag(cmd))
+			return executePrepend(args, readDryRunFlag(cmd), readVerboseFlag(cmd), readWorktreeFlag(cmd))
 		},
 	}
 	addDryRunFlag(&cmd)
 	addVerboseFlag(&cmd)
+	addWorktreeFlag(&cmd)
 	return &cmd
 }
 
-func executePrepend(args []string, dryRun, verbose bool) error {
+func executePrepend(args []string, dryRun, verbose bool, worktree configdomain.NewBranchWorktree) error {
 	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
 		DryRun:           dryRun,
 		OmitBranchNames:  false,
@@ -58,7 +62,7 @@ func executePrepend(args []string, dryRun, verbose bool) error {
 	if err != nil {
 		return err
 	}
-	config, initialBranchesSnapshot, initialStashSize, exit, err := determinePrependConfig(args, repo, dryRun, verbose)
+	config, initialBranchesSnapshot, initialStashSize, exit, err := determinePrependConfig(args, repo, dryRun, verbose, worktree)
 	if err != nil || exit {
 		return err
 	}
@@ -101,9 +105,10 @@ type prependConfig struct {
 	previousBranch            gitdomain.LocalBranchName
 	remotes                   gitdomain.Remotes
 	targetBranch              gitdomain.LocalBranchName
+	worktree                  gitdomain.RepoRootDir // the worktree in which to check out the new branch, empty to check it out in the current worktree
 }
 
-func determinePrependConfig(args []string, repo *execute.OpenRepoResult, dryRun, verbose bool) (*prependConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
+func determinePrependConfig(args []string, repo *execute.OpenRepoResult, dryRun, verbose bool, worktree configdomain.NewBranchWorktree) (*prependConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
 	dialogTestInputs := components.LoadTestInputs(os.Environ())
 	repoStatus, err := repo.Runner.Backend.RepoStatus()
 	if err != nil {
@@ -136,6 +141,10 @@ func determinePrependConfig(args []string, repo *execute.OpenRepoResult, dryRun,
 	if repo.Runner.Config.FullConfig.IsMainOrPerennialBranch(branchesSnapshot.Active) {
 		return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.SetParentNoFeatureBranch, branchesSnapshot.Active)
 	}
+	worktreePath, err := determineNewBranchWorktree(worktree, repo, targetBranch)
+	if err != nil {
+		return nil, branchesSnapshot, stashSize, false, err
+	}
 	err = execute.EnsureKnownBranchAncestry(branchesSnapshot.Active, execute.EnsureKnownBranchAncestryArgs{
 		Config:           &repo.Runner.Config.FullConfig,
 		AllBranches:      branchesSnapshot.Branches,
@@ -164,6 +173,7 @@ func determinePrependConfig(args []string, repo *execute.OpenRepoResult, dryRun,
 		previousBranch:            previousBranch,
 		remotes:                   remotes,
 		targetBranch:              targetBranch,
+		worktree:                  worktreePath,
 	}, branchesSnapshot, stashSize, false, fc.Err
 }
 
@@ -177,12 +187,21 @@ func prependProgram(config *prependConfig) program.Program {
 			Program:       &prog,
 			PushBranch:    true,
 			Remotes:       config.remotes,
+			RootDir:       gitdomain.EmptyRepoRootDir(),
+			Worktrees:     gitdomain.Worktrees{},
+		})
+	}
+	if config.worktree.IsEmpty() {
+		prog.Add(&opcodes.CreateAndCheckoutBranchExistingParent{
+			Ancestors: config.newBranchParentCandidates,
+			Branch:    config.targetBranch,
+		})
+	} else {
+		prog.Add(&opcodes.CreateBranchExistingParent{
+			Ancestors: config.newBranchParentCandidates,
+			Branch:    config.targetBranch,
 		})
 	}
-	prog.Add(&opcodes.CreateAndCheckoutBranchExistingParent{
-		Ancestors: config.newBranchParentCandidates,
-		Branch:    config.targetBranch,
-	})
 	// set the parent of the newly created branch
 	prog.Add(&opcodes.SetExistingParent{
 		Branch:    config.targetBranch,
@@ -196,6 +215,10 @@ func prependProgram(config *prependConfig) program.Program {
 	if config.remotes.HasOrigin() && config.ShouldPushNewBranches() && config.IsOnline() {
 		prog.Add(&opcodes.CreateTrackingBranch{Branch: config.targetBranch})
 	}
+	if !config.worktree.IsEmpty() {
+		prog.Add(&opcodes.Checkout{Branch: config.initialBranch})
+		prog.Add(&opcodes.AddWorktree{Branch: config.targetBranch, Path: config.worktree})
+	}
 	cmdhelpers.Wrap(&prog, cmdhelpers.WrapOptions{
 		DryRun:                   config.dryRun,
 		RunInGitRoot:             true,
@@ -3,7 +3,9 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 
 	"github.com/git-town/git-town/v14/src/cli/dialog/components"
 	"github.com/git-town/git-town/v14/src/cli/flags"
@@ -32,11 +34,21 @@ Syncs the current branch and opens a browser window to the new proposal page of
 
 The form is pre-populated for the current branch so that the proposal only shows the changes made against the immediate parent branch.
 
+When given a title, creates the proposal via the API of your code hosting platform and opens it in the browser. This requires an API token and works for GitHub, GitLab, and Gitea. Provide "--no-browser" to create proposals without opening a browser, for example in scripts. Running "git town undo" closes proposals created this way.
+
+With the --stack switch, syncs all branches in the current stack once and makes sure that each of them has a proposal targeting its parent branch. Creates missing proposals via the API, titled with the first commit message of the respective branch, and prints an overview of the proposals.
+
 Supported only for repositories hosted on GitHub, GitLab, Gitea and Bitbucket. When using self-hosted versions this command needs to be configured with "git config %s <driver>" where driver is "github", "gitlab", "gitea", or "bitbucket". When using SSH identities, this command needs to be configured with "git config %s <hostname>" where hostname matches what is in your ssh config file.`
 
 func proposeCommand() *cobra.Command {
 	addVerboseFlag, readVerboseFlag := flags.Verbose()
 	addDryRunFlag, readDryRunFlag := flags.DryRun()
+	addBodyFlag, readBodyFlag := flags.String("body", "b", "body of the proposal to create via the API", flags.FlagTypeNonPersistent)
+	addBodyFileFlag, readBodyFileFlag := flags.String("body-file", "f", `read the proposal body from the given file ("-" reads from STDIN)`, flags.FlagTypeNonPersistent)
+	addDraftFlag, readDraftFlag := flags.Bool("draft", "", "create the proposal as a draft", flags.FlagTypeNonPersistent)
+	addNoBrowserFlag, readNoBrowserFlag := flags.Bool("no-browser", "", "don't open the created proposal in the browser", flags.FlagTypeNonPersistent)
+	addStackFlag, readStackFlag := flags.Bool("stack", "s", "propose all branches in the current stack", flags.FlagTypeNonPersistent)
+	addTitleFlag, readTitleFlag := flags.String("title", "t", "create the proposal with this title via the API", flags.FlagTypeNonPersistent)
 	cmd := cobra.Command{
 		Use:     "propose",
 		GroupID: "basic",
@@ -44,15 +56,25 @@ func proposeCommand() *cobra.Command {
 		Short:   proposeDesc,
 		Long:    cmdhelpers.Long(proposeDesc, fmt.Sprintf(proposeHelp, gitconfig.KeyHostingPlatform, gitconfig.KeyHostingOriginHostname)),
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return executePropose(readDryRunFlag(cmd), readVerboseFlag(cmd))
+			return executePropose(readTitleFlag(cmd), readBodyFlag(cmd), readBodyFileFlag(cmd), readDraftFlag(cmd), readNoBrowserFlag(cmd), readStackFlag(cmd), readDryRunFlag(cmd), readVerboseFlag(cmd))
 		},
 	}
+	addBodyFlag(&cmd)
+	addBodyFileFlag(&cmd)
+	addDraftFlag(&cmd)
 	addDryRunFlag(&cmd)
+	addNoBrowserFlag(&cmd)
+	addStackFlag(&cmd)
+	addTitleFlag(&cmd)
 	addVerboseFlag(&cmd)
 	return &cmd
 }
 
-func executePropose(dryRun, verbose bool) error {
+func executePropose(title, body, bodyFile string, draft, noBrowser, stack, dryRun, verbose bool) error {
+	body, err := proposalBody(body, bodyFile)
+	if err != nil {
+		return err
+	}
 	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
 		DryRun:           dryRun,
 		OmitBranchNames:  false,
@@ -64,7 +86,7 @@ func executePropose(dryRun, verbose bool) error {
 	if err != nil {
 		return err
 	}
-	config, initialBranchesSnapshot, initialStashSize, exit, err := determineProposeConfig(repo, dryRun, verbose)
+	config, initialBranchesSnapshot, initialStashSize, exit, err := determineProposeConfig(repo, title, body, draft, noBrowser, stack, dryRun, verbose)
 	if err != nil || exit {
 		return err
 	}
@@ -99,18 +121,24 @@ func executePropose(dryRun, verbose bool) error {
 
 type proposeConfig struct {
 	*configdomain.FullConfig
-	allBranches      gitdomain.BranchInfos
-	branchesToSync   gitdomain.BranchInfos
-	connector        hostingdomain.Connector
-	dialogTestInputs components.TestInputs
-	dryRun           bool
-	hasOpenChanges   bool
-	initialBranch    gitdomain.LocalBranchName
-	previousBranch   gitdomain.LocalBranchName
-	remotes          gitdomain.Remotes
+	allBranches       gitdomain.BranchInfos
+	branchesToPropose gitdomain.LocalBranchNames
+	branchesToSync    gitdomain.BranchInfos
+	connector         hostingdomain.Connector
+	dialogTestInputs  components.TestInputs
+	draft             bool
+	dryRun            bool
+	hasOpenChanges    bool
+	initialBranch     gitdomain.LocalBranchName
+	noBrowser         bool
+	previousBranch    gitdomain.LocalBranchName
+	proposalBody      string
+	proposalTitle     string
+	remotes           gitdomain.Remotes
+	stack             bool
 }
 
-func determineProposeConfig(repo *execute.OpenRepoResult, dryRun, verbose bool) (*proposeConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
+func determineProposeConfig(repo *execute.OpenRepoResult, title, body string, draft, noBrowser, stack, dryRun, verbose bool) (*proposeConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
 	dialogTestInputs := components.LoadTestInputs(os.Environ())
 	repoStatus, err := repo.Runner.Backend.RepoStatus()
 	if err != nil {
@@ -158,19 +186,31 @@ func determineProposeConfig(repo *execute.OpenRepoResult, dryRun, verbose bool)
 	if connector == nil {
 		return nil, branchesSnapshot, stashSize, false, hostingdomain.UnsupportedServiceError()
 	}
-	branchNamesToSync := repo.Runner.Config.FullConfig.Lineage.BranchAndAncestors(branchesSnapshot.Active)
+	var branchesToPropose gitdomain.LocalBranchNames
+	if stack {
+		branchesToPropose = repo.Runner.Config.FullConfig.Lineage.BranchLineageWithoutRoot(branchesSnapshot.Active)
+	} else {
+		branchesToPropose = gitdomain.LocalBranchNames{branchesSnapshot.Active}
+	}
+	branchNamesToSync := repo.Runner.Config.FullConfig.Lineage.BranchesAndAncestors(slices.Clone(branchesToPropose))
 	branchesToSync, err := branchesSnapshot.Branches.Select(branchNamesToSync)
 	return &proposeConfig{
-		FullConfig:       &repo.Runner.Config.FullConfig,
-		allBranches:      branchesSnapshot.Branches,
-		branchesToSync:   branchesToSync,
-		connector:        connector,
-		dialogTestInputs: dialogTestInputs,
-		dryRun:           dryRun,
-		hasOpenChanges:   repoStatus.OpenChanges,
-		initialBranch:    branchesSnapshot.Active,
-		previousBranch:   previousBranch,
-		remotes:          remotes,
+		FullConfig:        &repo.Runner.Config.FullConfig,
+		allBranches:       branchesSnapshot.Branches,
+		branchesToPropose: branchesToPropose,
+		branchesToSync:    branchesToSync,
+		connector:         connector,
+		dialogTestInputs:  dialogTestInputs,
+		draft:             draft,
+		dryRun:            dryRun,
+		hasOpenChanges:    repoStatus.OpenChanges,
+		initialBranch:     branchesSnapshot.Active,
+		noBrowser:         noBrowser,
+		previousBranch:    previousBranch,
+		proposalBody:      body,
+		proposalTitle:     title,
+		remotes:           remotes,
+		stack:             stack,
 	}, branchesSnapshot, stashSize, false, err
 }
 
@@ -184,6 +224,8 @@ func proposeProgram(config *proposeConfig) program.Program {
 			Remotes:       config.remotes,
 			Program:       &prog,
 			PushBranch:    true,
+			RootDir:       gitdomain.EmptyRepoRootDir(),
+			Worktrees:     gitdomain.Worktrees{},
 		})
 	}
 	cmdhelpers.Wrap(&prog, cmdhelpers.WrapOptions{
@@ -192,13 +234,73 @@ func proposeProgram(config *proposeConfig) program.Program {
 		StashOpenChanges:         config.hasOpenChanges,
 		PreviousBranchCandidates: gitdomain.LocalBranchNames{config.previousBranch},
 	})
-	prog.Add(&opcodes.CreateProposal{Branch: config.initialBranch})
+	if config.stack {
+		for _, branch := range config.branchesToPropose {
+			prog.Add(&opcodes.EnsureProposal{
+				Branch: branch,
+				Draft:  config.draft,
+			})
+		}
+		prog.Add(&opcodes.UpdateProposalStacks{Branch: config.initialBranch})
+		prog.Add(&opcodes.PrintProposals{Branches: config.branchesToPropose})
+		return prog
+	}
+	prog.Add(&opcodes.CreateProposal{
+		Body:      config.proposalBody,
+		Branch:    config.initialBranch,
+		Draft:     config.draft,
+		NoBrowser: config.noBrowser,
+		Title:     config.proposalTitle,
+	})
+	if config.proposalTitle != "" {
+		prog.Add(&opcodes.UpdateProposalStacks{Branch: config.initialBranch})
+	}
 	return prog
 }
 
+// proposalBody provides the body of the proposal to create,
+// given either directly or via the file with the given name.
+func proposalBody(body, bodyFile string) (string, error) {
+	if bodyFile == "" {
+		return body, nil
+	}
+	if body != "" {
+		return "", errors.New(messages.ProposeBodyAndBodyFile)
+	}
+	var content []byte
+	var err error
+	if bodyFile == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(bodyFile)
+	}
+	if err != nil {
+		return "", fmt.Errorf(messages.ProposeBodyFileProblem, bodyFile, err)
+	}
+	return string(content), nil
+}
+
 func validateProposeConfig(config *proposeConfig) error {
-	initialBranchType := config.FullConfig.BranchType(config.initialBranch)
-	switch initialBranchType {
+	if config.stack {
+		if config.proposalTitle != "" || config.proposalBody != "" {
+			return errors.New(messages.ProposeStackWithTitle)
+		}
+	} else if config.proposalTitle == "" && (config.proposalBody != "" || config.draft || config.noBrowser) {
+		return errors.New(messages.ProposalTitleMissing)
+	}
+	if err := validateProposeBranchType(config.FullConfig.BranchType(config.initialBranch)); err != nil {
+		return err
+	}
+	for _, branch := range config.branchesToPropose {
+		if err := validateProposeBranchType(config.FullConfig.BranchType(branch)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateProposeBranchType(branchType configdomain.BranchType) error {
+	switch branchType {
 	case configdomain.BranchTypeFeatureBranch, configdomain.BranchTypeParkedBranch:
 		return nil
 	case configdomain.BranchTypeMainBranch:
@@ -209,6 +311,8 @@ func validateProposeConfig(config *proposeConfig) error {
 		return errors.New(messages.ObservedBranchCannotPropose)
 	case configdomain.BranchTypePerennialBranch:
 		return errors.New(messages.PerennialBranchCannotPropose)
+	case configdomain.BranchTypePrototypeBranch:
+		return errors.New(messages.PrototypeBranchCannotPropose)
 	}
-	panic(fmt.Sprintf("unhandled branch type: %v", initialBranchType))
+	panic(fmt.Sprintf("unhandled branch type: %v", branchType))
 }
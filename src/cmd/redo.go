@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/git-town/git-town/v14/src/cli/dialog/components"
+	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cli/print"
+	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/execute"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/hosting"
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+	"github.com/git-town/git-town/v14/src/undo"
+	"github.com/git-town/git-town/v14/src/undo/undoconfig"
+	fullInterpreter "github.com/git-town/git-town/v14/src/vm/interpreter/full"
+	"github.com/git-town/git-town/v14/src/vm/runstate"
+	"github.com/git-town/git-town/v14/src/vm/statefile"
+	"github.com/spf13/cobra"
+)
+
+const redoDesc = "Re-applies the most recently undone Git Town command"
+
+const redoHelp = `
+Reverts the effect of "git town undo".
+Running "git town redo" repeatedly re-applies the undone commands
+in the opposite order in which they were undone.
+
+Running any other Git Town command that changes the repository
+makes the undone commands no longer redoable.`
+
+func redoCmd() *cobra.Command {
+	addVerboseFlag, readVerboseFlag := flags.Verbose()
+	addDryRunFlag, readDryRunFlag := flags.DryRun()
+	cmd := cobra.Command{
+		Use:     "redo",
+		GroupID: "errors",
+		Args:    cobra.NoArgs,
+		Short:   redoDesc,
+		Long:    cmdhelpers.Long(redoDesc, redoHelp),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeRedo(readDryRunFlag(cmd), readVerboseFlag(cmd))
+		},
+	}
+	addDryRunFlag(&cmd)
+	addVerboseFlag(&cmd)
+	return &cmd
+}
+
+func executeRedo(dryRun, verbose bool) error {
+	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
+		DryRun:           dryRun,
+		OmitBranchNames:  false,
+		PrintCommands:    true,
+		ValidateGitRepo:  true,
+		ValidateIsOnline: false,
+		Verbose:          verbose,
+	})
+	if err != nil {
+		return err
+	}
+	config, initialBranchesSnapshot, initialStashSize, exit, err := determineRedoConfig(repo, verbose)
+	if err != nil || exit {
+		return err
+	}
+	redoable, err := statefile.LoadRedoable(repo.RootDir)
+	if err != nil {
+		return fmt.Errorf(messages.RunstateLoadProblem, err)
+	}
+	undoneRunState := redoable.Pop()
+	if undoneRunState == nil {
+		fmt.Println(messages.RedoNothingToDo)
+		return nil
+	}
+	worktrees, err := undo.DetermineWorktrees(&repo.Runner.Backend, repo.RootDir, *undoneRunState)
+	if err != nil {
+		return err
+	}
+	runState := runstate.RunState{
+		BeginBranchesSnapshot: initialBranchesSnapshot,
+		BeginConfigSnapshot:   repo.ConfigSnapshot,
+		BeginStashSize:        initialStashSize,
+		Command:               "redo",
+		DryRun:                dryRun,
+		EndBranchesSnapshot:   gitdomain.EmptyBranchesSnapshot(),
+		EndConfigSnapshot:     undoconfig.EmptyConfigSnapshot(),
+		EndStashSize:          0,
+		RunProgram: undo.CreateRedoProgram(undo.CreateUndoProgramArgs{
+			DryRun:         dryRun,
+			HasOpenChanges: config.hasOpenChanges,
+			NoPushHook:     config.NoPushHook(),
+			RootDir:        repo.RootDir,
+			Run:            repo.Runner,
+			RunState:       *undoneRunState,
+			Worktrees:      worktrees,
+		}),
+	}
+	return fullInterpreter.Execute(fullInterpreter.ExecuteArgs{
+		Connector:               config.connector,
+		DialogTestInputs:        &config.dialogTestInputs,
+		FullConfig:              config.FullConfig,
+		HasOpenChanges:          config.hasOpenChanges,
+		InitialBranchesSnapshot: initialBranchesSnapshot,
+		InitialConfigSnapshot:   repo.ConfigSnapshot,
+		InitialStashSize:        initialStashSize,
+		RootDir:                 repo.RootDir,
+		Run:                     repo.Runner,
+		RunState:                &runState,
+		Verbose:                 verbose,
+	})
+}
+
+type redoConfig struct {
+	*configdomain.FullConfig
+	connector        hostingdomain.Connector
+	dialogTestInputs components.TestInputs
+	hasOpenChanges   bool
+}
+
+func determineRedoConfig(repo *execute.OpenRepoResult, verbose bool) (*redoConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
+	dialogTestInputs := components.LoadTestInputs(os.Environ())
+	repoStatus, err := repo.Runner.Backend.RepoStatus()
+	if err != nil {
+		return nil, gitdomain.EmptyBranchesSnapshot(), 0, false, err
+	}
+	branchesSnapshot, stashSize, exit, err := execute.LoadRepoSnapshot(execute.LoadRepoSnapshotArgs{
+		DialogTestInputs:      dialogTestInputs,
+		Fetch:                 false,
+		FullConfig:            &repo.Runner.Config.FullConfig,
+		HandleUnfinishedState: true,
+		Repo:                  repo,
+		RepoStatus:            repoStatus,
+		ValidateIsConfigured:  true,
+		ValidateNoOpenChanges: false,
+		Verbose:               verbose,
+	})
+	if err != nil || exit {
+		return nil, branchesSnapshot, stashSize, exit, err
+	}
+	originURL := repo.Runner.Config.OriginURL()
+	connector, err := hosting.NewConnector(hosting.NewConnectorArgs{
+		FullConfig:      &repo.Runner.Config.FullConfig,
+		HostingPlatform: repo.Runner.Config.FullConfig.HostingPlatform,
+		Log:             print.Logger{},
+		OriginURL:       originURL,
+	})
+	return &redoConfig{
+		FullConfig:       &repo.Runner.Config.FullConfig,
+		connector:        connector,
+		dialogTestInputs: dialogTestInputs,
+		hasOpenChanges:   repoStatus.OpenChanges,
+	}, branchesSnapshot, stashSize, false, err
+}
@@ -190,6 +190,10 @@ func renameBranchProgram(config *renameBranchConfig) program.Program {
 		} else {
 			result.Add(&opcodes.DeleteParentBranch{Branch: config.oldBranch.LocalName})
 			result.Add(&opcodes.SetParent{Branch: config.newBranch, Parent: config.Lineage.Parent(config.oldBranch.LocalName)})
+			if strategy, hasStrategy := config.BranchSyncStrategies[config.oldBranch.LocalName]; hasStrategy {
+				result.Add(&opcodes.RemoveBranchSyncStrategy{Branch: config.oldBranch.LocalName})
+				result.Add(&opcodes.SetBranchSyncStrategy{Branch: config.newBranch, Strategy: strategy})
+			}
 		}
 	}
 	for _, child := range config.Lineage.Children(config.oldBranch.LocalName) {
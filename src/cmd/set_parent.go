@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -8,31 +9,53 @@ import (
 	"github.com/git-town/git-town/v14/src/cli/flags"
 	"github.com/git-town/git-town/v14/src/cli/print"
 	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/execute"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
 	"github.com/git-town/git-town/v14/src/messages"
+	"github.com/git-town/git-town/v14/src/undo/undoconfig"
+	fullInterpreter "github.com/git-town/git-town/v14/src/vm/interpreter/full"
+	"github.com/git-town/git-town/v14/src/vm/opcodes"
+	"github.com/git-town/git-town/v14/src/vm/program"
+	"github.com/git-town/git-town/v14/src/vm/runstate"
 	"github.com/spf13/cobra"
 )
 
-const setParentDesc = "Prompts to set the parent branch for the current branch"
+const setParentDesc = "Sets the parent branch for the current branch"
+
+const setParentHelp = `
+Without arguments, prompts for the new parent branch of the current branch.
+
+With one argument, makes the given branch the parent of the current branch. With two arguments, makes the second branch the parent of the first branch.
+
+With the --rebase switch, moves the commits that the branch has in addition to its old parent onto the new parent branch and force-pushes the branch if it has a tracking branch.
+
+With the --merge switch, merges the new parent branch into the branch and pushes the branch if it has a tracking branch.`
 
 func setParentCommand() *cobra.Command {
 	addVerboseFlag, readVerboseFlag := flags.Verbose()
+	addMergeFlag, readMergeFlag := flags.Bool("merge", "m", "merge the new parent into the branch", flags.FlagTypeNonPersistent)
+	addRebaseFlag, readRebaseFlag := flags.Bool("rebase", "r", "rebase the branch onto its new parent", flags.FlagTypeNonPersistent)
 	cmd := cobra.Command{
-		Use:     "set-parent",
+		Use:     "set-parent [<branch>] [<parent>]",
 		GroupID: "lineage",
-		Args:    cobra.NoArgs,
+		Args:    cobra.MaximumNArgs(2),
 		Short:   setParentDesc,
-		Long:    cmdhelpers.Long(setParentDesc),
-		RunE: func(cmd *cobra.Command, _ []string) error {
-			return executeSetParent(readVerboseFlag(cmd))
+		Long:    cmdhelpers.Long(setParentDesc, setParentHelp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeSetParent(args, readMergeFlag(cmd), readRebaseFlag(cmd), readVerboseFlag(cmd))
 		},
 	}
+	addMergeFlag(&cmd)
+	addRebaseFlag(&cmd)
 	addVerboseFlag(&cmd)
 	return &cmd
 }
 
-func executeSetParent(verbose bool) error {
-	dialogTestInputs := components.LoadTestInputs(os.Environ())
+func executeSetParent(args []string, merge, rebase, verbose bool) error {
+	if merge && rebase {
+		return errors.New(messages.SetParentMergeAndRebase)
+	}
 	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
 		DryRun:           false,
 		OmitBranchNames:  false,
@@ -44,11 +67,62 @@ func executeSetParent(verbose bool) error {
 	if err != nil {
 		return err
 	}
+	config, initialBranchesSnapshot, initialStashSize, exit, err := determineSetParentConfig(args, repo, merge, rebase, verbose)
+	if err != nil || exit {
+		return err
+	}
+	if !usesSetParentProgram(args, merge, rebase) {
+		// the dialog has already stored the new parent
+		print.Footer(verbose, repo.Runner.CommandsCounter.Count(), print.NoFinalMessages)
+		return nil
+	}
+	runState := runstate.RunState{
+		BeginBranchesSnapshot: initialBranchesSnapshot,
+		BeginConfigSnapshot:   repo.ConfigSnapshot,
+		BeginStashSize:        initialStashSize,
+		Command:               "set-parent",
+		DryRun:                false,
+		EndBranchesSnapshot:   gitdomain.EmptyBranchesSnapshot(),
+		EndConfigSnapshot:     undoconfig.EmptyConfigSnapshot(),
+		EndStashSize:          0,
+		RunProgram:            setParentProgram(config),
+	}
+	return fullInterpreter.Execute(fullInterpreter.ExecuteArgs{
+		Connector:               nil,
+		DialogTestInputs:        &config.dialogTestInputs,
+		FullConfig:              config.FullConfig,
+		HasOpenChanges:          config.hasOpenChanges,
+		InitialBranchesSnapshot: initialBranchesSnapshot,
+		InitialConfigSnapshot:   repo.ConfigSnapshot,
+		InitialStashSize:        initialStashSize,
+		RootDir:                 repo.RootDir,
+		Run:                     repo.Runner,
+		RunState:                &runState,
+		Verbose:                 verbose,
+	})
+}
+
+type setParentConfig struct {
+	*configdomain.FullConfig
+	branch           gitdomain.BranchInfo
+	dialogTestInputs components.TestInputs
+	hasOpenChanges   bool
+	initialBranch    gitdomain.LocalBranchName
+	merge            bool
+	newParent        gitdomain.LocalBranchName
+	oldParent        gitdomain.LocalBranchName
+	previousBranch   gitdomain.LocalBranchName
+	rebase           bool
+	remotes          gitdomain.Remotes
+}
+
+func determineSetParentConfig(args []string, repo *execute.OpenRepoResult, merge, rebase, verbose bool) (*setParentConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
+	dialogTestInputs := components.LoadTestInputs(os.Environ())
 	repoStatus, err := repo.Runner.Backend.RepoStatus()
 	if err != nil {
-		return err
+		return nil, gitdomain.EmptyBranchesSnapshot(), 0, false, err
 	}
-	branchesSnapshot, _, exit, err := execute.LoadRepoSnapshot(execute.LoadRepoSnapshotArgs{
+	branchesSnapshot, stashSize, exit, err := execute.LoadRepoSnapshot(execute.LoadRepoSnapshotArgs{
 		DialogTestInputs:      dialogTestInputs,
 		Fetch:                 false,
 		FullConfig:            &repo.Runner.Config.FullConfig,
@@ -60,29 +134,131 @@ func executeSetParent(verbose bool) error {
 		Verbose:               verbose,
 	})
 	if err != nil || exit {
-		return err
+		return nil, branchesSnapshot, stashSize, exit, err
+	}
+	branchName := branchesSnapshot.Active
+	if len(args) == 2 {
+		branchName = gitdomain.NewLocalBranchName(args[0])
+	}
+	branch := branchesSnapshot.Branches.FindByLocalName(branchName)
+	if branch == nil {
+		return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.BranchDoesntExist, branchName)
 	}
-	if repo.Runner.Config.FullConfig.IsMainOrPerennialBranch(branchesSnapshot.Active) {
-		return fmt.Errorf(messages.SetParentNoFeatureBranch, branchesSnapshot.Active)
+	if repo.Runner.Config.FullConfig.IsMainOrPerennialBranch(branchName) {
+		return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.SetParentNoFeatureBranch, branchName)
 	}
-	existingParent := repo.Runner.Config.FullConfig.Lineage.Parent(branchesSnapshot.Active)
-	if !existingParent.IsEmpty() {
+	oldParent := repo.Runner.Config.FullConfig.Lineage.Parent(branchName)
+	var newParent gitdomain.LocalBranchName
+	if len(args) == 0 {
+		newParent, err = enterParent(branchName, oldParent, branchesSnapshot, repo, dialogTestInputs)
+		if err != nil {
+			return nil, branchesSnapshot, stashSize, false, err
+		}
+	} else {
+		newParent = gitdomain.NewLocalBranchName(args[len(args)-1])
+		if err = validateNewParent(branchName, newParent, branchesSnapshot.Branches, repo.Runner.Config.FullConfig.Lineage); err != nil {
+			return nil, branchesSnapshot, stashSize, false, err
+		}
+	}
+	if rebase && !oldParent.IsEmpty() && !branchesSnapshot.Branches.HasLocalBranch(oldParent) {
+		return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.BranchDoesntExist, oldParent)
+	}
+	remotes := gitdomain.Remotes{}
+	previousBranch := gitdomain.EmptyLocalBranchName()
+	if usesSetParentProgram(args, merge, rebase) {
+		remotes, err = repo.Runner.Backend.Remotes()
+		if err != nil {
+			return nil, branchesSnapshot, stashSize, false, err
+		}
+		previousBranch = repo.Runner.Backend.PreviouslyCheckedOutBranch()
+	}
+	return &setParentConfig{
+		FullConfig:       &repo.Runner.Config.FullConfig,
+		branch:           *branch,
+		dialogTestInputs: dialogTestInputs,
+		hasOpenChanges:   repoStatus.OpenChanges,
+		initialBranch:    branchesSnapshot.Active,
+		merge:            merge,
+		newParent:        newParent,
+		oldParent:        oldParent,
+		previousBranch:   previousBranch,
+		rebase:           rebase,
+		remotes:          remotes,
+	}, branchesSnapshot, stashSize, false, nil
+}
+
+// enterParent lets the user select the new parent for the given branch
+// and provides the selected parent branch.
+// The dialog stores the selected parent in the Git Town configuration.
+func enterParent(branch, oldParent gitdomain.LocalBranchName, branchesSnapshot gitdomain.BranchesSnapshot, repo *execute.OpenRepoResult, dialogTestInputs components.TestInputs) (gitdomain.LocalBranchName, error) {
+	defaultParent := oldParent
+	if !oldParent.IsEmpty() {
 		// TODO: delete the old parent only when the user has entered a new parent
-		repo.Runner.Config.RemoveParent(branchesSnapshot.Active)
+		repo.Runner.Config.RemoveParent(branch)
 		repo.Runner.Config.Reload()
 	} else {
-		existingParent = repo.Runner.Config.FullConfig.MainBranch
+		defaultParent = repo.Runner.Config.FullConfig.MainBranch
 	}
-	err = execute.EnsureKnownBranchAncestry(branchesSnapshot.Active, execute.EnsureKnownBranchAncestryArgs{
+	err := execute.EnsureKnownBranchAncestry(branch, execute.EnsureKnownBranchAncestryArgs{
 		Config:           &repo.Runner.Config.FullConfig,
 		AllBranches:      branchesSnapshot.Branches,
-		DefaultBranch:    existingParent,
+		DefaultBranch:    defaultParent,
 		DialogTestInputs: &dialogTestInputs,
 		Runner:           repo.Runner,
 	})
-	if err != nil {
-		return err
+	return repo.Runner.Config.FullConfig.Lineage.Parent(branch), err
+}
+
+func setParentProgram(config *setParentConfig) program.Program {
+	prog := program.Program{}
+	if config.Lineage.Parent(config.branch.LocalName) != config.newParent {
+		prog.Add(&opcodes.ChangeParent{Branch: config.branch.LocalName, Parent: config.newParent})
+	}
+	canPush := config.branch.HasTrackingBranch() && config.remotes.HasOrigin() && config.IsOnline()
+	if config.rebase && !config.oldParent.IsEmpty() && !config.newParent.IsEmpty() && config.oldParent != config.newParent {
+		prog.Add(&opcodes.Checkout{Branch: config.branch.LocalName})
+		prog.Add(&opcodes.RebaseOnto{
+			BranchToRebaseOnto: config.newParent.BranchName(),
+			Upstream:           config.oldParent.Location(),
+		})
+		if canPush {
+			prog.Add(&opcodes.ForcePushCurrentBranch{})
+		}
+		prog.Add(&opcodes.Checkout{Branch: config.initialBranch})
+	}
+	if config.merge && !config.newParent.IsEmpty() && config.oldParent != config.newParent {
+		prog.Add(&opcodes.Checkout{Branch: config.branch.LocalName})
+		prog.Add(&opcodes.Merge{Branch: config.newParent.BranchName()})
+		if canPush {
+			prog.Add(&opcodes.PushCurrentBranch{CurrentBranch: config.branch.LocalName})
+		}
+		prog.Add(&opcodes.Checkout{Branch: config.initialBranch})
+	}
+	cmdhelpers.Wrap(&prog, cmdhelpers.WrapOptions{
+		DryRun:                   false,
+		RunInGitRoot:             true,
+		StashOpenChanges:         config.hasOpenChanges && (config.merge || config.rebase),
+		PreviousBranchCandidates: gitdomain.LocalBranchNames{config.previousBranch},
+	})
+	return prog
+}
+
+// usesSetParentProgram indicates whether set-parent runs a program
+// rather than only storing the parent that the user has entered into the dialog.
+func usesSetParentProgram(args []string, merge, rebase bool) bool {
+	return len(args) > 0 || merge || rebase
+}
+
+// validateNewParent verifies that the given new parent can become the parent of the given branch.
+func validateNewParent(branch, newParent gitdomain.LocalBranchName, branches gitdomain.BranchInfos, lineage configdomain.Lineage) error {
+	if newParent == branch {
+		return fmt.Errorf(messages.SetParentSelf, branch)
+	}
+	if !branches.HasLocalBranch(newParent) {
+		return fmt.Errorf(messages.BranchDoesntExist, newParent)
+	}
+	if lineage.IsAncestor(branch, newParent) {
+		return fmt.Errorf(messages.SetParentCycle, branch, newParent)
 	}
-	print.Footer(verbose, repo.Runner.CommandsCounter.Count(), print.NoFinalMessages)
 	return nil
 }
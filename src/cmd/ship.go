@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/git-town/git-town/v14/src/cli/dialog/components"
 	"github.com/git-town/git-town/v14/src/cli/flags"
@@ -40,8 +41,9 @@ Squash-merges the current branch, or <branch_name> if given, into the main branc
   with commit message specified by the user
 - pushes the main branch to the origin repository
 - deletes <branch_name> from the local and origin repositories
+  and removes the worktree that has it checked out
 
-Ships direct children of the main branch. To ship a child branch, ship or kill all ancestor branches first.
+Ships direct children of the main branch. To ship a child branch, ship or kill all ancestor branches first, or use the --stack switch to ship the branch together with all its ancestor branches. This ships the ancestor branches one after the other, beginning with the oldest one, into the main branch.
 
 If you use GitHub, this command can squash merge pull requests via the GitHub API. Setup:
 
@@ -56,6 +58,7 @@ func shipCmd() *cobra.Command {
 	addVerboseFlag, readVerboseFlag := flags.Verbose()
 	addMessageFlag, readMessageFlag := flags.CommitMessage("Specify the commit message for the squash commit")
 	addDryRunFlag, readDryRunFlag := flags.DryRun()
+	addStackFlag, readStackFlag := flags.Bool("stack", "s", "ship the branch together with all its ancestor branches", flags.FlagTypeNonPersistent)
 	cmd := cobra.Command{
 		Use:     "ship",
 		GroupID: "basic",
@@ -63,16 +66,20 @@ func shipCmd() *cobra.Command {
 		Short:   shipDesc,
 		Long:    cmdhelpers.Long(shipDesc, fmt.Sprintf(shipHelp, gitconfig.KeyGithubToken, gitconfig.KeyShipDeleteTrackingBranch)),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return executeShip(args, readMessageFlag(cmd), readDryRunFlag(cmd), readVerboseFlag(cmd))
+			return executeShip(args, readMessageFlag(cmd), readStackFlag(cmd), readDryRunFlag(cmd), readVerboseFlag(cmd))
 		},
 	}
 	addDryRunFlag(&cmd)
+	addStackFlag(&cmd)
 	addVerboseFlag(&cmd)
 	addMessageFlag(&cmd)
 	return &cmd
 }
 
-func executeShip(args []string, message gitdomain.CommitMessage, dryRun, verbose bool) error {
+func executeShip(args []string, message gitdomain.CommitMessage, stack, dryRun, verbose bool) error {
+	if stack && message != "" {
+		return errors.New(messages.ShipStackWithMessage)
+	}
 	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
 		DryRun:           dryRun,
 		OmitBranchNames:  false,
@@ -84,11 +91,11 @@ func executeShip(args []string, message gitdomain.CommitMessage, dryRun, verbose
 	if err != nil {
 		return err
 	}
-	config, initialBranchesSnapshot, initialStashSize, exit, err := determineShipConfig(args, repo, dryRun, verbose)
+	config, initialBranchesSnapshot, initialStashSize, exit, err := determineShipConfig(args, repo, stack, dryRun, verbose)
 	if err != nil || exit {
 		return err
 	}
-	if config.branchToShip.LocalName == config.initialBranch {
+	if config.isShippingInitialBranch {
 		repoStatus, err := repo.Runner.Backend.RepoStatus()
 		if err != nil {
 			return err
@@ -126,25 +133,33 @@ func executeShip(args []string, message gitdomain.CommitMessage, dryRun, verbose
 
 type shipConfig struct {
 	*configdomain.FullConfig
-	allBranches              gitdomain.BranchInfos
+	allBranches             gitdomain.BranchInfos
+	branchesToShip          []shipBranchConfig // the branches to ship, oldest ancestor first
+	connector               hostingdomain.Connector
+	dialogTestInputs        components.TestInputs
+	dryRun                  bool
+	hasOpenChanges          bool
+	initialBranch           gitdomain.LocalBranchName
+	isShippingInitialBranch bool
+	previousBranch          gitdomain.LocalBranchName
+	remotes                 gitdomain.Remotes
+	rootDir                 gitdomain.RepoRootDir
+	targetBranch            gitdomain.BranchInfo
+	worktrees               gitdomain.Worktrees // the other worktrees that have branches to ship checked out
+}
+
+// shipBranchConfig contains the information to ship a single branch.
+type shipBranchConfig struct {
 	branchToShip             gitdomain.BranchInfo
 	canShipViaAPI            bool
 	childBranches            gitdomain.LocalBranchNames
-	connector                hostingdomain.Connector
-	dialogTestInputs         components.TestInputs
-	dryRun                   bool
-	hasOpenChanges           bool
-	initialBranch            gitdomain.LocalBranchName
-	isShippingInitialBranch  bool
-	previousBranch           gitdomain.LocalBranchName
 	proposal                 *hostingdomain.Proposal
 	proposalMessage          string
 	proposalsOfChildBranches []hostingdomain.Proposal
-	remotes                  gitdomain.Remotes
-	targetBranch             gitdomain.BranchInfo
+	worktree                 gitdomain.RepoRootDir // the other worktree that has the branch to ship checked out
 }
 
-func determineShipConfig(args []string, repo *execute.OpenRepoResult, dryRun, verbose bool) (*shipConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
+func determineShipConfig(args []string, repo *execute.OpenRepoResult, stack, dryRun, verbose bool) (*shipConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
 	dialogTestInputs := components.LoadTestInputs(os.Environ())
 	repoStatus, err := repo.Runner.Backend.RepoStatus()
 	if err != nil {
@@ -171,14 +186,8 @@ func determineShipConfig(args []string, repo *execute.OpenRepoResult, dryRun, ve
 	}
 	branchNameToShip := gitdomain.NewLocalBranchName(slice.FirstElementOr(args, branchesSnapshot.Active.String()))
 	branchToShip := branchesSnapshot.Branches.FindByLocalName(branchNameToShip)
-	if branchToShip != nil && branchToShip.SyncStatus == gitdomain.SyncStatusOtherWorktree {
-		return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.ShipBranchOtherWorktree, branchNameToShip)
-	}
-	isShippingInitialBranch := branchNameToShip == branchesSnapshot.Active
-	if !isShippingInitialBranch {
-		if branchToShip == nil {
-			return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.BranchDoesntExist, branchNameToShip)
-		}
+	if branchToShip == nil {
+		return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.BranchDoesntExist, branchNameToShip)
 	}
 	if err = validateShippableBranchType(repo.Runner.Config.FullConfig.BranchType(branchNameToShip)); err != nil {
 		return nil, branchesSnapshot, stashSize, false, err
@@ -193,18 +202,21 @@ func determineShipConfig(args []string, repo *execute.OpenRepoResult, dryRun, ve
 	if err != nil {
 		return nil, branchesSnapshot, stashSize, false, err
 	}
-	err = ensureParentBranchIsMainOrPerennialBranch(branchNameToShip, &repo.Runner.Config.FullConfig, repo.Runner.Config.FullConfig.Lineage)
-	if err != nil {
-		return nil, branchesSnapshot, stashSize, false, err
+	branchNamesToShip := gitdomain.LocalBranchNames{branchNameToShip}
+	if stack {
+		ancestors := repo.Runner.Config.FullConfig.Lineage.Ancestors(branchNameToShip)
+		branchNamesToShip = append(ancestors[1:], branchNameToShip)
+	} else {
+		err = ensureParentBranchIsMainOrPerennialBranch(branchNameToShip, &repo.Runner.Config.FullConfig, repo.Runner.Config.FullConfig.Lineage)
+		if err != nil {
+			return nil, branchesSnapshot, stashSize, false, err
+		}
 	}
-	targetBranchName := repo.Runner.Config.FullConfig.Lineage.Parent(branchNameToShip)
+	targetBranchName := repo.Runner.Config.FullConfig.Lineage.Parent(branchNamesToShip[0])
 	targetBranch := branchesSnapshot.Branches.FindByLocalName(targetBranchName)
 	if targetBranch == nil {
 		return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.BranchDoesntExist, targetBranchName)
 	}
-	var proposal *hostingdomain.Proposal
-	childBranches := repo.Runner.Config.FullConfig.Lineage.Children(branchNameToShip)
-	proposalsOfChildBranches := []hostingdomain.Proposal{}
 	originURL := repo.Runner.Config.OriginURL()
 	connector, err := hosting.NewConnector(hosting.NewConnectorArgs{
 		FullConfig:      &repo.Runner.Config.FullConfig,
@@ -215,50 +227,87 @@ func determineShipConfig(args []string, repo *execute.OpenRepoResult, dryRun, ve
 	if err != nil {
 		return nil, branchesSnapshot, stashSize, false, err
 	}
-	canShipViaAPI := false
-	proposalMessage := ""
-	if !repo.IsOffline && connector != nil {
-		if branchToShip.HasTrackingBranch() {
-			proposal, err = connector.FindProposal(branchNameToShip, targetBranchName)
-			if err != nil {
-				return nil, branchesSnapshot, stashSize, false, err
-			}
-			if proposal != nil {
-				canShipViaAPI = true
-				proposalMessage = connector.DefaultProposalMessage(*proposal)
-			}
+	branchesToShip := make([]shipBranchConfig, len(branchNamesToShip))
+	worktrees := gitdomain.Worktrees{}
+	for b, branchName := range branchNamesToShip {
+		branchInfo := branchesSnapshot.Branches.FindByLocalName(branchName)
+		if branchInfo == nil {
+			return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.BranchDoesntExist, branchName)
+		}
+		if err = validateShippableBranchType(repo.Runner.Config.FullConfig.BranchType(branchName)); err != nil {
+			return nil, branchesSnapshot, stashSize, false, err
 		}
-		for _, childBranch := range childBranches {
-			childProposal, err := connector.FindProposal(childBranch, branchNameToShip)
-			if err != nil {
-				return nil, branchesSnapshot, stashSize, false, fmt.Errorf(messages.ProposalNotFoundForBranch, branchNameToShip, err)
-			}
-			if childProposal != nil {
-				proposalsOfChildBranches = append(proposalsOfChildBranches, *childProposal)
-			}
+		branchConfig, err := determineShipBranchConfig(*branchInfo, repo, connector)
+		if err != nil {
+			return nil, branchesSnapshot, stashSize, false, err
+		}
+		branchConfig.worktree, err = determineBranchWorktree(branchName, branchesSnapshot.Worktrees, repo)
+		if err != nil {
+			return nil, branchesSnapshot, stashSize, false, err
 		}
+		if !branchConfig.worktree.IsEmpty() {
+			worktrees = append(worktrees, gitdomain.Worktree{Branch: branchName, OpenChanges: false, Path: branchConfig.worktree})
+		}
+		branchesToShip[b] = branchConfig
 	}
 	return &shipConfig{
-		FullConfig:               &repo.Runner.Config.FullConfig,
-		allBranches:              branchesSnapshot.Branches,
-		branchToShip:             *branchToShip,
-		canShipViaAPI:            canShipViaAPI,
-		childBranches:            childBranches,
-		connector:                connector,
-		dialogTestInputs:         dialogTestInputs,
-		dryRun:                   dryRun,
-		hasOpenChanges:           repoStatus.OpenChanges,
-		initialBranch:            branchesSnapshot.Active,
-		isShippingInitialBranch:  isShippingInitialBranch,
-		previousBranch:           previousBranch,
-		proposal:                 proposal,
-		proposalMessage:          proposalMessage,
-		proposalsOfChildBranches: proposalsOfChildBranches,
-		remotes:                  remotes,
-		targetBranch:             *targetBranch,
+		FullConfig:              &repo.Runner.Config.FullConfig,
+		allBranches:             branchesSnapshot.Branches,
+		branchesToShip:          branchesToShip,
+		connector:               connector,
+		dialogTestInputs:        dialogTestInputs,
+		dryRun:                  dryRun,
+		hasOpenChanges:          repoStatus.OpenChanges,
+		initialBranch:           branchesSnapshot.Active,
+		isShippingInitialBranch: slices.Contains(branchNamesToShip, branchesSnapshot.Active),
+		previousBranch:          previousBranch,
+		remotes:                 remotes,
+		rootDir:                 repo.RootDir,
+		targetBranch:            *targetBranch,
+		worktrees:               worktrees,
 	}, branchesSnapshot, stashSize, false, nil
 }
 
+// determineShipBranchConfig provides the information to ship the given branch into its current parent branch.
+func determineShipBranchConfig(branchToShip gitdomain.BranchInfo, repo *execute.OpenRepoResult, connector hostingdomain.Connector) (shipBranchConfig, error) {
+	branchNameToShip := branchToShip.LocalName
+	parentBranchName := repo.Runner.Config.FullConfig.Lineage.Parent(branchNameToShip)
+	childBranches := repo.Runner.Config.FullConfig.Lineage.Children(branchNameToShip)
+	result := shipBranchConfig{
+		branchToShip:             branchToShip,
+		canShipViaAPI:            false,
+		childBranches:            childBranches,
+		proposal:                 nil,
+		proposalMessage:          "",
+		proposalsOfChildBranches: []hostingdomain.Proposal{},
+		worktree:                 gitdomain.EmptyRepoRootDir(),
+	}
+	if repo.IsOffline || connector == nil {
+		return result, nil
+	}
+	if branchToShip.HasTrackingBranch() {
+		proposal, err := connector.FindProposal(branchNameToShip, parentBranchName)
+		if err != nil {
+			return result, err
+		}
+		if proposal != nil {
+			result.canShipViaAPI = true
+			result.proposal = proposal
+			result.proposalMessage = connector.DefaultProposalMessage(*proposal)
+		}
+	}
+	for _, childBranch := range childBranches {
+		childProposal, err := connector.FindProposal(childBranch, branchNameToShip)
+		if err != nil {
+			return result, fmt.Errorf(messages.ProposalNotFoundForBranch, branchNameToShip, err)
+		}
+		if childProposal != nil {
+			result.proposalsOfChildBranches = append(result.proposalsOfChildBranches, *childProposal)
+		}
+	}
+	return result, nil
+}
+
 func ensureParentBranchIsMainOrPerennialBranch(branch gitdomain.LocalBranchName, config *configdomain.FullConfig, lineage configdomain.Lineage) error {
 	parentBranch := lineage.Parent(branch)
 	if !config.IsMainOrPerennialBranch(parentBranch) {
@@ -281,37 +330,60 @@ func shipProgram(config *shipConfig, commitMessage gitdomain.CommitMessage) prog
 			Remotes:       config.remotes,
 			Program:       &prog,
 			PushBranch:    true,
+			RootDir:       gitdomain.EmptyRepoRootDir(),
+			Worktrees:     gitdomain.Worktrees{},
 		})
+	}
+	for _, branchConfig := range config.branchesToShip {
+		shipBranchProgram(&prog, config, branchConfig, commitMessage)
+	}
+	if !config.isShippingInitialBranch {
+		prog.Add(&opcodes.Checkout{Branch: config.initialBranch})
+	}
+	cmdhelpers.Wrap(&prog, cmdhelpers.WrapOptions{
+		DryRun:                   config.dryRun,
+		RunInGitRoot:             true,
+		StashOpenChanges:         !config.isShippingInitialBranch && config.hasOpenChanges,
+		PreviousBranchCandidates: gitdomain.LocalBranchNames{config.previousBranch},
+	})
+	return prog
+}
+
+// shipBranchProgram adds the opcodes to ship the given branch into the target branch to the given program.
+func shipBranchProgram(prog *program.Program, config *shipConfig, branchConfig shipBranchConfig, commitMessage gitdomain.CommitMessage) {
+	if config.SyncBeforeShip {
 		// sync the branch to ship (local sync only)
-		sync.BranchProgram(config.branchToShip, sync.BranchProgramArgs{
+		sync.BranchProgram(branchConfig.branchToShip, sync.BranchProgramArgs{
 			Config:        config.FullConfig,
 			BranchInfos:   config.allBranches,
 			InitialBranch: config.initialBranch,
 			Remotes:       config.remotes,
-			Program:       &prog,
+			Program:       prog,
 			PushBranch:    false,
+			RootDir:       config.rootDir,
+			Worktrees:     config.worktrees,
 		})
 	}
-	prog.Add(&opcodes.EnsureHasShippableChanges{Branch: config.branchToShip.LocalName, Parent: config.MainBranch})
+	prog.Add(&opcodes.EnsureHasShippableChanges{Branch: branchConfig.branchToShip.LocalName, Parent: config.MainBranch})
 	prog.Add(&opcodes.Checkout{Branch: config.targetBranch.LocalName})
-	if config.canShipViaAPI {
+	if branchConfig.canShipViaAPI {
 		// update the proposals of child branches
-		for _, childProposal := range config.proposalsOfChildBranches {
+		for _, childProposal := range branchConfig.proposalsOfChildBranches {
 			prog.Add(&opcodes.UpdateProposalTarget{
 				ProposalNumber: childProposal.Number,
 				NewTarget:      config.targetBranch.LocalName,
 			})
 		}
-		prog.Add(&opcodes.PushCurrentBranch{CurrentBranch: config.branchToShip.LocalName})
+		prog.Add(&opcodes.PushCurrentBranch{CurrentBranch: branchConfig.branchToShip.LocalName})
 		prog.Add(&opcodes.ConnectorMergeProposal{
-			Branch:          config.branchToShip.LocalName,
-			ProposalNumber:  config.proposal.Number,
+			Branch:          branchConfig.branchToShip.LocalName,
+			ProposalNumber:  branchConfig.proposal.Number,
 			CommitMessage:   commitMessage,
-			ProposalMessage: config.proposalMessage,
+			ProposalMessage: branchConfig.proposalMessage,
 		})
 		prog.Add(&opcodes.PullCurrentBranch{})
 	} else {
-		prog.Add(&opcodes.SquashMerge{Branch: config.branchToShip.LocalName, CommitMessage: commitMessage, Parent: config.targetBranch.LocalName})
+		prog.Add(&opcodes.SquashMerge{Branch: branchConfig.branchToShip.LocalName, CommitMessage: commitMessage, Parent: config.targetBranch.LocalName})
 	}
 	if config.remotes.HasOrigin() && config.IsOnline() {
 		prog.Add(&opcodes.PushCurrentBranch{CurrentBranch: config.targetBranch.LocalName})
@@ -320,28 +392,27 @@ func shipProgram(config *shipConfig, commitMessage gitdomain.CommitMessage) prog
 	// - we know we have a tracking branch (otherwise there would be no PR to ship via API)
 	// - we have updated the PRs of all child branches (because we have API access)
 	// - we know we are online
-	if config.canShipViaAPI || (config.branchToShip.HasTrackingBranch() && len(config.childBranches) == 0 && config.IsOnline()) {
+	if branchConfig.canShipViaAPI || (branchConfig.branchToShip.HasTrackingBranch() && len(branchConfig.childBranches) == 0 && config.IsOnline()) {
 		if config.ShipDeleteTrackingBranch {
-			prog.Add(&opcodes.DeleteTrackingBranch{Branch: config.branchToShip.RemoteName})
+			prog.Add(&opcodes.DeleteTrackingBranch{Branch: branchConfig.branchToShip.RemoteName})
 		}
 	}
-	prog.Add(&opcodes.DeleteLocalBranch{Branch: config.branchToShip.LocalName})
+	if !branchConfig.worktree.IsEmpty() {
+		prog.Add(&opcodes.RemoveWorktree{Path: branchConfig.worktree})
+	}
+	prog.Add(&opcodes.DeleteLocalBranch{Branch: branchConfig.branchToShip.LocalName})
 	if !config.dryRun {
-		prog.Add(&opcodes.DeleteParentBranch{Branch: config.branchToShip.LocalName})
+		prog.Add(&opcodes.DeleteParentBranch{Branch: branchConfig.branchToShip.LocalName})
+		prog.Add(&opcodes.RemoveBranchSyncStrategy{Branch: branchConfig.branchToShip.LocalName})
 	}
-	for _, child := range config.childBranches {
+	for _, child := range branchConfig.childBranches {
 		prog.Add(&opcodes.ChangeParent{Branch: child, Parent: config.targetBranch.LocalName})
 	}
-	if !config.isShippingInitialBranch {
-		prog.Add(&opcodes.Checkout{Branch: config.initialBranch})
+	if branchConfig.canShipViaAPI {
+		for _, child := range branchConfig.childBranches {
+			prog.Add(&opcodes.UpdateProposalStacks{Branch: child})
+		}
 	}
-	cmdhelpers.Wrap(&prog, cmdhelpers.WrapOptions{
-		DryRun:                   config.dryRun,
-		RunInGitRoot:             true,
-		StashOpenChanges:         !config.isShippingInitialBranch && config.hasOpenChanges,
-		PreviousBranchCandidates: gitdomain.LocalBranchNames{config.previousBranch},
-	})
-	return prog
 }
 
 func validateShippableBranchType(branchType configdomain.BranchType) error {
@@ -356,6 +427,8 @@ func validateShippableBranchType(branchType configdomain.BranchType) error {
 		return errors.New(messages.ObservedBranchCannotShip)
 	case configdomain.BranchTypePerennialBranch:
 		return errors.New(messages.PerennialBranchCannotShip)
+	case configdomain.BranchTypePrototypeBranch:
+		return errors.New(messages.PrototypeBranchCannotShip)
 	}
 	panic(fmt.Sprintf("unhandled branch type: %v", branchType))
 }
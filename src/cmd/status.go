@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cli/format"
+	"github.com/git-town/git-town/v14/src/cli/jsonoutput"
 	"github.com/git-town/git-town/v14/src/cli/print"
 	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
 	"github.com/git-town/git-town/v14/src/execute"
@@ -18,6 +20,7 @@ import (
 const statusDesc = "Displays or resets the current suspended Git Town command"
 
 func statusCommand() *cobra.Command {
+	addFormatFlag, readFormatFlag := flags.Format()
 	addVerboseFlag, readVerboseFlag := flags.Verbose()
 	cmd := cobra.Command{
 		Use:     "status",
@@ -26,15 +29,20 @@ func statusCommand() *cobra.Command {
 		Short:   statusDesc,
 		Long:    cmdhelpers.Long(statusDesc),
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return executeStatus(readVerboseFlag(cmd))
+			outputFormat, err := readFormatFlag(cmd)
+			if err != nil {
+				return err
+			}
+			return executeStatus(outputFormat, readVerboseFlag(cmd))
 		},
 	}
+	addFormatFlag(&cmd)
 	addVerboseFlag(&cmd)
 	cmd.AddCommand(resetRunstateCommand())
 	return &cmd
 }
 
-func executeStatus(verbose bool) error {
+func executeStatus(outputFormat format.OutputFormat, verbose bool) error {
 	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
 		DryRun:           false,
 		OmitBranchNames:  false,
@@ -50,6 +58,9 @@ func executeStatus(verbose bool) error {
 	if err != nil {
 		return err
 	}
+	if outputFormat.IsJSON() {
+		return jsonoutput.Print(jsonoutput.NewStatus(config.state))
+	}
 	displayStatus(*config)
 	print.Footer(verbose, repo.Runner.CommandsCounter.Count(), print.NoFinalMessages)
 	return nil
@@ -13,6 +13,10 @@ import (
 
 const statusResetDesc = "Resets the current suspended Git Town command"
 
+const statusResetHelp = `
+Removes the suspended Git Town command
+as well as the history of Git Town commands that "git town undo" and "git town redo" use.`
+
 // TODO: extract this and the "status" command into a "status" subfolder,
 //
 //	similar to the "config" subfolder.
@@ -22,7 +26,7 @@ func resetRunstateCommand() *cobra.Command {
 		Use:   "reset",
 		Args:  cobra.NoArgs,
 		Short: statusResetDesc,
-		Long:  cmdhelpers.Long(statusResetDesc),
+		Long:  cmdhelpers.Long(statusResetDesc, statusResetHelp),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return executeStatusReset(readVerboseFlag(cmd))
 		},
@@ -43,7 +47,7 @@ func executeStatusReset(verbose bool) error {
 	if err != nil {
 		return err
 	}
-	err = statefile.Delete(repo.RootDir)
+	err = statefile.DeleteAll(repo.RootDir)
 	if err != nil {
 		return err
 	}
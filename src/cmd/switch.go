@@ -8,6 +8,8 @@ import (
 	"github.com/git-town/git-town/v14/src/cli/dialog"
 	"github.com/git-town/git-town/v14/src/cli/dialog/components"
 	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cli/format"
+	"github.com/git-town/git-town/v14/src/cli/jsonoutput"
 	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
 	"github.com/git-town/git-town/v14/src/execute"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
@@ -17,6 +19,7 @@ import (
 const switchDesc = "Displays the local branches visually and allows switching between them"
 
 func switchCmd() *cobra.Command {
+	addFormatFlag, readFormatFlag := flags.Format()
 	addVerboseFlag, readVerboseFlag := flags.Verbose()
 	cmd := cobra.Command{
 		Use:     "switch",
@@ -25,14 +28,19 @@ func switchCmd() *cobra.Command {
 		Short:   switchDesc,
 		Long:    cmdhelpers.Long(switchDesc),
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return executeSwitch(readVerboseFlag(cmd))
+			outputFormat, err := readFormatFlag(cmd)
+			if err != nil {
+				return err
+			}
+			return executeSwitch(outputFormat, readVerboseFlag(cmd))
 		},
 	}
+	addFormatFlag(&cmd)
 	addVerboseFlag(&cmd)
 	return &cmd
 }
 
-func executeSwitch(verbose bool) error {
+func executeSwitch(outputFormat format.OutputFormat, verbose bool) error {
 	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
 		DryRun:           false,
 		OmitBranchNames:  false,
@@ -48,6 +56,9 @@ func executeSwitch(verbose bool) error {
 	if err != nil || exit {
 		return err
 	}
+	if outputFormat.IsJSON() {
+		return jsonoutput.Print(jsonoutput.NewSwitch(&repo.Runner.Config.FullConfig, config.branchNames, config.initialBranch))
+	}
 	branchToCheckout, abort, err := dialog.SwitchBranch(config.branchNames, config.initialBranch, repo.Runner.Config.FullConfig.Lineage)
 	if err != nil || abort {
 		return err
@@ -1,19 +1,29 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/git-town/git-town/v14/src/cli/dialog/components"
 	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cli/print"
 	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
 	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/config/gitconfig"
+	"github.com/git-town/git-town/v14/src/config/lineageshare"
 	"github.com/git-town/git-town/v14/src/execute"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/hosting"
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+	"github.com/git-town/git-town/v14/src/messages"
 	"github.com/git-town/git-town/v14/src/sync"
+	"github.com/git-town/git-town/v14/src/sync/synccheck"
 	"github.com/git-town/git-town/v14/src/undo/undoconfig"
+	"github.com/git-town/git-town/v14/src/validate"
 	fullInterpreter "github.com/git-town/git-town/v14/src/vm/interpreter/full"
+	"github.com/git-town/git-town/v14/src/vm/opcodes"
 	"github.com/git-town/git-town/v14/src/vm/program"
 	"github.com/git-town/git-town/v14/src/vm/runstate"
 	"github.com/spf13/cobra"
@@ -34,12 +44,18 @@ When run on the main branch or a perennial branch:
 - pulls and pushes updates for the current branch
 - pushes tags
 
+With the "--parallel" flag, Git Town syncs feature branches that use the "merge" sync strategy without checking them out. It computes the merges in memory and syncs independent stacks of branches concurrently. Branches that have merge conflicts, and their descendants, get synced the regular way afterwards. This requires Git 2.38 or higher, with older Git versions Git Town syncs the branches one at a time.
+
+The "--check" flag reports for each branch whether syncing it would cause merge conflicts and which files would conflict, as well as which branches would need a force-push. It doesn't fetch updates from origin, it simulates the merges and rebases with the remote-tracking branches as they are, in memory, without changing any branch or the workspace. Run "git fetch" beforehand to check against the latest state of origin. It doesn't ask for missing parent branches, branches without a known parent get checked only against their tracking branch. It cannot be combined with "--dry-run" or "--parallel". Rebases get simulated as a whole rather than commit by commit, so their results are approximate. This requires Git 2.38 or higher.
+
 If the repository contains an "upstream" remote, syncs the main branch with its upstream counterpart. You can disable this by running "git config %s false".`
 
 func syncCmd() *cobra.Command {
 	addVerboseFlag, readVerboseFlag := flags.Verbose()
 	addDryRunFlag, readDryRunFlag := flags.DryRun()
 	addAllFlag, readAllFlag := flags.Bool("all", "a", "Sync all local branches", flags.FlagTypeNonPersistent)
+	addCheckFlag, readCheckFlag := flags.Bool("check", "", "Report whether syncing would cause conflicts, without fetching or changing any branch", flags.FlagTypeNonPersistent)
+	addParallelFlag, readParallelFlag := flags.Bool("parallel", "", "Sync feature branches without checking them out, independent stacks in parallel", flags.FlagTypeNonPersistent)
 	cmd := cobra.Command{
 		Use:     "sync",
 		GroupID: "basic",
@@ -47,16 +63,21 @@ func syncCmd() *cobra.Command {
 		Short:   syncDesc,
 		Long:    cmdhelpers.Long(syncDesc, fmt.Sprintf(syncHelp, gitconfig.KeySyncUpstream)),
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return executeSync(readAllFlag(cmd), readDryRunFlag(cmd), readVerboseFlag(cmd))
+			return executeSync(readAllFlag(cmd), readCheckFlag(cmd), readDryRunFlag(cmd), readParallelFlag(cmd), readVerboseFlag(cmd))
 		},
 	}
 	addAllFlag(&cmd)
+	addCheckFlag(&cmd)
+	addParallelFlag(&cmd)
 	addVerboseFlag(&cmd)
 	addDryRunFlag(&cmd)
 	return &cmd
 }
 
-func executeSync(all, dryRun, verbose bool) error {
+func executeSync(all, check, dryRun, parallel, verbose bool) error {
+	if check && (dryRun || parallel) {
+		return errors.New(messages.SyncCheckFlagConflict)
+	}
 	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
 		DryRun:           dryRun,
 		OmitBranchNames:  false,
@@ -68,10 +89,31 @@ func executeSync(all, dryRun, verbose bool) error {
 	if err != nil {
 		return err
 	}
-	config, initialBranchesSnapshot, initialStashSize, exit, err := determineSyncConfig(all, repo, verbose)
+	if check {
+		canCheck, err := canMergeInMemory(repo)
+		if err != nil {
+			return err
+		}
+		if !canCheck {
+			return errors.New(messages.SyncCheckGitVersion)
+		}
+	}
+	if parallel {
+		parallel, err = canMergeInMemory(repo)
+		if err != nil {
+			return err
+		}
+		if !parallel {
+			fmt.Println(messages.SyncParallelGitVersion)
+		}
+	}
+	config, initialBranchesSnapshot, initialStashSize, exit, err := determineSyncConfig(all, check, repo, verbose)
 	if err != nil || exit {
 		return err
 	}
+	if check {
+		return checkSync(config, repo)
+	}
 	runProgram := program.Program{}
 	sync.BranchesProgram(sync.BranchesProgramArgs{
 		BranchProgramArgs: sync.BranchProgramArgs{
@@ -81,15 +123,26 @@ func executeSync(all, dryRun, verbose bool) error {
 			Remotes:       config.remotes,
 			Program:       &runProgram,
 			PushBranch:    true,
+			RootDir:       repo.RootDir,
+			Worktrees:     config.worktrees,
 		},
 		BranchesToSync: config.branchesToSync,
 		DryRun:         dryRun,
 		HasOpenChanges: config.hasOpenChanges,
 		InitialBranch:  config.initialBranch,
+		Parallel:       parallel,
 		PreviousBranch: config.previousBranch,
 		ShouldPushTags: config.shouldPushTags,
 	})
 	runProgram.RemoveDuplicateCheckout()
+	if config.connector != nil {
+		for _, branch := range config.stacksToUpdate() {
+			runProgram.Add(&opcodes.UpdateProposalStacks{Branch: branch})
+		}
+	}
+	if config.shareLineage {
+		runProgram.Add(&opcodes.PushSharedLineage{Base: config.sharedLineageBase})
+	}
 	runState := runstate.RunState{
 		BeginBranchesSnapshot: initialBranchesSnapshot,
 		BeginConfigSnapshot:   repo.ConfigSnapshot,
@@ -102,7 +155,7 @@ func executeSync(all, dryRun, verbose bool) error {
 		RunProgram:            runProgram,
 	}
 	return fullInterpreter.Execute(fullInterpreter.ExecuteArgs{
-		Connector:               nil,
+		Connector:               config.connector,
 		DialogTestInputs:        &config.dialogTestInputs,
 		FullConfig:              config.FullConfig,
 		HasOpenChanges:          config.hasOpenChanges,
@@ -116,19 +169,120 @@ func executeSync(all, dryRun, verbose bool) error {
 	})
 }
 
+// canMergeInMemory indicates whether the installed Git version can merge branches without checking them out.
+func canMergeInMemory(repo *execute.OpenRepoResult) (bool, error) {
+	major, minor, err := repo.Runner.Backend.Version()
+	if err != nil {
+		return false, err
+	}
+	return validate.CanMergeInMemory(major, minor), nil
+}
+
+// checkSync prints whether syncing the branches to sync would cause conflicts.
+func checkSync(config *syncConfig, repo *execute.OpenRepoResult) error {
+	results, err := synccheck.Check(synccheck.CheckArgs{
+		Backend:        &repo.Runner.Backend,
+		BranchInfos:    config.allBranches,
+		BranchesToSync: config.branchesToSync,
+		Config:         config.FullConfig,
+		InitialBranch:  config.initialBranch,
+		Remotes:        config.remotes,
+		RootDir:        repo.RootDir,
+		Worktrees:      config.worktrees,
+	})
+	if err != nil {
+		return err
+	}
+	conflicting := gitdomain.LocalBranchNames{}
+	approximate := false
+	for _, result := range results {
+		var line string
+		switch result.Outcome {
+		case synccheck.OutcomeBlocked:
+			line = fmt.Sprintf(messages.SyncCheckBlocked, result.Branch, result.Parent)
+		case synccheck.OutcomeConflict:
+			line = fmt.Sprintf(messages.SyncCheckConflict, result.Branch, strings.Join(result.Conflicts, ", "))
+			conflicting = append(conflicting, result.Branch)
+		case synccheck.OutcomeDeleted:
+			line = fmt.Sprintf(messages.SyncCheckDeleted, result.Branch)
+		case synccheck.OutcomeUpToDate:
+			line = fmt.Sprintf(messages.SyncCheckUpToDate, result.Branch)
+		case synccheck.OutcomeUpdatable:
+			if result.ForcePush {
+				line = fmt.Sprintf(messages.SyncCheckForcePush, result.Branch)
+			} else {
+				line = fmt.Sprintf(messages.SyncCheckUpdatable, result.Branch)
+			}
+		case synccheck.OutcomeSkipped:
+			continue
+		}
+		if result.Approximate {
+			line += messages.SyncCheckApproximate
+			approximate = true
+		}
+		fmt.Println(line)
+	}
+	if approximate {
+		fmt.Println(messages.SyncCheckApproximateNote)
+	}
+	if len(conflicting) > 0 {
+		return fmt.Errorf(messages.SyncCheckConflicts, conflicting.Join(", "))
+	}
+	return nil
+}
+
+// adoptSharedLineage fetches the lineage shared through the origin remote
+// and takes over its entries for local branches that don't have a parent yet.
+// Provides the SHA of the shared lineage known before the fetch
+// and whether the shared lineage was available.
+// Sync continues with the local lineage if the shared lineage cannot be loaded.
+func adoptSharedLineage(repo *execute.OpenRepoResult, localBranches gitdomain.LocalBranchNames) (gitdomain.SHA, bool, error) {
+	base := repo.Runner.Backend.SharedLineageSHA()
+	err := repo.Runner.Frontend.FetchSharedLineage()
+	if err != nil {
+		fmt.Printf(messages.SharedLineageUnavailable, err)
+		return base, false, nil
+	}
+	sharedLineage, err := lineageshare.Read(&repo.Runner.Backend, repo.Runner.Backend.SharedLineageSHA())
+	if err != nil {
+		fmt.Printf(messages.SharedLineageUnavailable, err)
+		return base, false, nil
+	}
+	adopted := lineageshare.Adopt(lineageshare.AdoptArgs{
+		Config:        &repo.Runner.Config.FullConfig,
+		LocalBranches: localBranches,
+		Shared:        sharedLineage,
+	})
+	for _, branch := range adopted.BranchNames() {
+		fmt.Printf(messages.SharedLineageAdopted, adopted[branch], branch)
+		if repo.Runner.Config.DryRun {
+			repo.Runner.Config.FullConfig.Lineage[branch] = adopted[branch]
+			continue
+		}
+		if err = repo.Runner.Config.SetParent(branch, adopted[branch]); err != nil {
+			return base, false, err
+		}
+	}
+	return base, true, nil
+}
+
 type syncConfig struct {
 	*configdomain.FullConfig
-	allBranches      gitdomain.BranchInfos
-	branchesToSync   gitdomain.BranchInfos
-	dialogTestInputs components.TestInputs
-	hasOpenChanges   bool
-	initialBranch    gitdomain.LocalBranchName
-	previousBranch   gitdomain.LocalBranchName
-	remotes          gitdomain.Remotes
-	shouldPushTags   bool
+	allBranches       gitdomain.BranchInfos
+	branchesToSync    gitdomain.BranchInfos
+	connector         hostingdomain.Connector
+	dialogTestInputs  components.TestInputs
+	hasOpenChanges    bool
+	initialBranch     gitdomain.LocalBranchName
+	previousBranch    gitdomain.LocalBranchName
+	remotes           gitdomain.Remotes
+	shareLineage      bool
+	sharedLineageBase gitdomain.SHA
+	shouldPushTags    bool
+	worktrees         gitdomain.Worktrees
 }
 
-func determineSyncConfig(allFlag bool, repo *execute.OpenRepoResult, verbose bool) (*syncConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
+func determineSyncConfig(allFlag, check bool, repo *execute.OpenRepoResult, verbose bool) (*syncConfig, gitdomain.BranchesSnapshot, gitdomain.StashSize, bool, error) {
 	dialogTestInputs := components.LoadTestInputs(os.Environ())
 	repoStatus, err := repo.Runner.Backend.RepoStatus()
 	if err != nil {
@@ -136,9 +290,9 @@ func determineSyncConfig(allFlag bool, repo *execute.OpenRepoResult, verbose boo
 	}
 	branchesSnapshot, stashSize, exit, err := execute.LoadRepoSnapshot(execute.LoadRepoSnapshotArgs{
 		DialogTestInputs:      dialogTestInputs,
-		Fetch:                 true,
+		Fetch:                 !check,
 		FullConfig:            &repo.Runner.Config.FullConfig,
-		HandleUnfinishedState: true,
+		HandleUnfinishedState: !check,
 		Repo:                  repo,
 		RepoStatus:            repoStatus,
 		ValidateIsConfigured:  true,
@@ -153,46 +307,115 @@ func determineSyncConfig(allFlag bool, repo *execute.OpenRepoResult, verbose boo
 	if err != nil {
 		return nil, branchesSnapshot, stashSize, false, err
 	}
+	sharedLineageBase := gitdomain.EmptySHA()
+	shareLineage := false
+	if shouldShareLineage(&repo.Runner.Config.FullConfig, remotes) && !check {
+		sharedLineageBase, shareLineage, err = adoptSharedLineage(repo, branchesSnapshot.Branches.LocalBranches().Names())
+		if err != nil {
+			return nil, branchesSnapshot, stashSize, false, err
+		}
+	}
 	var branchNamesToSync gitdomain.LocalBranchNames
 	var shouldPushTags bool
 	if allFlag {
 		localBranches := branchesSnapshot.Branches.LocalBranches()
-		err = execute.EnsureKnownBranchesAncestry(execute.EnsureKnownBranchesAncestryArgs{
-			Config:           &repo.Runner.Config.FullConfig,
-			DialogTestInputs: &dialogTestInputs,
-			LocalBranches:    localBranches,
-			Runner:           repo.Runner,
-		})
-		if err != nil {
-			return nil, branchesSnapshot, stashSize, false, err
+		// checking doesn't ask for missing parent branches because it doesn't change anything
+		if !check {
+			err = execute.EnsureKnownBranchesAncestry(execute.EnsureKnownBranchesAncestryArgs{
+				Config:           &repo.Runner.Config.FullConfig,
+				DialogTestInputs: &dialogTestInputs,
+				LocalBranches:    localBranches,
+				Runner:           repo.Runner,
+			})
+			if err != nil {
+				return nil, branchesSnapshot, stashSize, false, err
+			}
 		}
 		branchNamesToSync = localBranches.Names()
 		shouldPushTags = true
 	} else {
-		err = execute.EnsureKnownBranchAncestry(branchesSnapshot.Active, execute.EnsureKnownBranchAncestryArgs{
-			Config:           &repo.Runner.Config.FullConfig,
-			AllBranches:      branchesSnapshot.Branches,
-			DefaultBranch:    repo.Runner.Config.FullConfig.MainBranch,
-			DialogTestInputs: &dialogTestInputs,
-			Runner:           repo.Runner,
-		})
-		if err != nil {
-			return nil, branchesSnapshot, stashSize, false, err
+		if !check {
+			err = execute.EnsureKnownBranchAncestry(branchesSnapshot.Active, execute.EnsureKnownBranchAncestryArgs{
+				Config:           &repo.Runner.Config.FullConfig,
+				AllBranches:      branchesSnapshot.Branches,
+				DefaultBranch:    repo.Runner.Config.FullConfig.MainBranch,
+				DialogTestInputs: &dialogTestInputs,
+				Runner:           repo.Runner,
+			})
+			if err != nil {
+				return nil, branchesSnapshot, stashSize, false, err
+			}
 		}
 		branchNamesToSync = gitdomain.LocalBranchNames{branchesSnapshot.Active}
 		shouldPushTags = repo.Runner.Config.FullConfig.IsMainOrPerennialBranch(branchesSnapshot.Active)
 	}
 	allBranchNamesToSync := repo.Runner.Config.FullConfig.Lineage.BranchesAndAncestors(branchNamesToSync)
 	branchesToSync, err := branchesSnapshot.Branches.Select(allBranchNamesToSync)
+	if err != nil {
+		return nil, branchesSnapshot, stashSize, false, err
+	}
+	worktrees, err := determineSyncWorktrees(repo, branchesToSync)
+	if err != nil {
+		return nil, branchesSnapshot, stashSize, false, err
+	}
+	// sync updates the stack overview in proposals only for users who have given Git Town API access
+	var connector hostingdomain.Connector
+	if repo.Runner.Config.FullConfig.IsOnline() && remotes.HasOrigin() && hosting.HasAnyAPIToken(&repo.Runner.Config.FullConfig) {
+		connector, err = hosting.NewAPIConnector(hosting.NewConnectorArgs{
+			FullConfig:      &repo.Runner.Config.FullConfig,
+			HostingPlatform: repo.Runner.Config.FullConfig.HostingPlatform,
+			Log:             print.Logger{},
+			OriginURL:       repo.Runner.Config.OriginURL(),
+		})
+		if err != nil {
+			return nil, branchesSnapshot, stashSize, false, err
+		}
+	}
 	return &syncConfig{
-		FullConfig:       &repo.Runner.Config.FullConfig,
-		allBranches:      branchesSnapshot.Branches,
-		branchesToSync:   branchesToSync,
-		dialogTestInputs: dialogTestInputs,
-		hasOpenChanges:   repoStatus.OpenChanges,
-		initialBranch:    branchesSnapshot.Active,
-		previousBranch:   previousBranch,
-		remotes:          remotes,
-		shouldPushTags:   shouldPushTags,
-	}, branchesSnapshot, stashSize, false, err
+		FullConfig:        &repo.Runner.Config.FullConfig,
+		allBranches:       branchesSnapshot.Branches,
+		branchesToSync:    branchesToSync,
+		connector:         connector,
+		dialogTestInputs:  dialogTestInputs,
+		hasOpenChanges:    repoStatus.OpenChanges,
+		initialBranch:     branchesSnapshot.Active,
+		previousBranch:    previousBranch,
+		remotes:           remotes,
+		shareLineage:      shareLineage,
+		sharedLineageBase: sharedLineageBase,
+		shouldPushTags:    shouldPushTags,
+		worktrees:         worktrees,
+	}, branchesSnapshot, stashSize, false, nil
+}
+
+// determineSyncWorktrees provides the other worktrees of this repo
+// if some of the given branches are checked out in them.
+func determineSyncWorktrees(repo *execute.OpenRepoResult, branchesToSync gitdomain.BranchInfos) (gitdomain.Worktrees, error) {
+	for _, branch := range branchesToSync {
+		if branch.SyncStatus == gitdomain.SyncStatusOtherWorktree {
+			worktrees, err := repo.Runner.Backend.Worktrees()
+			return worktrees.Other(repo.RootDir), err
+		}
+	}
+	return gitdomain.Worktrees{}, nil
+}
+
+// shouldShareLineage indicates whether sync should exchange the lineage with the origin remote.
+func shouldShareLineage(config *configdomain.FullConfig, remotes gitdomain.Remotes) bool {
+	return config.ShareLineage.Bool() && config.IsOnline() && remotes.HasOrigin()
+}
+
+// stacksToUpdate provides the branches whose stacks should get their proposal stack overview updated.
+func (self *syncConfig) stacksToUpdate() gitdomain.LocalBranchNames {
+	if !self.IsMainOrPerennialBranch(self.initialBranch) {
+		return gitdomain.LocalBranchNames{self.initialBranch}
+	}
+	result := gitdomain.LocalBranchNames{}
+	for _, branch := range self.branchesToSync {
+		parent := self.Lineage.Parent(branch.LocalName)
+		if !parent.IsEmpty() && self.IsMainOrPerennialBranch(parent) {
+			result = append(result, branch.LocalName)
+		}
+	}
+	return result
 }
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v14/src/cli/flags"
+	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
+	"github.com/git-town/git-town/v14/src/config/commandconfig"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/execute"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+	"github.com/git-town/git-town/v14/src/undo/undoconfig"
+	configInterpreter "github.com/git-town/git-town/v14/src/vm/interpreter/config"
+	"github.com/spf13/cobra"
+)
+
+const syncStrategyDesc = "Sets the sync strategy of individual feature branches"
+
+const syncStrategyHelp = `
+Configures the given local feature branches to sync
+using the given strategy instead of the sync-feature-strategy.
+If no branch is provided, configures the current branch.
+
+The strategy can be "merge", "rebase", or "compress".
+The strategy "default" removes the branch-specific strategy,
+so that the branches use the sync-feature-strategy again.
+
+The branch-specific sync strategy is stored in the local Git metadata.
+`
+
+// syncStrategyDefault is the argument that removes the branch-specific sync strategy.
+const syncStrategyDefault = "default"
+
+func syncStrategyCmd() *cobra.Command {
+	addVerboseFlag, readVerboseFlag := flags.Verbose()
+	cmd := cobra.Command{
+		Use:     "sync-strategy <merge|rebase|compress|default> [branches]",
+		Args:    cobra.MinimumNArgs(1),
+		GroupID: "types",
+		Short:   syncStrategyDesc,
+		Long:    cmdhelpers.Long(syncStrategyDesc, syncStrategyHelp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeSyncStrategy(args, readVerboseFlag(cmd))
+		},
+	}
+	addVerboseFlag(&cmd)
+	return &cmd
+}
+
+func executeSyncStrategy(args []string, verbose bool) error {
+	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
+		DryRun:           false,
+		OmitBranchNames:  true,
+		PrintCommands:    true,
+		ValidateGitRepo:  true,
+		ValidateIsOnline: false,
+		Verbose:          verbose,
+	})
+	if err != nil {
+		return err
+	}
+	config, err := determineSyncStrategyConfig(args, repo)
+	if err != nil {
+		return err
+	}
+	err = validateSyncStrategyConfig(config)
+	if err != nil {
+		return err
+	}
+	for _, branch := range config.branches.Keys() {
+		if config.strategy == nil {
+			repo.Runner.Config.RemoveBranchSyncStrategy(branch)
+			fmt.Printf(messages.SyncStrategyIsDefault, branch)
+			continue
+		}
+		if err = repo.Runner.Config.SetBranchSyncStrategy(branch, *config.strategy); err != nil {
+			return err
+		}
+		fmt.Printf(messages.SyncStrategyIsNow, branch, config.strategy)
+	}
+	return configInterpreter.Finished(configInterpreter.FinishedArgs{
+		BeginConfigSnapshot: repo.ConfigSnapshot,
+		Command:             "sync-strategy",
+		EndConfigSnapshot:   undoconfig.EmptyConfigSnapshot(),
+		RootDir:             repo.RootDir,
+		Runner:              repo.Runner,
+		Verbose:             verbose,
+	})
+}
+
+type syncStrategyConfig struct {
+	allBranches gitdomain.BranchInfos
+	branches    commandconfig.BranchesAndTypes
+	strategy    *configdomain.SyncFeatureStrategy // nil means remove the branch-specific sync strategy
+}
+
+func determineSyncStrategyConfig(args []string, repo *execute.OpenRepoResult) (syncStrategyConfig, error) {
+	var strategy *configdomain.SyncFeatureStrategy
+	if args[0] != syncStrategyDefault {
+		var err error
+		strategy, err = configdomain.NewSyncFeatureStrategyRef(args[0])
+		if err != nil {
+			return syncStrategyConfig{}, err
+		}
+	}
+	branchesSnapshot, err := repo.Runner.Backend.BranchesSnapshot()
+	if err != nil {
+		return syncStrategyConfig{}, err
+	}
+	branches := commandconfig.BranchesAndTypes{}
+	if len(args) == 1 {
+		branches.Add(branchesSnapshot.Active, &repo.Runner.Config.FullConfig)
+	} else {
+		branches.AddMany(gitdomain.NewLocalBranchNames(args[1:]...), &repo.Runner.Config.FullConfig)
+	}
+	return syncStrategyConfig{
+		allBranches: branchesSnapshot.Branches,
+		branches:    branches,
+		strategy:    strategy,
+	}, nil
+}
+
+func validateSyncStrategyConfig(config syncStrategyConfig) error {
+	for branchName, branchType := range config.branches {
+		if !config.allBranches.HasLocalBranch(branchName) {
+			return fmt.Errorf(messages.BranchDoesntExist, branchName)
+		}
+		switch branchType {
+		case configdomain.BranchTypeFeatureBranch, configdomain.BranchTypeParkedBranch, configdomain.BranchTypePrototypeBranch:
+		case configdomain.BranchTypeMainBranch, configdomain.BranchTypePerennialBranch, configdomain.BranchTypeContributionBranch, configdomain.BranchTypeObservedBranch:
+			return fmt.Errorf(messages.SyncStrategyBranchType, branchName, branchType.Name())
+		}
+	}
+	return nil
+}
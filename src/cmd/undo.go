@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/git-town/git-town/v14/src/cli/dialog/components"
 	"github.com/git-town/git-town/v14/src/cli/flags"
@@ -15,29 +16,40 @@ import (
 	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
 	"github.com/git-town/git-town/v14/src/messages"
 	"github.com/git-town/git-town/v14/src/undo"
+	"github.com/git-town/git-town/v14/src/vm/runstate"
 	"github.com/git-town/git-town/v14/src/vm/statefile"
 	"github.com/spf13/cobra"
 )
 
 const undoDesc = "Undoes the most recent Git Town command"
 
+const undoHelp = `
+Git Town remembers the most recent commands you ran in this repository.
+Running "git town undo" repeatedly undoes them one after the other,
+starting with the most recent command.
+
+The "--list" option displays the commands that can be undone,
+starting with the one that "git town undo" would undo next.`
+
 func undoCmd() *cobra.Command {
+	addListFlag, readListFlag := flags.Bool("list", "l", "List the Git Town commands that can be undone", flags.FlagTypeNonPersistent)
 	addVerboseFlag, readVerboseFlag := flags.Verbose()
 	cmd := cobra.Command{
 		Use:     "undo",
 		GroupID: "errors",
 		Args:    cobra.NoArgs,
 		Short:   undoDesc,
-		Long:    cmdhelpers.Long(undoDesc),
+		Long:    cmdhelpers.Long(undoDesc, undoHelp),
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return executeUndo(readVerboseFlag(cmd))
+			return executeUndo(readListFlag(cmd), readVerboseFlag(cmd))
 		},
 	}
+	addListFlag(&cmd)
 	addVerboseFlag(&cmd)
 	return &cmd
 }
 
-func executeUndo(verbose bool) error {
+func executeUndo(list, verbose bool) error {
 	repo, err := execute.OpenRepo(execute.OpenRepoArgs{
 		DryRun:           false,
 		OmitBranchNames:  false,
@@ -49,6 +61,9 @@ func executeUndo(verbose bool) error {
 	if err != nil {
 		return err
 	}
+	if list {
+		return displayUndoHistory(repo, verbose)
+	}
 	var config *undoConfig
 	var initialStashSize gitdomain.StashSize
 	config, initialStashSize, repo.Runner.Config.FullConfig.Lineage, err = determineUndoConfig(repo, verbose)
@@ -64,6 +79,7 @@ func executeUndo(verbose bool) error {
 		return nil
 	}
 	return undo.Execute(undo.ExecuteArgs{
+		Connector:        config.connector,
 		FullConfig:       config.FullConfig,
 		HasOpenChanges:   config.hasOpenChanges,
 		InitialStashSize: initialStashSize,
@@ -124,3 +140,47 @@ func determineUndoConfig(repo *execute.OpenRepoResult, verbose bool) (*undoConfi
 		previousBranch:          previousBranch,
 	}, initialStashSize, repo.Runner.Config.FullConfig.Lineage, nil
 }
+
+// displays the Git Town commands that can be undone, starting with the one that gets undone next
+func displayUndoHistory(repo *execute.OpenRepoResult, verbose bool) error {
+	undoable, err := loadUndoableRunStates(repo.RootDir)
+	if err != nil {
+		return err
+	}
+	if len(undoable) == 0 {
+		fmt.Println(messages.UndoNothingToDo)
+	}
+	for index, runState := range undoable {
+		when := messages.UndoHistoryUnfinished
+		if runState.IsFinished() {
+			when = runState.EndTime.Local().Format(time.DateTime)
+		}
+		branches := messages.UndoHistoryNoBranches
+		if affectedBranches := runState.AffectedBranches(); len(affectedBranches) > 0 {
+			branches = affectedBranches.Join(", ")
+		}
+		fmt.Printf(messages.UndoHistoryEntry, index+1, runState.Command, when, branches)
+	}
+	print.Footer(verbose, repo.Runner.CommandsCounter.Count(), print.NoFinalMessages)
+	return nil
+}
+
+// provides the runstates that "git town undo" can undo, starting with the one that it undoes next
+func loadUndoableRunStates(rootDir gitdomain.RepoRootDir) ([]runstate.RunState, error) {
+	result := []runstate.RunState{}
+	current, err := statefile.Load(rootDir)
+	if err != nil {
+		return result, fmt.Errorf(messages.RunstateLoadProblem, err)
+	}
+	if current != nil && !current.DryRun {
+		result = append(result, *current)
+	}
+	history, err := statefile.LoadHistory(rootDir)
+	if err != nil {
+		return result, fmt.Errorf(messages.RunstateLoadProblem, err)
+	}
+	for index := len(history) - 1; index >= 0; index-- {
+		result = append(result, history[index])
+	}
+	return result, nil
+}
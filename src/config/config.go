@@ -54,6 +54,12 @@ func (self *Config) AddToPerennialBranches(branches ...gitdomain.LocalBranchName
 	return self.SetPerennialBranches(append(self.FullConfig.PerennialBranches, branches...))
 }
 
+// AddToPrototypeBranches registers the given branch names as prototype branches.
+// The branches must exist.
+func (self *Config) AddToPrototypeBranches(branches ...gitdomain.LocalBranchName) error {
+	return self.SetPrototypeBranches(append(self.FullConfig.PrototypeBranches, branches...))
+}
+
 // OriginURL provides the URL for the "origin" remote.
 // Tests can stub this through the GIT_TOWN_REMOTE environment variable.
 // Caches its result so can be called repeatedly.
@@ -93,6 +99,16 @@ func (self *Config) RemoveFromContributionBranches(branch gitdomain.LocalBranchN
 	return self.SetContributionBranches(self.FullConfig.ContributionBranches)
 }
 
+// RemoveBranchSyncStrategy removes the sync strategy override of the given branch, if it has one.
+func (self *Config) RemoveBranchSyncStrategy(branch gitdomain.LocalBranchName) {
+	if _, has := self.FullConfig.BranchSyncStrategies[branch]; !has {
+		return
+	}
+	delete(self.FullConfig.BranchSyncStrategies, branch)
+	delete(self.LocalGitConfig.BranchSyncStrategies, branch)
+	_ = self.GitConfig.RemoveLocalConfigValue(gitconfig.NewSyncStrategyKey(branch))
+}
+
 // RemoveFromObservedBranches removes the given branch as a perennial branch.
 func (self *Config) RemoveFromObservedBranches(branch gitdomain.LocalBranchName) error {
 	self.FullConfig.ObservedBranches = slice.Remove(self.FullConfig.ObservedBranches, branch)
@@ -111,6 +127,12 @@ func (self *Config) RemoveFromPerennialBranches(branch gitdomain.LocalBranchName
 	return self.SetPerennialBranches(self.FullConfig.PerennialBranches)
 }
 
+// RemoveFromPrototypeBranches removes the given branch as a prototype branch.
+func (self *Config) RemoveFromPrototypeBranches(branch gitdomain.LocalBranchName) error {
+	self.FullConfig.PrototypeBranches = slice.Remove(self.FullConfig.PrototypeBranches, branch)
+	return self.SetPrototypeBranches(self.FullConfig.PrototypeBranches)
+}
+
 func (self *Config) RemoveMainBranch() {
 	_ = self.GitConfig.RemoveLocalConfigValue(gitconfig.KeyMainBranch)
 }
@@ -159,6 +181,17 @@ func (self *Config) RemoveSyncUpstream() {
 	_ = self.GitConfig.RemoveLocalConfigValue(gitconfig.KeySyncUpstream)
 }
 
+// SetBranchSyncStrategy configures the given branch to sync with the given strategy
+// instead of the globally configured sync-feature-strategy.
+func (self *Config) SetBranchSyncStrategy(branch gitdomain.LocalBranchName, strategy configdomain.SyncFeatureStrategy) error {
+	self.FullConfig.BranchSyncStrategies[branch] = strategy
+	if self.LocalGitConfig.BranchSyncStrategies == nil {
+		self.LocalGitConfig.BranchSyncStrategies = configdomain.BranchSyncStrategies{}
+	}
+	self.LocalGitConfig.BranchSyncStrategies[branch] = strategy
+	return self.GitConfig.SetLocalConfigValue(gitconfig.NewSyncStrategyKey(branch), strategy.String())
+}
+
 // SetObservedBranches marks the given branches as observed branches.
 func (self *Config) SetContributionBranches(branches gitdomain.LocalBranchNames) error {
 	self.FullConfig.ContributionBranches = branches
@@ -219,6 +252,13 @@ func (self *Config) SetPerennialBranches(branches gitdomain.LocalBranchNames) er
 	return self.GitConfig.SetLocalConfigValue(gitconfig.KeyPerennialBranches, branches.Join(" "))
 }
 
+// SetPrototypeBranches marks the given branches as prototype branches.
+func (self *Config) SetPrototypeBranches(branches gitdomain.LocalBranchNames) error {
+	self.FullConfig.PrototypeBranches = branches
+	self.LocalGitConfig.PrototypeBranches = &branches
+	return self.GitConfig.SetLocalConfigValue(gitconfig.KeyPrototypeBranches, branches.Join(" "))
+}
+
 // SetPushHookLocally updates the locally configured push-hook strategy.
 func (self *Config) SetPerennialRegexLocally(value configdomain.PerennialRegex) error {
 	self.LocalGitConfig.PerennialRegex = &value
@@ -264,6 +304,17 @@ func (self *Config) SetShipDeleteTrackingBranch(value configdomain.ShipDeleteTra
 	return self.GitConfig.SetLocalConfigValue(gitconfig.KeyShipDeleteTrackingBranch, strconv.FormatBool(value.Bool()))
 }
 
+// SetShareLineage updates the configured share-lineage setting.
+func (self *Config) SetShareLineage(value configdomain.ShareLineage, global bool) error {
+	self.FullConfig.ShareLineage = value
+	if global {
+		self.GlobalGitConfig.ShareLineage = &value
+		return self.GitConfig.SetGlobalConfigValue(gitconfig.KeyShareLineage, strconv.FormatBool(value.Bool()))
+	}
+	self.LocalGitConfig.ShareLineage = &value
+	return self.GitConfig.SetLocalConfigValue(gitconfig.KeyShareLineage, strconv.FormatBool(value.Bool()))
+}
+
 func (self *Config) SetSyncBeforeShip(value configdomain.SyncBeforeShip, global bool) error {
 	self.FullConfig.SyncBeforeShip = value
 	if global {
@@ -0,0 +1,22 @@
+package configdomain
+
+import "strings"
+
+// BitbucketToken is the credential to use with the Bitbucket Cloud API.
+// It is either an access token or an app password in the format "username:app-password".
+type BitbucketToken string
+
+// AppPassword indicates whether this token is an app password.
+// If so, it provides the username and the app password.
+func (self BitbucketToken) AppPassword() (username, password string, isAppPassword bool) {
+	return strings.Cut(self.String(), ":")
+}
+
+func (self BitbucketToken) String() string {
+	return string(self)
+}
+
+func NewBitbucketTokenRef(value string) *BitbucketToken {
+	token := BitbucketToken(value)
+	return &token
+}
@@ -0,0 +1,17 @@
+package configdomain
+
+import "github.com/git-town/git-town/v14/src/git/gitdomain"
+
+// BranchSyncStrategies contains the sync strategies that individual feature branches
+// use instead of the globally configured sync-feature-strategy.
+type BranchSyncStrategies map[gitdomain.LocalBranchName]SyncFeatureStrategy
+
+// Branches provides the names of all branches that have a sync strategy override, sorted alphabetically.
+func (self BranchSyncStrategies) Branches() gitdomain.LocalBranchNames {
+	result := make(gitdomain.LocalBranchNames, 0, len(self))
+	for branch := range self {
+		result = append(result, branch)
+	}
+	result.Sort()
+	return result
+}
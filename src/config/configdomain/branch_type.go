@@ -11,6 +11,7 @@ const (
 	BranchTypeParkedBranch
 	BranchTypeContributionBranch
 	BranchTypeObservedBranch
+	BranchTypePrototypeBranch
 )
 
 func NewBranchType(name string) BranchType {
@@ -27,16 +28,40 @@ func NewBranchType(name string) BranchType {
 		return BranchTypeParkedBranch
 	case "perennial":
 		return BranchTypePerennialBranch
+	case "prototype":
+		return BranchTypePrototypeBranch
 	}
 	panic("unhandled branch type: " + name)
 }
 
+// Name provides the machine-readable name of this branch type.
+// This is the counterpart to NewBranchType.
+func (self BranchType) Name() string {
+	switch self {
+	case BranchTypeMainBranch:
+		return "main"
+	case BranchTypePerennialBranch:
+		return "perennial"
+	case BranchTypeFeatureBranch:
+		return "feature"
+	case BranchTypeParkedBranch:
+		return "parked"
+	case BranchTypeContributionBranch:
+		return "contribution"
+	case BranchTypeObservedBranch:
+		return "observed"
+	case BranchTypePrototypeBranch:
+		return "prototype"
+	}
+	panic("unhandled branch type")
+}
+
 // ShouldPush indicates whether a branch with this type should push its local commit to origin.
 func (self BranchType) ShouldPush(currentBranch, initialBranch gitdomain.LocalBranchName) bool {
 	switch self {
 	case BranchTypeMainBranch, BranchTypeFeatureBranch, BranchTypePerennialBranch, BranchTypeContributionBranch:
 		return true
-	case BranchTypeObservedBranch:
+	case BranchTypeObservedBranch, BranchTypePrototypeBranch:
 		return false
 	case BranchTypeParkedBranch:
 		return currentBranch == initialBranch
@@ -58,6 +83,8 @@ func (self BranchType) String() string {
 		return "contribution branch"
 	case BranchTypeObservedBranch:
 		return "observed branch"
+	case BranchTypePrototypeBranch:
+		return "prototype branch"
 	}
 	panic("unhandled branch type")
 }
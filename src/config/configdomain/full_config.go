@@ -8,6 +8,9 @@ import (
 // FullConfig is the merged configuration to be used by Git Town commands.
 type FullConfig struct {
 	Aliases                  Aliases
+	AzureDevOpsToken         AzureDevOpsToken
+	BitbucketToken           BitbucketToken
+	BranchSyncStrategies     BranchSyncStrategies
 	ContributionBranches     gitdomain.LocalBranchNames
 	GitHubToken              GitHubToken
 	GitLabToken              GitLabToken
@@ -23,13 +26,16 @@ type FullConfig struct {
 	ParkedBranches           gitdomain.LocalBranchNames
 	PerennialBranches        gitdomain.LocalBranchNames
 	PerennialRegex           PerennialRegex
+	PrototypeBranches        gitdomain.LocalBranchNames
 	PushHook                 PushHook
 	PushNewBranches          PushNewBranches
+	ShareLineage             ShareLineage
 	ShipDeleteTrackingBranch ShipDeleteTrackingBranch
 	SyncBeforeShip           SyncBeforeShip
 	SyncFeatureStrategy      SyncFeatureStrategy
 	SyncPerennialStrategy    SyncPerennialStrategy
 	SyncUpstream             SyncUpstream
+	WorktreePath             WorktreePath
 }
 
 func (self *FullConfig) BranchType(branch gitdomain.LocalBranchName) BranchType {
@@ -44,6 +50,8 @@ func (self *FullConfig) BranchType(branch gitdomain.LocalBranchName) BranchType
 		return BranchTypeObservedBranch
 	case self.IsParkedBranch(branch):
 		return BranchTypeParkedBranch
+	case self.IsPrototypeBranch(branch):
+		return BranchTypePrototypeBranch
 	}
 	return BranchTypeFeatureBranch
 }
@@ -88,6 +96,10 @@ func (self *FullConfig) IsPerennialBranch(branch gitdomain.LocalBranchName) bool
 	return self.PerennialRegex.MatchesBranch(branch)
 }
 
+func (self *FullConfig) IsPrototypeBranch(branch gitdomain.LocalBranchName) bool {
+	return slice.Contains(self.PrototypeBranches, branch)
+}
+
 func (self *FullConfig) MainAndPerennials() gitdomain.LocalBranchNames {
 	return append(gitdomain.LocalBranchNames{self.MainBranch}, self.PerennialBranches...)
 }
@@ -97,11 +109,20 @@ func (self *FullConfig) Merge(other PartialConfig) {
 	for key, value := range other.Aliases {
 		self.Aliases[key] = value
 	}
+	for branch, strategy := range other.BranchSyncStrategies {
+		self.BranchSyncStrategies[branch] = strategy
+	}
 	if other.Lineage != nil {
 		for child, parent := range *other.Lineage {
 			self.Lineage[child] = parent
 		}
 	}
+	if other.AzureDevOpsToken != nil {
+		self.AzureDevOpsToken = *other.AzureDevOpsToken
+	}
+	if other.BitbucketToken != nil {
+		self.BitbucketToken = *other.BitbucketToken
+	}
 	if other.ContributionBranches != nil {
 		self.ContributionBranches = append(self.ContributionBranches, *other.ContributionBranches...)
 	}
@@ -147,9 +168,15 @@ func (self *FullConfig) Merge(other PartialConfig) {
 	if other.PerennialRegex != nil {
 		self.PerennialRegex = *other.PerennialRegex
 	}
+	if other.PrototypeBranches != nil {
+		self.PrototypeBranches = append(self.PrototypeBranches, *other.PrototypeBranches...)
+	}
 	if other.PushHook != nil {
 		self.PushHook = *other.PushHook
 	}
+	if other.ShareLineage != nil {
+		self.ShareLineage = *other.ShareLineage
+	}
 	if other.ShipDeleteTrackingBranch != nil {
 		self.ShipDeleteTrackingBranch = *other.ShipDeleteTrackingBranch
 	}
@@ -165,6 +192,9 @@ func (self *FullConfig) Merge(other PartialConfig) {
 	if other.SyncUpstream != nil {
 		self.SyncUpstream = *other.SyncUpstream
 	}
+	if other.WorktreePath != nil {
+		self.WorktreePath = *other.WorktreePath
+	}
 }
 
 func (self *FullConfig) NoPushHook() NoPushHook {
@@ -179,10 +209,23 @@ func (self *FullConfig) ShouldPushNewBranches() bool {
 	return self.PushNewBranches.Bool()
 }
 
+// SyncFeatureStrategyForBranch provides the strategy to sync the given feature branch with:
+// the strategy configured for this particular branch if there is one,
+// otherwise the globally configured sync-feature-strategy.
+func (self *FullConfig) SyncFeatureStrategyForBranch(branch gitdomain.LocalBranchName) SyncFeatureStrategy {
+	if strategy, has := self.BranchSyncStrategies[branch]; has {
+		return strategy
+	}
+	return self.SyncFeatureStrategy
+}
+
 // DefaultConfig provides the default configuration data to use when nothing is configured.
 func DefaultConfig() FullConfig {
 	return FullConfig{
 		Aliases:                  Aliases{},
+		AzureDevOpsToken:         "",
+		BitbucketToken:           "",
+		BranchSyncStrategies:     BranchSyncStrategies{},
 		ContributionBranches:     gitdomain.NewLocalBranchNames(),
 		GitHubToken:              "",
 		GitLabToken:              "",
@@ -198,12 +241,15 @@ func DefaultConfig() FullConfig {
 		ParkedBranches:           gitdomain.NewLocalBranchNames(),
 		PerennialBranches:        gitdomain.NewLocalBranchNames(),
 		PerennialRegex:           "",
+		PrototypeBranches:        gitdomain.NewLocalBranchNames(),
 		PushHook:                 true,
 		PushNewBranches:          false,
+		ShareLineage:             false,
 		ShipDeleteTrackingBranch: true,
 		SyncBeforeShip:           false,
 		SyncFeatureStrategy:      SyncFeatureStrategyMerge,
 		SyncPerennialStrategy:    SyncPerennialStrategyRebase,
 		SyncUpstream:             true,
+		WorktreePath:             "../{{repo}}-{{branch}}",
 	}
 }
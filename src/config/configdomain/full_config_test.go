@@ -37,6 +37,18 @@ func TestFullConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("SyncFeatureStrategyForBranch", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			BranchSyncStrategies: configdomain.BranchSyncStrategies{
+				gitdomain.NewLocalBranchName("rebased"): configdomain.SyncFeatureStrategyRebase,
+			},
+			SyncFeatureStrategy: configdomain.SyncFeatureStrategyMerge,
+		}
+		must.EqOp(t, configdomain.SyncFeatureStrategyRebase, config.SyncFeatureStrategyForBranch(gitdomain.NewLocalBranchName("rebased")))
+		must.EqOp(t, configdomain.SyncFeatureStrategyMerge, config.SyncFeatureStrategyForBranch(gitdomain.NewLocalBranchName("other")))
+	})
+
 	t.Run("IsMainBranch", func(t *testing.T) {
 		t.Parallel()
 		config := configdomain.FullConfig{ //nolint:exhaustruct
@@ -13,11 +13,12 @@ type HostingPlatform string
 func (self HostingPlatform) String() string { return string(self) }
 
 const (
-	HostingPlatformBitbucket = HostingPlatform("bitbucket")
-	HostingPlatformGitHub    = HostingPlatform("github")
-	HostingPlatformGitLab    = HostingPlatform("gitlab")
-	HostingPlatformGitea     = HostingPlatform("gitea")
-	HostingPlatformNone      = HostingPlatform("") // no hosting or auto-detect
+	HostingPlatformAzureDevOps = HostingPlatform("azure-devops")
+	HostingPlatformBitbucket   = HostingPlatform("bitbucket")
+	HostingPlatformGitHub      = HostingPlatform("github")
+	HostingPlatformGitLab      = HostingPlatform("gitlab")
+	HostingPlatformGitea       = HostingPlatform("gitea")
+	HostingPlatformNone        = HostingPlatform("") // no hosting or auto-detect
 )
 
 // NewHostingPlatform provides the HostingPlatform enum matching the given text.
@@ -45,5 +46,6 @@ func hostingPlatforms() []HostingPlatform {
 		HostingPlatformGitHub,
 		HostingPlatformGitLab,
 		HostingPlatformGitea,
+		HostingPlatformAzureDevOps,
 	}
 }
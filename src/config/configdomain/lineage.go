@@ -124,6 +124,23 @@ func (self Lineage) IsAncestor(ancestor, other gitdomain.LocalBranchName) bool {
 	}
 }
 
+// IsInCycle indicates whether the given branch is an ancestor of itself.
+func (self Lineage) IsInCycle(branch gitdomain.LocalBranchName) bool {
+	visited := map[gitdomain.LocalBranchName]bool{}
+	current := branch
+	for {
+		parent, hasParent := self[current]
+		if !hasParent || visited[parent] {
+			return false
+		}
+		if parent == branch {
+			return true
+		}
+		visited[parent] = true
+		current = parent
+	}
+}
+
 // OrderHierarchically sorts the given branches in place so that ancestor branches come before their descendants
 // and everything is sorted alphabetically.
 func (self Lineage) OrderHierarchically(branches gitdomain.LocalBranchNames) {
@@ -326,6 +326,35 @@ func TestLineage(t *testing.T) {
 		})
 	})
 
+	t.Run("IsInCycle", func(t *testing.T) {
+		t.Parallel()
+		t.Run("branch is its own grandparent", func(t *testing.T) {
+			t.Parallel()
+			lineage := configdomain.Lineage{
+				one: two,
+				two: one,
+			}
+			must.True(t, lineage.IsInCycle(one))
+		})
+		t.Run("branch is a descendant of a cycle", func(t *testing.T) {
+			t.Parallel()
+			lineage := configdomain.Lineage{
+				three: one,
+				one:   two,
+				two:   one,
+			}
+			must.False(t, lineage.IsInCycle(three))
+		})
+		t.Run("regular lineage", func(t *testing.T) {
+			t.Parallel()
+			lineage := configdomain.Lineage{
+				two: one,
+				one: main,
+			}
+			must.False(t, lineage.IsInCycle(two))
+		})
+	})
+
 	t.Run("OrderedHierarchically", func(t *testing.T) {
 		t.Run("multiple lineages", func(t *testing.T) {
 			t.Parallel()
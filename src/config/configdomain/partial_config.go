@@ -7,6 +7,9 @@ import (
 // PartialConfig contains configuration data as it is stored in the local or global Git configuration.
 type PartialConfig struct {
 	Aliases                  Aliases
+	AzureDevOpsToken         *AzureDevOpsToken
+	BitbucketToken           *BitbucketToken
+	BranchSyncStrategies     BranchSyncStrategies
 	ContributionBranches     *gitdomain.LocalBranchNames
 	GitHubToken              *GitHubToken
 	GitLabToken              *GitLabToken
@@ -22,17 +25,21 @@ type PartialConfig struct {
 	ParkedBranches           *gitdomain.LocalBranchNames
 	PerennialBranches        *gitdomain.LocalBranchNames
 	PerennialRegex           *PerennialRegex
+	PrototypeBranches        *gitdomain.LocalBranchNames
 	PushHook                 *PushHook
 	PushNewBranches          *PushNewBranches
+	ShareLineage             *ShareLineage
 	ShipDeleteTrackingBranch *ShipDeleteTrackingBranch
 	SyncBeforeShip           *SyncBeforeShip
 	SyncFeatureStrategy      *SyncFeatureStrategy
 	SyncPerennialStrategy    *SyncPerennialStrategy
 	SyncUpstream             *SyncUpstream
+	WorktreePath             *WorktreePath
 }
 
 func EmptyPartialConfig() PartialConfig {
 	return PartialConfig{ //nolint:exhaustruct
-		Aliases: Aliases{},
+		Aliases:              Aliases{},
+		BranchSyncStrategies: BranchSyncStrategies{},
 	}
 }
@@ -0,0 +1,43 @@
+package configdomain
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/git-town/git-town/v14/src/gohacks"
+	"github.com/git-town/git-town/v14/src/messages"
+)
+
+// ShareLineage contains the configuration setting whether to share the lineage through the origin remote.
+type ShareLineage bool
+
+func (self ShareLineage) Bool() bool {
+	return bool(self)
+}
+
+func (self ShareLineage) String() string {
+	return strconv.FormatBool(self.Bool())
+}
+
+func NewShareLineage(value bool) ShareLineage {
+	return ShareLineage(value)
+}
+
+func NewShareLineageRef(value bool) *ShareLineage {
+	result := NewShareLineage(value)
+	return &result
+}
+
+func ParseShareLineage(value, source string) (ShareLineage, error) {
+	parsed, err := gohacks.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf(messages.ValueInvalid, source, value)
+	}
+	result := ShareLineage(parsed)
+	return result, nil
+}
+
+func ParseShareLineageRef(value, source string) (*ShareLineage, error) {
+	result, err := ParseShareLineage(value, source)
+	return &result, err
+}
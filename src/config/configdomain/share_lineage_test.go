@@ -0,0 +1,57 @@
+package configdomain_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/shoenig/test/must"
+)
+
+func TestShareLineage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Bool", func(t *testing.T) {
+		t.Parallel()
+		give := configdomain.NewShareLineage(true)
+		have := give.Bool()
+		must.True(t, have)
+	})
+
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+		give := configdomain.NewShareLineage(true)
+		have := give.String()
+		want := "true"
+		must.EqOp(t, want, have)
+	})
+
+	t.Run("NewShareLineage", func(t *testing.T) {
+		t.Parallel()
+		have := configdomain.NewShareLineage(true)
+		want := configdomain.ShareLineage(true)
+		must.EqOp(t, want, have)
+	})
+
+	t.Run("NewShareLineageRef", func(t *testing.T) {
+		t.Parallel()
+		have := configdomain.NewShareLineageRef(true)
+		want := configdomain.ShareLineage(true)
+		must.EqOp(t, want, *have)
+	})
+
+	t.Run("ParseShareLineage", func(t *testing.T) {
+		t.Parallel()
+		t.Run("parsable value", func(t *testing.T) {
+			t.Parallel()
+			have, err := configdomain.ParseShareLineage("yes", "test")
+			must.NoError(t, err)
+			want := configdomain.NewShareLineage(true)
+			must.EqOp(t, want, have)
+		})
+		t.Run("invalid value", func(t *testing.T) {
+			t.Parallel()
+			_, err := configdomain.ParseShareLineage("zonk", "local config")
+			must.EqOp(t, `invalid value for local config: "zonk". Please provide either "yes" or "no"`, err.Error())
+		})
+	})
+}
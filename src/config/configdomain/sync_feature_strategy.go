@@ -17,8 +17,9 @@ func (self SyncFeatureStrategy) StringRef() *string {
 }
 
 const (
-	SyncFeatureStrategyMerge  = SyncFeatureStrategy("merge")
-	SyncFeatureStrategyRebase = SyncFeatureStrategy("rebase")
+	SyncFeatureStrategyCompress = SyncFeatureStrategy("compress")
+	SyncFeatureStrategyMerge    = SyncFeatureStrategy("merge")
+	SyncFeatureStrategyRebase   = SyncFeatureStrategy("rebase")
 )
 
 func NewSyncFeatureStrategy(text string) (SyncFeatureStrategy, error) {
@@ -27,6 +28,8 @@ func NewSyncFeatureStrategy(text string) (SyncFeatureStrategy, error) {
 		return SyncFeatureStrategyMerge, nil
 	case "rebase":
 		return SyncFeatureStrategyRebase, nil
+	case "compress":
+		return SyncFeatureStrategyCompress, nil
 	default:
 		return SyncFeatureStrategyMerge, fmt.Errorf(messages.ConfigSyncFeatureStrategyUnknown, text)
 	}
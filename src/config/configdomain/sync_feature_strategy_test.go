@@ -0,0 +1,39 @@
+package configdomain_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/shoenig/test/must"
+)
+
+func TestNewSyncFeatureStrategy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid content", func(t *testing.T) {
+		t.Parallel()
+		tests := map[string]configdomain.SyncFeatureStrategy{
+			"compress": configdomain.SyncFeatureStrategyCompress,
+			"merge":    configdomain.SyncFeatureStrategyMerge,
+			"rebase":   configdomain.SyncFeatureStrategyRebase,
+		}
+		for give, want := range tests {
+			have, err := configdomain.NewSyncFeatureStrategy(give)
+			must.NoError(t, err)
+			must.EqOp(t, want, have)
+		}
+	})
+
+	t.Run("defaults to merge", func(t *testing.T) {
+		t.Parallel()
+		have, err := configdomain.NewSyncFeatureStrategy("")
+		must.NoError(t, err)
+		must.EqOp(t, configdomain.SyncFeatureStrategyMerge, have)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Parallel()
+		_, err := configdomain.NewSyncFeatureStrategy("zonk")
+		must.EqError(t, err, `unknown sync-feature strategy: "zonk"`)
+	})
+}
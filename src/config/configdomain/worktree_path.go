@@ -0,0 +1,38 @@
+package configdomain
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+)
+
+// WorktreePath contains the template for the location of the worktrees that Git Town creates for new branches.
+// "{{repo}}" stands for the name of the repository folder and "{{branch}}" for the name of the new branch.
+// Relative paths are relative to the root directory of the repository.
+type WorktreePath string
+
+// Resolve provides the location of the worktree for the given branch in the repository at the given root directory.
+func (self WorktreePath) Resolve(rootDir gitdomain.RepoRootDir, branch gitdomain.LocalBranchName) gitdomain.RepoRootDir {
+	path := strings.ReplaceAll(self.String(), "{{repo}}", filepath.Base(rootDir.String()))
+	path = strings.ReplaceAll(path, "{{branch}}", strings.ReplaceAll(branch.String(), "/", "-"))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(rootDir.String(), path)
+	}
+	return gitdomain.NewRepoRootDir(filepath.Clean(path))
+}
+
+func (self WorktreePath) String() string {
+	return string(self)
+}
+
+func NewWorktreePathRef(value string) *WorktreePath {
+	result := WorktreePath(value)
+	return &result
+}
+
+// NewBranchWorktree describes whether and where to check out a new branch in a separate worktree.
+type NewBranchWorktree struct {
+	Enabled bool         // whether to check out the new branch in a separate worktree
+	Path    WorktreePath // the location of the worktree, uses the worktree-path setting if empty
+}
@@ -0,0 +1,35 @@
+package configdomain_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/shoenig/test/must"
+)
+
+func TestWorktreePath(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Resolve", func(t *testing.T) {
+		t.Parallel()
+		rootDir := gitdomain.NewRepoRootDir("/code/project")
+		tests := map[string]string{
+			"../{{repo}}-{{branch}}":       "/code/project-feature",
+			"../worktrees/{{branch}}":      "/code/worktrees/feature",
+			".worktrees/{{branch}}":        "/code/project/.worktrees/feature",
+			"/tmp/{{repo}}/{{branch}}":     "/tmp/project/feature",
+			"../{{repo}}-{{branch}}/../ok": "/code/ok",
+		}
+		for give, want := range tests {
+			have := configdomain.WorktreePath(give).Resolve(rootDir, gitdomain.NewLocalBranchName("feature"))
+			must.EqOp(t, gitdomain.NewRepoRootDir(want), have)
+		}
+	})
+
+	t.Run("Resolve with slashes in the branch name", func(t *testing.T) {
+		t.Parallel()
+		have := configdomain.WorktreePath("../{{branch}}").Resolve(gitdomain.NewRepoRootDir("/code/project"), gitdomain.NewLocalBranchName("kg/feature"))
+		must.EqOp(t, gitdomain.NewRepoRootDir("/code/kg-feature"), have)
+	})
+}
@@ -10,25 +10,28 @@ type Data struct {
 	SyncBeforeShip           *bool         `toml:"sync-before-ship"`
 	SyncStrategy             *SyncStrategy `toml:"sync-strategy"`
 	SyncUpstream             *bool         `toml:"sync-upstream"`
+	WorktreePath             *string       `toml:"worktree-path"`
 }
 
 type Branches struct {
 	Main           *string  `toml:"main"`
 	Perennials     []string `toml:"perennials"`
 	PerennialRegex *string  `toml:"perennial-regex"`
+	Prototypes     []string `toml:"prototypes"`
 }
 
 func (self Branches) IsEmpty() bool {
-	return self.Main == nil && len(self.Perennials) == 0
+	return self.Main == nil && len(self.Perennials) == 0 && len(self.Prototypes) == 0
 }
 
 type Hosting struct {
+	BitbucketToken *string `toml:"bitbucket-token"`
 	OriginHostname *string `toml:"origin-hostname"`
 	Platform       *string `toml:"platform"`
 }
 
 func (self Hosting) IsEmpty() bool {
-	return self.Platform == nil && self.OriginHostname == nil
+	return self.BitbucketToken == nil && self.Platform == nil && self.OriginHostname == nil
 }
 
 type SyncStrategy struct {
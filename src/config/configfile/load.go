@@ -50,8 +50,14 @@ func Validate(data Data) (configdomain.PartialConfig, error) {
 		if data.Branches.PerennialRegex != nil {
 			result.PerennialRegex = configdomain.NewPerennialRegexRef(*data.Branches.PerennialRegex)
 		}
+		if data.Branches.Prototypes != nil {
+			result.PrototypeBranches = gitdomain.NewLocalBranchNamesRef(data.Branches.Prototypes...)
+		}
 	}
 	if data.Hosting != nil {
+		if data.Hosting.BitbucketToken != nil {
+			result.BitbucketToken = configdomain.NewBitbucketTokenRef(*data.Hosting.BitbucketToken)
+		}
 		if data.Hosting.Platform != nil {
 			result.HostingPlatform, err = configdomain.NewHostingPlatformRef(*data.Hosting.Platform)
 		}
@@ -79,5 +85,8 @@ func Validate(data Data) (configdomain.PartialConfig, error) {
 	if data.SyncUpstream != nil {
 		result.SyncUpstream = configdomain.NewSyncUpstreamRef(*data.SyncUpstream)
 	}
+	if data.WorktreePath != nil {
+		result.WorktreePath = configdomain.NewWorktreePathRef(*data.WorktreePath)
+	}
 	return result, err
 }
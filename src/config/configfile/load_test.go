@@ -20,15 +20,18 @@ push-new-branches = true
 ship-delete-tracking-branch = false
 sync-before-ship = false
 sync-upstream = true
+worktree-path = "../{{repo}}-{{branch}}"
 
 [branches]
 main = "main"
 perennials = [ "public", "staging" ]
 perennial-regex = "release-.*"
+prototypes = [ "experiment" ]
 
 [hosting]
 platform = "github"
 origin-hostname = "github.com"
+bitbucket-token = "bitbucket-token"
 
 [sync-strategy]
 feature-branches = "merge"
@@ -36,6 +39,7 @@ perennial-branches = "rebase"
 `[1:]
 			have, err := configfile.Decode(give)
 			must.NoError(t, err)
+			bitbucketToken := "bitbucket-token"
 			github := "github"
 			githubCom := "github.com"
 			main := "main"
@@ -47,13 +51,16 @@ perennial-branches = "rebase"
 			shipDeleteTrackingBranch := false
 			syncBeforeShip := false
 			syncUpstream := true
+			worktreePath := "../{{repo}}-{{branch}}"
 			want := configfile.Data{
 				Branches: &configfile.Branches{
 					Main:           &main,
 					Perennials:     []string{"public", "staging"},
 					PerennialRegex: &releaseRegex,
+					Prototypes:     []string{"experiment"},
 				},
 				Hosting: &configfile.Hosting{
+					BitbucketToken: &bitbucketToken,
 					Platform:       &github,
 					OriginHostname: &githubCom,
 				},
@@ -66,6 +73,7 @@ perennial-branches = "rebase"
 				ShipDeleteTrackingBranch: &shipDeleteTrackingBranch,
 				SyncBeforeShip:           &syncBeforeShip,
 				SyncUpstream:             &syncUpstream,
+				WorktreePath:             &worktreePath,
 			}
 			must.Eq(t, want, *have)
 		})
@@ -92,6 +100,7 @@ main = "main"
 				ShipDeleteTrackingBranch: nil,
 				SyncBeforeShip:           nil,
 				SyncUpstream:             nil,
+				WorktreePath:             nil,
 			}
 			must.Eq(t, want, *have)
 		})
@@ -0,0 +1,212 @@
+// Package configrepair finds and fixes inconsistencies between the Git Town configuration and the branches in the repo.
+package configrepair
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/git-town/git-town/v14/src/config"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+)
+
+// Problem describes an inconsistency in the Git Town configuration together with the change that fixes it.
+type Problem struct {
+	Description string                     // describes the problem and how to fix it
+	Fix         func(*config.Config) error // updates the Git Town configuration so that the problem disappears
+}
+
+// Fix applies the fixes for the given problems to the given configuration.
+func Fix(problems []Problem, config *config.Config) error {
+	for _, problem := range problems {
+		if err := problem.Fix(config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Problems finds the inconsistencies between the given Git Town configuration and the given branches.
+// Later problems assume that the fixes for earlier problems have been applied.
+func Problems(config *configdomain.FullConfig, branches gitdomain.BranchInfos) []Problem {
+	finder := problemFinder{
+		branches: branches,
+		config:   config,
+		lineage:  maps.Clone(config.Lineage),
+		result:   []Problem{},
+	}
+	finder.missingTypedBranches()
+	finder.missingSyncStrategyBranches()
+	finder.missingLineageBranches()
+	finder.missingParents()
+	finder.cycles()
+	finder.parentsDeletedAtRemote()
+	return finder.result
+}
+
+// problemFinder finds problems in the configuration.
+// It applies the fixes for the problems it finds to its copy of the lineage
+// so that it sees the lineage as it will be after the preceding problems are fixed.
+type problemFinder struct {
+	branches gitdomain.BranchInfos
+	config   *configdomain.FullConfig
+	lineage  configdomain.Lineage
+	result   []Problem
+}
+
+func (self *problemFinder) add(fix func(*config.Config) error, format string, args ...any) {
+	self.result = append(self.result, Problem{
+		Description: fmt.Sprintf(format, args...),
+		Fix:         fix,
+	})
+}
+
+// changeParent registers a problem that gets fixed by changing the parent of the given branch.
+func (self *problemFinder) changeParent(branch, parent gitdomain.LocalBranchName, format string, args ...any) {
+	self.add(func(config *config.Config) error {
+		return config.SetParent(branch, parent)
+	}, format, args...)
+	self.lineage[branch] = parent
+}
+
+// cycles finds branches that are their own ancestors.
+func (self *problemFinder) cycles() {
+	for _, branch := range self.lineage.BranchNames() {
+		if self.lineage.IsInCycle(branch) {
+			self.changeParent(branch, self.config.MainBranch, messages.RepairCycle, branch, self.config.MainBranch)
+		}
+	}
+}
+
+// exists indicates whether the given branch exists locally or at the origin remote.
+func (self *problemFinder) exists(branch gitdomain.LocalBranchName) bool {
+	return self.branches.HasLocalBranch(branch) || self.branches.HasMatchingTrackingBranchFor(branch)
+}
+
+// missingLineageBranches finds lineage entries for branches that don't exist anymore.
+func (self *problemFinder) missingLineageBranches() {
+	for _, branch := range self.lineage.BranchNames() {
+		if self.exists(branch) {
+			continue
+		}
+		branch := branch
+		parent, hasParent := self.lineage[branch]
+		children := self.lineage.Children(branch)
+		self.add(func(config *config.Config) error {
+			for _, child := range children {
+				if !hasParent {
+					config.RemoveParent(child)
+					continue
+				}
+				if err := config.SetParent(child, parent); err != nil {
+					return err
+				}
+			}
+			config.RemoveParent(branch)
+			return nil
+		}, messages.RepairBranchMissing, branch)
+		for _, child := range children {
+			if hasParent {
+				self.lineage[child] = parent
+			} else {
+				delete(self.lineage, child)
+			}
+		}
+		delete(self.lineage, branch)
+	}
+}
+
+// missingParents finds branches whose parent doesn't exist and has no lineage entry itself.
+func (self *problemFinder) missingParents() {
+	for _, branch := range self.lineage.BranchNames() {
+		parent := self.lineage[branch]
+		if parent == self.config.MainBranch || self.exists(parent) {
+			continue
+		}
+		self.changeParent(branch, self.config.MainBranch, messages.RepairParentMissing, parent, branch, self.config.MainBranch)
+	}
+}
+
+// missingSyncStrategyBranches finds sync strategy overrides for branches that don't exist.
+func (self *problemFinder) missingSyncStrategyBranches() {
+	for _, branch := range self.config.BranchSyncStrategies.Branches() {
+		if !self.exists(branch) {
+			branch := branch
+			self.add(func(config *config.Config) error {
+				config.RemoveBranchSyncStrategy(branch)
+				return nil
+			}, messages.RepairSyncStrategyBranchMissing, branch)
+		}
+	}
+}
+
+// missingTypedBranches finds branches listed as perennial, contribution, observed, parked, or prototype branches that don't exist.
+func (self *problemFinder) missingTypedBranches() {
+	for _, branch := range self.config.ContributionBranches {
+		if !self.exists(branch) {
+			branch := branch
+			self.add(func(config *config.Config) error {
+				return config.RemoveFromContributionBranches(branch)
+			}, messages.RepairTypedBranchMissing, configdomain.BranchTypeContributionBranch, branch)
+		}
+	}
+	for _, branch := range self.config.ObservedBranches {
+		if !self.exists(branch) {
+			branch := branch
+			self.add(func(config *config.Config) error {
+				return config.RemoveFromObservedBranches(branch)
+			}, messages.RepairTypedBranchMissing, configdomain.BranchTypeObservedBranch, branch)
+		}
+	}
+	for _, branch := range self.config.ParkedBranches {
+		if !self.exists(branch) {
+			branch := branch
+			self.add(func(config *config.Config) error {
+				return config.RemoveFromParkedBranches(branch)
+			}, messages.RepairTypedBranchMissing, configdomain.BranchTypeParkedBranch, branch)
+		}
+	}
+	for _, branch := range self.config.PerennialBranches {
+		if !self.exists(branch) {
+			branch := branch
+			self.add(func(config *config.Config) error {
+				return config.RemoveFromPerennialBranches(branch)
+			}, messages.RepairTypedBranchMissing, configdomain.BranchTypePerennialBranch, branch)
+		}
+	}
+	for _, branch := range self.config.PrototypeBranches {
+		if !self.exists(branch) {
+			branch := branch
+			self.add(func(config *config.Config) error {
+				return config.RemoveFromPrototypeBranches(branch)
+			}, messages.RepairTypedBranchMissing, configdomain.BranchTypePrototypeBranch, branch)
+		}
+	}
+}
+
+// parentsDeletedAtRemote finds branches whose parent branch was deleted at the remote,
+// which usually happens when the parent branch was shipped via the code hosting platform.
+func (self *problemFinder) parentsDeletedAtRemote() {
+	for _, branch := range self.lineage.BranchNames() {
+		parent := self.lineage[branch]
+		if self.config.IsMainOrPerennialBranch(parent) || !self.isDeletedAtRemote(parent) {
+			continue
+		}
+		newParent := parent
+		for !self.config.IsMainOrPerennialBranch(newParent) && self.isDeletedAtRemote(newParent) {
+			grandParent, hasGrandParent := self.lineage[newParent]
+			if !hasGrandParent {
+				newParent = self.config.MainBranch
+				break
+			}
+			newParent = grandParent
+		}
+		self.changeParent(branch, newParent, messages.RepairParentDeletedAtRemote, parent, branch, newParent)
+	}
+}
+
+func (self *problemFinder) isDeletedAtRemote(branch gitdomain.LocalBranchName) bool {
+	branchInfo := self.branches.FindByLocalName(branch)
+	return branchInfo != nil && branchInfo.SyncStatus == gitdomain.SyncStatusDeletedAtRemote
+}
@@ -0,0 +1,184 @@
+package configrepair_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/config/configrepair"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/shoenig/test/must"
+)
+
+func TestProblems(t *testing.T) {
+	t.Parallel()
+
+	main := gitdomain.NewLocalBranchName("main")
+	branchA := gitdomain.NewLocalBranchName("branch-a")
+	branchB := gitdomain.NewLocalBranchName("branch-b")
+	branchC := gitdomain.NewLocalBranchName("branch-c")
+
+	// branchInfo provides a BranchInfo for the given branch that exists locally and at origin
+	branchInfo := func(name gitdomain.LocalBranchName, syncStatus gitdomain.SyncStatus) gitdomain.BranchInfo {
+		return gitdomain.BranchInfo{
+			LocalName:  name,
+			LocalSHA:   gitdomain.NewSHA("111111"),
+			RemoteName: name.TrackingBranch(),
+			RemoteSHA:  gitdomain.NewSHA("111111"),
+			SyncStatus: syncStatus,
+		}
+	}
+
+	descriptions := func(problems []configrepair.Problem) []string {
+		result := make([]string, len(problems))
+		for p, problem := range problems {
+			result[p] = problem.Description
+		}
+		return result
+	}
+
+	t.Run("no problems", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage: configdomain.Lineage{
+				branchA: main,
+				branchB: branchA,
+			},
+			MainBranch: main,
+		}
+		branches := gitdomain.BranchInfos{
+			branchInfo(main, gitdomain.SyncStatusUpToDate),
+			branchInfo(branchA, gitdomain.SyncStatusUpToDate),
+			branchInfo(branchB, gitdomain.SyncStatusUpToDate),
+		}
+		have := configrepair.Problems(&config, branches)
+		must.Len(t, 0, have)
+	})
+
+	t.Run("branch in the lineage doesn't exist", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage: configdomain.Lineage{
+				branchA: main,
+				branchB: branchA,
+			},
+			MainBranch: main,
+		}
+		branches := gitdomain.BranchInfos{
+			branchInfo(main, gitdomain.SyncStatusUpToDate),
+			branchInfo(branchB, gitdomain.SyncStatusUpToDate),
+		}
+		have := descriptions(configrepair.Problems(&config, branches))
+		want := []string{
+			`branch "branch-a" doesn't exist anymore, removing it from the lineage`,
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("parent doesn't exist and has no lineage entry", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage: configdomain.Lineage{
+				branchB: branchA,
+			},
+			MainBranch: main,
+		}
+		branches := gitdomain.BranchInfos{
+			branchInfo(main, gitdomain.SyncStatusUpToDate),
+			branchInfo(branchB, gitdomain.SyncStatusUpToDate),
+		}
+		have := descriptions(configrepair.Problems(&config, branches))
+		want := []string{
+			`the parent branch "branch-a" of "branch-b" doesn't exist, making "main" its new parent`,
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage: configdomain.Lineage{
+				branchA: branchB,
+				branchB: branchA,
+				branchC: branchB,
+			},
+			MainBranch: main,
+		}
+		branches := gitdomain.BranchInfos{
+			branchInfo(main, gitdomain.SyncStatusUpToDate),
+			branchInfo(branchA, gitdomain.SyncStatusUpToDate),
+			branchInfo(branchB, gitdomain.SyncStatusUpToDate),
+			branchInfo(branchC, gitdomain.SyncStatusUpToDate),
+		}
+		have := descriptions(configrepair.Problems(&config, branches))
+		want := []string{
+			`branch "branch-a" is its own ancestor, making "main" its new parent`,
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("parents deleted at the remote", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage: configdomain.Lineage{
+				branchA: main,
+				branchB: branchA,
+				branchC: branchB,
+			},
+			MainBranch: main,
+		}
+		branches := gitdomain.BranchInfos{
+			branchInfo(main, gitdomain.SyncStatusUpToDate),
+			branchInfo(branchA, gitdomain.SyncStatusDeletedAtRemote),
+			branchInfo(branchB, gitdomain.SyncStatusDeletedAtRemote),
+			branchInfo(branchC, gitdomain.SyncStatusUpToDate),
+		}
+		have := descriptions(configrepair.Problems(&config, branches))
+		want := []string{
+			`the parent branch "branch-a" of "branch-b" was deleted at the remote, making "main" its new parent`,
+			`the parent branch "branch-b" of "branch-c" was deleted at the remote, making "main" its new parent`,
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("typed branches that don't exist", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage:           configdomain.Lineage{},
+			MainBranch:        main,
+			ObservedBranches:  gitdomain.LocalBranchNames{branchB},
+			PerennialBranches: gitdomain.LocalBranchNames{branchA},
+		}
+		branches := gitdomain.BranchInfos{
+			branchInfo(main, gitdomain.SyncStatusUpToDate),
+		}
+		have := descriptions(configrepair.Problems(&config, branches))
+		want := []string{
+			`observed branch "branch-b" doesn't exist, removing it from the configuration`,
+			`perennial branch "branch-a" doesn't exist, removing it from the configuration`,
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("sync strategy overrides for branches that don't exist", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			BranchSyncStrategies: configdomain.BranchSyncStrategies{
+				branchA: configdomain.SyncFeatureStrategyRebase,
+				branchB: configdomain.SyncFeatureStrategyMerge,
+			},
+			Lineage: configdomain.Lineage{
+				branchA: main,
+			},
+			MainBranch: main,
+		}
+		branches := gitdomain.BranchInfos{
+			branchInfo(main, gitdomain.SyncStatusUpToDate),
+			branchInfo(branchA, gitdomain.SyncStatusUpToDate),
+		}
+		have := descriptions(configrepair.Problems(&config, branches))
+		want := []string{
+			`branch "branch-b" with a sync strategy override doesn't exist, removing the override`,
+		}
+		must.Eq(t, want, have)
+	})
+}
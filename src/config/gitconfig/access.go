@@ -32,15 +32,25 @@ func (self *Access) LoadLocal(updateOutdated bool) (SingleSnapshot, configdomain
 }
 
 func AddKeyToPartialConfig(key Key, value string, config *configdomain.PartialConfig) error {
-	if strings.HasPrefix(key.String(), "git-town-branch.") {
+	if child, isParentKey := ParseParentKey(key); isParentKey {
 		if config.Lineage == nil {
 			config.Lineage = &configdomain.Lineage{}
 		}
-		child := gitdomain.NewLocalBranchName(strings.TrimSuffix(strings.TrimPrefix(key.String(), "git-town-branch."), ".parent"))
 		parent := gitdomain.NewLocalBranchName(value)
 		(*config.Lineage)[child] = parent
 		return nil
 	}
+	if branch, isSyncStrategyKey := ParseSyncStrategyKey(key); isSyncStrategyKey {
+		strategy, err := configdomain.NewSyncFeatureStrategy(value)
+		if err != nil {
+			return err
+		}
+		if config.BranchSyncStrategies == nil {
+			config.BranchSyncStrategies = configdomain.BranchSyncStrategies{}
+		}
+		config.BranchSyncStrategies[branch] = strategy
+		return nil
+	}
 	var err error
 	switch key {
 	case KeyAliasAppend:
@@ -73,6 +83,10 @@ func AddKeyToPartialConfig(key Key, value string, config *configdomain.PartialCo
 		config.Aliases[configdomain.AliasableCommandShip] = value
 	case KeyAliasSync:
 		config.Aliases[configdomain.AliasableCommandSync] = value
+	case KeyAzureDevOpsToken:
+		config.AzureDevOpsToken = configdomain.NewAzureDevOpsTokenRef(value)
+	case KeyBitbucketToken:
+		config.BitbucketToken = configdomain.NewBitbucketTokenRef(value)
 	case KeyContributionBranches:
 		config.ContributionBranches = gitdomain.ParseLocalBranchNamesRef(value)
 	case KeyHostingOriginHostname:
@@ -101,10 +115,14 @@ func AddKeyToPartialConfig(key Key, value string, config *configdomain.PartialCo
 		config.PerennialBranches = gitdomain.ParseLocalBranchNamesRef(value)
 	case KeyPerennialRegex:
 		config.PerennialRegex = configdomain.NewPerennialRegexRef(value)
+	case KeyPrototypeBranches:
+		config.PrototypeBranches = gitdomain.ParseLocalBranchNamesRef(value)
 	case KeyPushHook:
 		config.PushHook, err = configdomain.NewPushHookRef(value, KeyPushHook.String())
 	case KeyPushNewBranches:
 		config.PushNewBranches, err = configdomain.ParsePushNewBranchesRef(value, KeyPushNewBranches.String())
+	case KeyShareLineage:
+		config.ShareLineage, err = configdomain.ParseShareLineageRef(value, KeyShareLineage.String())
 	case KeyShipDeleteTrackingBranch:
 		config.ShipDeleteTrackingBranch, err = configdomain.ParseShipDeleteTrackingBranchRef(value, KeyShipDeleteTrackingBranch.String())
 	case KeySyncBeforeShip:
@@ -115,6 +133,8 @@ func AddKeyToPartialConfig(key Key, value string, config *configdomain.PartialCo
 		config.SyncPerennialStrategy, err = configdomain.NewSyncPerennialStrategyRef(value)
 	case KeySyncUpstream:
 		config.SyncUpstream, err = configdomain.ParseSyncUpstreamRef(value, KeySyncUpstream.String())
+	case KeyWorktreePath:
+		config.WorktreePath = configdomain.NewWorktreePathRef(value)
 	case KeyDeprecatedCodeHostingDriver,
 		KeyDeprecatedCodeHostingOriginHostname,
 		KeyDeprecatedCodeHostingPlatform,
@@ -145,7 +165,7 @@ func (self *Access) RemoveLocalConfigValue(key Key) error {
 }
 
 // RemoveLocalGitConfiguration removes all Git Town configuration.
-func (self *Access) RemoveLocalGitConfiguration(lineage configdomain.Lineage) error {
+func (self *Access) RemoveLocalGitConfiguration(lineage configdomain.Lineage, branchSyncStrategies configdomain.BranchSyncStrategies) error {
 	err := self.Run("git", "config", "--remove-section", "git-town")
 	if err != nil {
 		var exitErr *exec.ExitError
@@ -159,8 +179,13 @@ func (self *Access) RemoveLocalGitConfiguration(lineage configdomain.Lineage) er
 		return fmt.Errorf(messages.ConfigRemoveError, err)
 	}
 	for child := range lineage {
-		key := fmt.Sprintf("git-town-branch.%s.parent", child)
-		err = self.Run("git", "config", "--unset", key)
+		err = self.RemoveLocalConfigValue(NewParentKey(child))
+		if err != nil {
+			return fmt.Errorf(messages.ConfigRemoveError, err)
+		}
+	}
+	for branch := range branchSyncStrategies {
+		err = self.RemoveLocalConfigValue(NewSyncStrategyKey(branch))
 		if err != nil {
 			return fmt.Errorf(messages.ConfigRemoveError, err)
 		}
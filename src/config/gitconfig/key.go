@@ -43,6 +43,8 @@ const (
 	KeyAliasSetParent                      = Key("alias.set-parent")
 	KeyAliasShip                           = Key("alias.ship")
 	KeyAliasSync                           = Key("alias.sync")
+	KeyAzureDevOpsToken                    = Key("git-town.azure-devops-token")
+	KeyBitbucketToken                      = Key("git-town.bitbucket-token")
 	KeyContributionBranches                = Key("git-town.contribution-branches")
 	KeyDeprecatedCodeHostingDriver         = Key("git-town.code-hosting-driver")
 	KeyDeprecatedCodeHostingOriginHostname = Key("git-town.code-hosting-origin-hostname")
@@ -65,14 +67,17 @@ const (
 	KeyParkedBranches                      = Key("git-town.parked-branches")
 	KeyPerennialBranches                   = Key("git-town.perennial-branches")
 	KeyPerennialRegex                      = Key("git-town.perennial-regex")
+	KeyPrototypeBranches                   = Key("git-town.prototype-branches")
 	KeyPushHook                            = Key("git-town.push-hook")
 	KeyPushNewBranches                     = Key("git-town.push-new-branches")
+	KeyShareLineage                        = Key("git-town.share-lineage")
 	KeyShipDeleteTrackingBranch            = Key("git-town.ship-delete-tracking-branch")
 	KeySyncBeforeShip                      = Key("git-town.sync-before-ship")
 	KeySyncFeatureStrategy                 = Key("git-town.sync-feature-strategy")
 	KeySyncPerennialStrategy               = Key("git-town.sync-perennial-strategy")
 	KeySyncStrategy                        = Key("git-town.sync-strategy")
 	KeySyncUpstream                        = Key("git-town.sync-upstream")
+	KeyWorktreePath                        = Key("git-town.worktree-path")
 	KeyGitUserEmail                        = Key("user.email")
 	KeyGitUserName                         = Key("user.name")
 )
@@ -80,6 +85,8 @@ const (
 var keys = []Key{ //nolint:gochecknoglobals
 	KeyHostingOriginHostname,
 	KeyHostingPlatform,
+	KeyAzureDevOpsToken,
+	KeyBitbucketToken,
 	KeyContributionBranches,
 	KeyDeprecatedCodeHostingDriver,
 	KeyDeprecatedCodeHostingOriginHostname,
@@ -102,14 +109,17 @@ var keys = []Key{ //nolint:gochecknoglobals
 	KeyParkedBranches,
 	KeyPerennialBranches,
 	KeyPerennialRegex,
+	KeyPrototypeBranches,
 	KeyPushHook,
 	KeyPushNewBranches,
+	KeyShareLineage,
 	KeyShipDeleteTrackingBranch,
 	KeySyncBeforeShip,
 	KeySyncFeatureStrategy,
 	KeySyncPerennialStrategy,
 	KeySyncStrategy,
 	KeySyncUpstream,
+	KeyWorktreePath,
 }
 
 func AliasableCommandForKey(key Key) *configdomain.AliasableCommand {
@@ -157,8 +167,20 @@ func KeyForAliasableCommand(aliasableCommand configdomain.AliasableCommand) Key
 	panic(fmt.Sprintf("don't know how to convert alias type %q into a config key", &aliasableCommand))
 }
 
+// BranchKeyPrefix is the prefix of all configuration keys that contain settings for individual branches.
+const BranchKeyPrefix = "git-town-branch."
+
+const (
+	parentKeySuffix       = ".parent"
+	syncStrategyKeySuffix = ".sync-strategy"
+)
+
 func NewParentKey(branch gitdomain.LocalBranchName) Key {
-	return Key(fmt.Sprintf("git-town-branch.%s.parent", branch))
+	return Key(BranchKeyPrefix + branch.String() + parentKeySuffix)
+}
+
+func NewSyncStrategyKey(branch gitdomain.LocalBranchName) Key {
+	return Key(BranchKeyPrefix + branch.String() + syncStrategyKeySuffix)
 }
 
 func ParseKey(name string) *Key {
@@ -167,9 +189,8 @@ func ParseKey(name string) *Key {
 			return &configKey
 		}
 	}
-	lineageKey := parseLineageKey(name)
-	if lineageKey != nil {
-		return lineageKey
+	if branchKey := parseBranchKey(name); branchKey != nil {
+		return branchKey
 	}
 	for _, aliasableCommand := range configdomain.AllAliasableCommands() {
 		key := KeyForAliasableCommand(aliasableCommand)
@@ -180,12 +201,39 @@ func ParseKey(name string) *Key {
 	return nil
 }
 
-func parseLineageKey(key string) *Key {
-	if !strings.HasPrefix(key, "git-town-branch.") || !strings.HasSuffix(key, ".parent") {
-		return nil
+// ParseBranchKey provides the branch that the given per-branch configuration key
+// with the given suffix is about.
+func ParseBranchKey(key Key, suffix string) (gitdomain.LocalBranchName, bool) {
+	text := key.String()
+	if !strings.HasPrefix(text, BranchKeyPrefix) || !strings.HasSuffix(text, suffix) {
+		return gitdomain.EmptyLocalBranchName(), false
+	}
+	branch := strings.TrimSuffix(strings.TrimPrefix(text, BranchKeyPrefix), suffix)
+	if branch == "" {
+		return gitdomain.EmptyLocalBranchName(), false
+	}
+	return gitdomain.NewLocalBranchName(branch), true
+}
+
+// ParseParentKey provides the branch whose parent the given lineage key defines.
+func ParseParentKey(key Key) (gitdomain.LocalBranchName, bool) {
+	return ParseBranchKey(key, parentKeySuffix)
+}
+
+// ParseSyncStrategyKey provides the branch whose sync strategy the given key defines.
+func ParseSyncStrategyKey(key Key) (gitdomain.LocalBranchName, bool) {
+	return ParseBranchKey(key, syncStrategyKeySuffix)
+}
+
+func parseBranchKey(name string) *Key {
+	key := Key(name)
+	if _, isParentKey := ParseParentKey(key); isParentKey {
+		return &key
 	}
-	result := Key(key)
-	return &result
+	if _, isSyncStrategyKey := ParseSyncStrategyKey(key); isSyncStrategyKey {
+		return &key
+	}
+	return nil
 }
 
 // DeprecatedKeys defines the up-to-date counterparts to deprecated configuration settings.
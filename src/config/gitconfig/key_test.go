@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/git-town/git-town/v14/src/config/gitconfig"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
 	"github.com/shoenig/test/must"
 )
 
@@ -38,6 +39,21 @@ func TestKey(t *testing.T) {
 				must.Nil(t, have)
 			})
 		})
+		t.Run("sync strategy keys", func(t *testing.T) {
+			t.Parallel()
+			t.Run("valid sync strategy key", func(t *testing.T) {
+				t.Parallel()
+				give := "git-town-branch.branch-1.sync-strategy"
+				have := gitconfig.ParseKey(give)
+				want := gitconfig.Key(give)
+				must.EqOp(t, want, *have)
+			})
+			t.Run("sync strategy key without branch", func(t *testing.T) {
+				t.Parallel()
+				have := gitconfig.ParseKey("git-town-branch..sync-strategy")
+				must.Nil(t, have)
+			})
+		})
 		t.Run("alias key", func(t *testing.T) {
 			t.Parallel()
 			t.Run("valid alias", func(t *testing.T) {
@@ -60,4 +76,19 @@ func TestKey(t *testing.T) {
 			must.Nil(t, have)
 		})
 	})
+
+	t.Run("ParseSyncStrategyKey", func(t *testing.T) {
+		t.Parallel()
+		t.Run("sync strategy key", func(t *testing.T) {
+			t.Parallel()
+			have, isSyncStrategyKey := gitconfig.ParseSyncStrategyKey(gitconfig.NewSyncStrategyKey(gitdomain.NewLocalBranchName("kg/feature")))
+			must.True(t, isSyncStrategyKey)
+			must.EqOp(t, gitdomain.NewLocalBranchName("kg/feature"), have)
+		})
+		t.Run("parent key", func(t *testing.T) {
+			t.Parallel()
+			_, isSyncStrategyKey := gitconfig.ParseSyncStrategyKey(gitconfig.NewParentKey(gitdomain.NewLocalBranchName("feature")))
+			must.False(t, isSyncStrategyKey)
+		})
+	})
 }
@@ -0,0 +1,96 @@
+package lineagefile
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/git-town/git-town/v14/src/config"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+)
+
+// Import merges the given data into the given configuration.
+// Imported entries override the existing entries for the same branch.
+// Provides how many lineage entries and branch types have changed.
+func Import(data Data, config *config.Config) (lineageChanges, typeChanges int, err error) { //nolint:nonamedreturns
+	branchTypes, err := data.BranchTypes()
+	if err != nil {
+		return 0, 0, err
+	}
+	imported := data.LineageEntries()
+	merged := maps.Clone(config.FullConfig.Lineage)
+	if merged == nil {
+		merged = configdomain.Lineage{}
+	}
+	maps.Copy(merged, imported)
+	for _, branch := range merged.BranchNames() {
+		if merged.IsInCycle(branch) {
+			return 0, 0, fmt.Errorf(messages.LineageFileCycle, branch)
+		}
+	}
+	for _, branch := range imported.BranchNames() {
+		parent := imported[branch]
+		if existing, has := config.FullConfig.Lineage[branch]; has && existing == parent {
+			continue
+		}
+		if err = config.SetParent(branch, parent); err != nil {
+			return lineageChanges, typeChanges, err
+		}
+		lineageChanges++
+	}
+	branches := gitdomain.LocalBranchNames{}
+	for branch := range branchTypes {
+		branches = append(branches, branch)
+	}
+	branches.Sort()
+	for _, branch := range branches {
+		oldType := config.FullConfig.BranchType(branch)
+		newType := branchTypes[branch]
+		if oldType == newType || oldType == configdomain.BranchTypeMainBranch {
+			continue
+		}
+		if err = removeBranchType(config, branch, oldType); err != nil {
+			return lineageChanges, typeChanges, err
+		}
+		if err = addBranchType(config, branch, newType); err != nil {
+			return lineageChanges, typeChanges, err
+		}
+		typeChanges++
+	}
+	return lineageChanges, typeChanges, nil
+}
+
+func addBranchType(config *config.Config, branch gitdomain.LocalBranchName, branchType configdomain.BranchType) error {
+	switch branchType {
+	case configdomain.BranchTypeContributionBranch:
+		return config.AddToContributionBranches(branch)
+	case configdomain.BranchTypeObservedBranch:
+		return config.AddToObservedBranches(branch)
+	case configdomain.BranchTypeParkedBranch:
+		return config.AddToParkedBranches(branch)
+	case configdomain.BranchTypePerennialBranch:
+		return config.AddToPerennialBranches(branch)
+	case configdomain.BranchTypePrototypeBranch:
+		return config.AddToPrototypeBranches(branch)
+	case configdomain.BranchTypeFeatureBranch, configdomain.BranchTypeMainBranch:
+	}
+	return nil
+}
+
+func removeBranchType(config *config.Config, branch gitdomain.LocalBranchName, branchType configdomain.BranchType) error {
+	switch branchType {
+	case configdomain.BranchTypeContributionBranch:
+		return config.RemoveFromContributionBranches(branch)
+	case configdomain.BranchTypeObservedBranch:
+		return config.RemoveFromObservedBranches(branch)
+	case configdomain.BranchTypeParkedBranch:
+		return config.RemoveFromParkedBranches(branch)
+	case configdomain.BranchTypePerennialBranch:
+		return config.RemoveFromPerennialBranches(branch)
+	case configdomain.BranchTypePrototypeBranch:
+		return config.RemoveFromPrototypeBranches(branch)
+	case configdomain.BranchTypeFeatureBranch, configdomain.BranchTypeMainBranch:
+	}
+	return nil
+}
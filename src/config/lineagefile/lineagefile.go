@@ -0,0 +1,125 @@
+// Package lineagefile converts the branch lineage and the branch types into a portable file format and back.
+package lineagefile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+)
+
+// Data defines the portable form of the branch lineage and the branch types.
+type Data struct {
+	Contribution []string          `json:"contribution" toml:"contribution"`
+	Lineage      map[string]string `json:"lineage"      toml:"lineage"`
+	Observed     []string          `json:"observed"     toml:"observed"`
+	Parked       []string          `json:"parked"       toml:"parked"`
+	Perennial    []string          `json:"perennial"    toml:"perennial"`
+	Prototype    []string          `json:"prototype"    toml:"prototype"`
+}
+
+// New provides the portable form of the lineage and branch types in the given configuration.
+func New(config *configdomain.FullConfig) Data {
+	lineage := make(map[string]string, len(config.Lineage))
+	for child, parent := range config.Lineage {
+		lineage[child.String()] = parent.String()
+	}
+	return Data{
+		Contribution: config.ContributionBranches.Strings(),
+		Lineage:      lineage,
+		Observed:     config.ObservedBranches.Strings(),
+		Parked:       config.ParkedBranches.Strings(),
+		Perennial:    config.PerennialBranches.Strings(),
+		Prototype:    config.PrototypeBranches.Strings(),
+	}
+}
+
+// Parse converts the given TOML or JSON source into Data.
+func Parse(text string) (Data, error) {
+	var result Data
+	var err error
+	if strings.HasPrefix(strings.TrimSpace(text), "{") {
+		err = json.Unmarshal([]byte(text), &result)
+	} else {
+		_, err = toml.Decode(text, &result)
+	}
+	if err != nil {
+		return result, fmt.Errorf(messages.LineageFileInvalid, err)
+	}
+	return result, nil
+}
+
+// Render provides the given data serialized in the given format.
+func Render(data Data, format Format) (string, error) {
+	switch format {
+	case FormatJSON:
+		result, err := json.MarshalIndent(data, "", "  ")
+		return string(result) + "\n", err
+	case FormatTOML:
+		buffer := bytes.Buffer{}
+		err := toml.NewEncoder(&buffer).Encode(data)
+		return buffer.String(), err
+	}
+	panic("unhandled lineage file format: " + format)
+}
+
+// BranchTypes provides the branch type for each branch listed in this data.
+func (self Data) BranchTypes() (map[gitdomain.LocalBranchName]configdomain.BranchType, error) {
+	result := map[gitdomain.LocalBranchName]configdomain.BranchType{}
+	lists := []struct {
+		branches   []string
+		branchType configdomain.BranchType
+	}{
+		{self.Contribution, configdomain.BranchTypeContributionBranch},
+		{self.Observed, configdomain.BranchTypeObservedBranch},
+		{self.Parked, configdomain.BranchTypeParkedBranch},
+		{self.Perennial, configdomain.BranchTypePerennialBranch},
+		{self.Prototype, configdomain.BranchTypePrototypeBranch},
+	}
+	for _, list := range lists {
+		for _, name := range list.branches {
+			branch := gitdomain.NewLocalBranchName(name)
+			if existing, has := result[branch]; has {
+				return result, fmt.Errorf(messages.LineageFileMultipleTypes, branch, existing, list.branchType)
+			}
+			result[branch] = list.branchType
+		}
+	}
+	return result, nil
+}
+
+// LineageEntries provides the lineage contained in this data.
+func (self Data) LineageEntries() configdomain.Lineage {
+	result := make(configdomain.Lineage, len(self.Lineage))
+	for child, parent := range self.Lineage {
+		result[gitdomain.NewLocalBranchName(child)] = gitdomain.NewLocalBranchName(parent)
+	}
+	return result
+}
+
+// Format defines the file formats in which lineage files can be written.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatTOML Format = "toml"
+)
+
+// ParseFormat provides the Format with the given name.
+func ParseFormat(text string) (Format, error) {
+	for _, format := range []Format{FormatTOML, FormatJSON} {
+		if text == format.String() {
+			return format, nil
+		}
+	}
+	return FormatTOML, fmt.Errorf(messages.LineageFileFormatUnknown, text)
+}
+
+func (self Format) String() string {
+	return string(self)
+}
@@ -0,0 +1,106 @@
+package lineagefile_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/config/lineagefile"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/shoenig/test/must"
+)
+
+func TestLineageFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("BranchTypes", func(t *testing.T) {
+		t.Parallel()
+		t.Run("branches with one type each", func(t *testing.T) {
+			t.Parallel()
+			data := lineagefile.Data{ //nolint:exhaustruct
+				Observed:  []string{"observed"},
+				Perennial: []string{"qa"},
+				Prototype: []string{"prototype"},
+			}
+			have, err := data.BranchTypes()
+			must.NoError(t, err)
+			want := map[gitdomain.LocalBranchName]configdomain.BranchType{
+				gitdomain.NewLocalBranchName("observed"):  configdomain.BranchTypeObservedBranch,
+				gitdomain.NewLocalBranchName("prototype"): configdomain.BranchTypePrototypeBranch,
+				gitdomain.NewLocalBranchName("qa"):        configdomain.BranchTypePerennialBranch,
+			}
+			must.Eq(t, want, have)
+		})
+		t.Run("branch with several types", func(t *testing.T) {
+			t.Parallel()
+			data := lineagefile.Data{ //nolint:exhaustruct
+				Parked:    []string{"branch"},
+				Perennial: []string{"branch"},
+			}
+			_, err := data.BranchTypes()
+			must.Error(t, err)
+		})
+	})
+
+	t.Run("Parse", func(t *testing.T) {
+		t.Parallel()
+		t.Run("TOML", func(t *testing.T) {
+			t.Parallel()
+			give := `
+parked = ["beta"]
+
+[lineage]
+  alpha = "main"
+  beta = "alpha"
+`
+			have, err := lineagefile.Parse(give)
+			must.NoError(t, err)
+			must.Eq(t, []string{"beta"}, have.Parked)
+			must.Eq(t, map[string]string{"alpha": "main", "beta": "alpha"}, have.Lineage)
+		})
+		t.Run("JSON", func(t *testing.T) {
+			t.Parallel()
+			give := `{"lineage": {"alpha": "main"}, "perennial": ["qa"]}`
+			have, err := lineagefile.Parse(give)
+			must.NoError(t, err)
+			must.Eq(t, []string{"qa"}, have.Perennial)
+			must.Eq(t, map[string]string{"alpha": "main"}, have.Lineage)
+		})
+		t.Run("invalid content", func(t *testing.T) {
+			t.Parallel()
+			_, err := lineagefile.Parse("{lineage")
+			must.Error(t, err)
+		})
+	})
+
+	t.Run("ParseFormat", func(t *testing.T) {
+		t.Parallel()
+		have, err := lineagefile.ParseFormat("json")
+		must.NoError(t, err)
+		must.EqOp(t, lineagefile.FormatJSON, have)
+		_, err = lineagefile.ParseFormat("yaml")
+		must.Error(t, err)
+	})
+
+	t.Run("Render", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage: configdomain.Lineage{
+				gitdomain.NewLocalBranchName("alpha"): gitdomain.NewLocalBranchName("main"),
+			},
+			PerennialBranches: gitdomain.NewLocalBranchNames("qa"),
+			PrototypeBranches: gitdomain.NewLocalBranchNames("prototype"),
+		}
+		for _, format := range []lineagefile.Format{lineagefile.FormatJSON, lineagefile.FormatTOML} {
+			text, err := lineagefile.Render(lineagefile.New(&config), format)
+			must.NoError(t, err)
+			have, err := lineagefile.Parse(text)
+			must.NoError(t, err)
+			must.Eq(t, config.Lineage, have.LineageEntries())
+			must.Eq(t, []string{"qa"}, have.Perennial)
+			must.Eq(t, []string{"prototype"}, have.Prototype)
+			branchTypes, err := have.BranchTypes()
+			must.NoError(t, err)
+			must.EqOp(t, configdomain.BranchTypePrototypeBranch, branchTypes[gitdomain.NewLocalBranchName("prototype")])
+		}
+	})
+}
@@ -0,0 +1,136 @@
+// Package lineageinfer proposes parent branches for local branches that have no lineage entry yet.
+package lineageinfer
+
+import (
+	"maps"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/gohacks/slice"
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+)
+
+// Backend describes the Git operations needed to infer the lineage.
+type Backend interface {
+	AheadBehind(branch, other gitdomain.BranchName) (ahead, behind int, err error)
+}
+
+// Infer proposes parent branches for all given local branches that need a parent but don't have one in the lineage yet.
+//
+// The target branch of a proposal takes precedence.
+// Otherwise the parent is the closest branch that is contained in the branch,
+// or the main or perennial branch that the branch diverged from most recently.
+func Infer(args InferArgs) (configdomain.Lineage, error) {
+	candidates := parentCandidates(args.Config, args.LocalBranches)
+	result := configdomain.Lineage{}
+	combined := maps.Clone(args.Config.Lineage)
+	if combined == nil {
+		combined = configdomain.Lineage{}
+	}
+	branches := BranchesToInfer(args.Config, args.LocalBranches)
+	for _, branch := range branches {
+		target, hasTarget := args.ProposalTargets[branch]
+		if hasTarget && target != branch && candidates.Contains(target) && !combined.IsAncestor(branch, target) {
+			result[branch] = target
+			combined[branch] = target
+		}
+	}
+	for _, branch := range branches {
+		if _, inferred := result[branch]; inferred {
+			continue
+		}
+		parent, err := closestParent(branch, candidates, args.Config, args.Backend)
+		if err != nil {
+			return result, err
+		}
+		if combined.IsAncestor(branch, parent) {
+			parent = args.Config.MainBranch
+		}
+		result[branch] = parent
+		combined[branch] = parent
+	}
+	return result, nil
+}
+
+type InferArgs struct {
+	Backend         Backend
+	Config          *configdomain.FullConfig
+	LocalBranches   gitdomain.LocalBranchNames
+	ProposalTargets map[gitdomain.LocalBranchName]gitdomain.LocalBranchName // the target branches of the proposals for the given local branches
+}
+
+// BranchesToInfer provides the branches among the given local branches that need a parent but don't have one.
+func BranchesToInfer(config *configdomain.FullConfig, localBranches gitdomain.LocalBranchNames) gitdomain.LocalBranchNames {
+	result := gitdomain.LocalBranchNames{}
+	for _, branch := range localBranches {
+		if needsParent(config, branch) && !config.Lineage.HasParents(branch) {
+			result = append(result, branch)
+		}
+	}
+	return slice.NaturalSort(result)
+}
+
+// closestParent provides the parent candidate with the fewest commits between it and the given branch.
+// Feature branch candidates must be fully contained in the given branch.
+// When several candidates are equally close, the main and perennial branches win.
+func closestParent(branch gitdomain.LocalBranchName, candidates gitdomain.LocalBranchNames, config *configdomain.FullConfig, backend Backend) (gitdomain.LocalBranchName, error) {
+	result := config.MainBranch
+	resultDistance := -1
+	for _, candidate := range candidates {
+		if candidate == branch {
+			continue
+		}
+		ahead, behind, err := backend.AheadBehind(branch.BranchName(), candidate.BranchName())
+		if err != nil {
+			return result, err
+		}
+		if !config.IsMainOrPerennialBranch(candidate) && (behind > 0 || ahead == 0) {
+			continue
+		}
+		if resultDistance == -1 || ahead < resultDistance {
+			result = candidate
+			resultDistance = ahead
+		}
+	}
+	return result, nil
+}
+
+// needsParent indicates whether the given branch should have a parent branch in the lineage.
+func needsParent(config *configdomain.FullConfig, branch gitdomain.LocalBranchName) bool {
+	return !config.IsMainOrPerennialBranch(branch) && !config.IsObservedBranch(branch) && !config.IsContributionBranch(branch)
+}
+
+// parentCandidates provides the branches that can be parents of other branches,
+// ordered so that the main and perennial branches come first.
+func parentCandidates(config *configdomain.FullConfig, localBranches gitdomain.LocalBranchNames) gitdomain.LocalBranchNames {
+	result := gitdomain.LocalBranchNames{config.MainBranch}
+	features := gitdomain.LocalBranchNames{}
+	for _, branch := range localBranches {
+		switch {
+		case branch == config.MainBranch:
+		case config.IsMainOrPerennialBranch(branch):
+			result = append(result, branch)
+		case needsParent(config, branch):
+			features = append(features, branch)
+		}
+	}
+	return append(result, slice.NaturalSort(features)...)
+}
+
+// ProposalTargets provides the target branches of the open proposals for the given branches.
+func ProposalTargets(connector hostingdomain.Connector, branches gitdomain.LocalBranchNames) (map[gitdomain.LocalBranchName]gitdomain.LocalBranchName, error) {
+	result := map[gitdomain.LocalBranchName]gitdomain.LocalBranchName{}
+	if connector == nil {
+		return result, nil
+	}
+	for _, branch := range branches {
+		proposal, err := connector.FindProposal(branch, gitdomain.EmptyLocalBranchName())
+		if err != nil {
+			return result, err
+		}
+		if proposal != nil {
+			result[branch] = proposal.Target
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,175 @@
+package lineageinfer_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/config/lineageinfer"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/shoenig/test/must"
+)
+
+// fakeBackend provides predefined ahead/behind counts for pairs of branches.
+type fakeBackend map[string][2]int
+
+func (self fakeBackend) AheadBehind(branch, other gitdomain.BranchName) (int, int, error) {
+	counts := self[branch.String()+" "+other.String()]
+	return counts[0], counts[1], nil
+}
+
+func TestInfer(t *testing.T) {
+	t.Parallel()
+
+	main := gitdomain.NewLocalBranchName("main")
+	production := gitdomain.NewLocalBranchName("production")
+	branchA := gitdomain.NewLocalBranchName("branch-a")
+	branchB := gitdomain.NewLocalBranchName("branch-b")
+
+	t.Run("branches cut from main", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage:    configdomain.Lineage{},
+			MainBranch: main,
+		}
+		backend := fakeBackend{
+			"branch-a main":     {1, 0},
+			"branch-a branch-b": {1, 1},
+			"branch-b main":     {1, 0},
+			"branch-b branch-a": {1, 1},
+		}
+		have, err := lineageinfer.Infer(lineageinfer.InferArgs{
+			Backend:         backend,
+			Config:          &config,
+			LocalBranches:   gitdomain.LocalBranchNames{main, branchA, branchB},
+			ProposalTargets: map[gitdomain.LocalBranchName]gitdomain.LocalBranchName{},
+		})
+		must.NoError(t, err)
+		want := configdomain.Lineage{
+			branchA: main,
+			branchB: main,
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("stacked branches", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage:    configdomain.Lineage{},
+			MainBranch: main,
+		}
+		backend := fakeBackend{
+			"branch-a main":     {1, 0},
+			"branch-a branch-b": {0, 1},
+			"branch-b main":     {2, 0},
+			"branch-b branch-a": {1, 0},
+		}
+		have, err := lineageinfer.Infer(lineageinfer.InferArgs{
+			Backend:         backend,
+			Config:          &config,
+			LocalBranches:   gitdomain.LocalBranchNames{main, branchA, branchB},
+			ProposalTargets: map[gitdomain.LocalBranchName]gitdomain.LocalBranchName{},
+		})
+		must.NoError(t, err)
+		want := configdomain.Lineage{
+			branchA: main,
+			branchB: branchA,
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("branch cut from a perennial branch", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage:           configdomain.Lineage{},
+			MainBranch:        main,
+			PerennialBranches: gitdomain.LocalBranchNames{production},
+		}
+		backend := fakeBackend{
+			"branch-a main":       {3, 2},
+			"branch-a production": {1, 0},
+		}
+		have, err := lineageinfer.Infer(lineageinfer.InferArgs{
+			Backend:         backend,
+			Config:          &config,
+			LocalBranches:   gitdomain.LocalBranchNames{main, production, branchA},
+			ProposalTargets: map[gitdomain.LocalBranchName]gitdomain.LocalBranchName{},
+		})
+		must.NoError(t, err)
+		want := configdomain.Lineage{
+			branchA: production,
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("proposal targets take precedence", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage:    configdomain.Lineage{},
+			MainBranch: main,
+		}
+		backend := fakeBackend{
+			"branch-a main":     {1, 0},
+			"branch-a branch-b": {1, 1},
+			"branch-b main":     {1, 0},
+			"branch-b branch-a": {1, 1},
+		}
+		have, err := lineageinfer.Infer(lineageinfer.InferArgs{
+			Backend:       backend,
+			Config:        &config,
+			LocalBranches: gitdomain.LocalBranchNames{main, branchA, branchB},
+			ProposalTargets: map[gitdomain.LocalBranchName]gitdomain.LocalBranchName{
+				branchB: branchA,
+			},
+		})
+		must.NoError(t, err)
+		want := configdomain.Lineage{
+			branchA: main,
+			branchB: branchA,
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("ignores proposal targets that create cycles", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage: configdomain.Lineage{
+				branchA: branchB,
+			},
+			MainBranch: main,
+		}
+		backend := fakeBackend{
+			"branch-b main": {1, 0},
+		}
+		have, err := lineageinfer.Infer(lineageinfer.InferArgs{
+			Backend:       backend,
+			Config:        &config,
+			LocalBranches: gitdomain.LocalBranchNames{main, branchA, branchB},
+			ProposalTargets: map[gitdomain.LocalBranchName]gitdomain.LocalBranchName{
+				branchB: branchA,
+			},
+		})
+		must.NoError(t, err)
+		want := configdomain.Lineage{
+			branchB: main,
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("skips branches with known parents", func(t *testing.T) {
+		t.Parallel()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage: configdomain.Lineage{
+				branchA: main,
+			},
+			MainBranch: main,
+		}
+		have, err := lineageinfer.Infer(lineageinfer.InferArgs{
+			Backend:         fakeBackend{},
+			Config:          &config,
+			LocalBranches:   gitdomain.LocalBranchNames{main, branchA},
+			ProposalTargets: map[gitdomain.LocalBranchName]gitdomain.LocalBranchName{},
+		})
+		must.NoError(t, err)
+		must.Eq(t, configdomain.Lineage{}, have)
+	})
+}
@@ -0,0 +1,116 @@
+// Package lineageshare merges the local lineage with the lineage shared through the origin remote.
+package lineageshare
+
+import (
+	"maps"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/config/lineagefile"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+)
+
+// Backend describes the Git operations needed to read the shared lineage.
+type Backend interface {
+	BlobContent(sha gitdomain.SHA) (string, error)
+}
+
+// Adopt provides the entries of the shared lineage that the local lineage should take over.
+// These are the entries for local branches that need a parent but don't have one yet
+// and whose shared parent exists locally.
+// Entries that would make a branch its own ancestor are ignored.
+func Adopt(args AdoptArgs) configdomain.Lineage {
+	result := configdomain.Lineage{}
+	combined := maps.Clone(args.Config.Lineage)
+	if combined == nil {
+		combined = configdomain.Lineage{}
+	}
+	for _, branch := range args.Shared.BranchNames() {
+		parent := args.Shared[branch]
+		if !args.LocalBranches.Contains(branch) || !args.LocalBranches.Contains(parent) {
+			continue
+		}
+		if args.Config.IsMainOrPerennialBranch(branch) || args.Config.IsObservedBranch(branch) || args.Config.IsContributionBranch(branch) {
+			continue
+		}
+		if combined.HasParents(branch) || branch == parent || combined.IsAncestor(branch, parent) {
+			continue
+		}
+		result[branch] = parent
+		combined[branch] = parent
+	}
+	return result
+}
+
+type AdoptArgs struct {
+	Config        *configdomain.FullConfig
+	LocalBranches gitdomain.LocalBranchNames
+	Shared        configdomain.Lineage // the lineage shared through the origin remote
+}
+
+// Publish provides the lineage to share through the origin remote.
+// This is the local lineage merged into the shared lineage,
+// limited to branches that exist at the origin remote.
+// Local entries override the shared entries for the same branch
+// unless only the shared entry has changed since the last exchange.
+func Publish(args PublishArgs) configdomain.Lineage {
+	remoteBranches := gitdomain.LocalBranchNames{}
+	for _, branch := range args.Branches {
+		if !branch.RemoteName.IsEmpty() && branch.RemoteName.Remote() == gitdomain.RemoteOrigin {
+			remoteBranches = append(remoteBranches, branch.RemoteName.LocalBranchName())
+		}
+	}
+	result := configdomain.Lineage{}
+	for child, parent := range args.Local {
+		if !remoteBranches.Contains(child) {
+			continue
+		}
+		sharedParent, isShared := args.Shared[child]
+		if isShared && sharedParent != parent && args.Base[child] == parent {
+			continue
+		}
+		result[child] = parent
+	}
+	for _, branch := range args.Shared.BranchNames() {
+		parent := args.Shared[branch]
+		if !remoteBranches.Contains(branch) || result.HasParents(branch) || branch == parent || result.IsAncestor(branch, parent) {
+			continue
+		}
+		result[branch] = parent
+	}
+	return result
+}
+
+type PublishArgs struct {
+	Base     configdomain.Lineage  // the shared lineage at the time of the last exchange
+	Branches gitdomain.BranchInfos // all branches in the repo, including the branches at the origin remote
+	Local    configdomain.Lineage  // the local lineage
+	Shared   configdomain.Lineage  // the lineage shared through the origin remote
+}
+
+// Read provides the shared lineage stored in the blob with the given SHA.
+// Provides an empty lineage if the given SHA is empty.
+func Read(backend Backend, sha gitdomain.SHA) (configdomain.Lineage, error) {
+	if sha.IsEmpty() {
+		return configdomain.Lineage{}, nil
+	}
+	content, err := backend.BlobContent(sha)
+	if err != nil {
+		return configdomain.Lineage{}, err
+	}
+	data, err := lineagefile.Parse(content)
+	if err != nil {
+		return configdomain.Lineage{}, err
+	}
+	return data.LineageEntries(), nil
+}
+
+// Render provides the given lineage in the format used to share it through the origin remote.
+func Render(lineage configdomain.Lineage) (string, error) {
+	entries := make(map[string]string, len(lineage))
+	for child, parent := range lineage {
+		entries[child.String()] = parent.String()
+	}
+	return lineagefile.Render(lineagefile.Data{ //nolint:exhaustruct
+		Lineage: entries,
+	}, lineagefile.FormatTOML)
+}
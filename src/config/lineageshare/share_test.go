@@ -0,0 +1,165 @@
+package lineageshare_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/config/lineageshare"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/shoenig/test/must"
+)
+
+// fakeBackend provides predefined blob contents.
+type fakeBackend map[string]string
+
+func (self fakeBackend) BlobContent(sha gitdomain.SHA) (string, error) {
+	return self[sha.String()], nil
+}
+
+func TestShare(t *testing.T) {
+	t.Parallel()
+
+	main := gitdomain.NewLocalBranchName("main")
+	alpha := gitdomain.NewLocalBranchName("alpha")
+	beta := gitdomain.NewLocalBranchName("beta")
+	gamma := gitdomain.NewLocalBranchName("gamma")
+
+	t.Run("Adopt", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("adopts entries for local branches without a parent", func(t *testing.T) {
+			t.Parallel()
+			config := configdomain.FullConfig{ //nolint:exhaustruct
+				Lineage:    configdomain.Lineage{alpha: main},
+				MainBranch: main,
+			}
+			have := lineageshare.Adopt(lineageshare.AdoptArgs{
+				Config:        &config,
+				LocalBranches: gitdomain.LocalBranchNames{main, alpha, beta},
+				Shared:        configdomain.Lineage{alpha: beta, beta: main, gamma: main},
+			})
+			want := configdomain.Lineage{beta: main}
+			must.Eq(t, want, have)
+		})
+
+		t.Run("ignores entries whose parent doesn't exist locally", func(t *testing.T) {
+			t.Parallel()
+			config := configdomain.FullConfig{ //nolint:exhaustruct
+				Lineage:    configdomain.Lineage{},
+				MainBranch: main,
+			}
+			have := lineageshare.Adopt(lineageshare.AdoptArgs{
+				Config:        &config,
+				LocalBranches: gitdomain.LocalBranchNames{main, beta},
+				Shared:        configdomain.Lineage{beta: alpha},
+			})
+			must.Eq(t, configdomain.Lineage{}, have)
+		})
+
+		t.Run("ignores entries for perennial branches", func(t *testing.T) {
+			t.Parallel()
+			config := configdomain.FullConfig{ //nolint:exhaustruct
+				Lineage:           configdomain.Lineage{},
+				MainBranch:        main,
+				PerennialBranches: gitdomain.LocalBranchNames{alpha},
+			}
+			have := lineageshare.Adopt(lineageshare.AdoptArgs{
+				Config:        &config,
+				LocalBranches: gitdomain.LocalBranchNames{main, alpha},
+				Shared:        configdomain.Lineage{alpha: main},
+			})
+			must.Eq(t, configdomain.Lineage{}, have)
+		})
+
+		t.Run("ignores entries that create cycles", func(t *testing.T) {
+			t.Parallel()
+			config := configdomain.FullConfig{ //nolint:exhaustruct
+				Lineage:    configdomain.Lineage{alpha: beta},
+				MainBranch: main,
+			}
+			have := lineageshare.Adopt(lineageshare.AdoptArgs{
+				Config:        &config,
+				LocalBranches: gitdomain.LocalBranchNames{main, alpha, beta},
+				Shared:        configdomain.Lineage{beta: alpha},
+			})
+			must.Eq(t, configdomain.Lineage{}, have)
+		})
+	})
+
+	t.Run("Publish", func(t *testing.T) {
+		t.Parallel()
+		branches := gitdomain.BranchInfos{
+			{LocalName: alpha, RemoteName: gitdomain.NewRemoteBranchName("origin/alpha")},                            //nolint:exhaustruct
+			{LocalName: beta, RemoteName: gitdomain.NewRemoteBranchName("origin/beta")},                              //nolint:exhaustruct
+			{LocalName: gamma, RemoteName: gitdomain.EmptyRemoteBranchName()},                                        //nolint:exhaustruct
+			{LocalName: gitdomain.EmptyLocalBranchName(), RemoteName: gitdomain.NewRemoteBranchName("origin/other")}, //nolint:exhaustruct
+		}
+		other := gitdomain.NewLocalBranchName("other")
+
+		t.Run("merges the local lineage into the shared lineage", func(t *testing.T) {
+			t.Parallel()
+			have := lineageshare.Publish(lineageshare.PublishArgs{
+				Base:     configdomain.Lineage{},
+				Branches: branches,
+				Local:    configdomain.Lineage{alpha: main, beta: alpha, gamma: beta},
+				Shared:   configdomain.Lineage{other: main},
+			})
+			want := configdomain.Lineage{alpha: main, beta: alpha, other: main}
+			must.Eq(t, want, have)
+		})
+
+		t.Run("local changes override the shared lineage", func(t *testing.T) {
+			t.Parallel()
+			have := lineageshare.Publish(lineageshare.PublishArgs{
+				Base:     configdomain.Lineage{beta: alpha},
+				Branches: branches,
+				Local:    configdomain.Lineage{beta: main},
+				Shared:   configdomain.Lineage{beta: alpha},
+			})
+			want := configdomain.Lineage{beta: main}
+			must.Eq(t, want, have)
+		})
+
+		t.Run("keeps shared changes to entries that didn't change locally", func(t *testing.T) {
+			t.Parallel()
+			have := lineageshare.Publish(lineageshare.PublishArgs{
+				Base:     configdomain.Lineage{beta: alpha},
+				Branches: branches,
+				Local:    configdomain.Lineage{beta: alpha},
+				Shared:   configdomain.Lineage{beta: main},
+			})
+			want := configdomain.Lineage{beta: main}
+			must.Eq(t, want, have)
+		})
+
+		t.Run("removes branches that don't exist at origin", func(t *testing.T) {
+			t.Parallel()
+			have := lineageshare.Publish(lineageshare.PublishArgs{
+				Base:     configdomain.Lineage{},
+				Branches: branches,
+				Local:    configdomain.Lineage{},
+				Shared:   configdomain.Lineage{alpha: main, gamma: main},
+			})
+			want := configdomain.Lineage{alpha: main}
+			must.Eq(t, want, have)
+		})
+	})
+
+	t.Run("Render and Read", func(t *testing.T) {
+		t.Parallel()
+		lineage := configdomain.Lineage{alpha: main, beta: alpha}
+		content, err := lineageshare.Render(lineage)
+		must.NoError(t, err)
+		backend := fakeBackend{"123456": content}
+		have, err := lineageshare.Read(backend, gitdomain.NewSHA("123456"))
+		must.NoError(t, err)
+		must.Eq(t, lineage, have)
+	})
+
+	t.Run("Read with an empty SHA", func(t *testing.T) {
+		t.Parallel()
+		have, err := lineageshare.Read(fakeBackend{}, gitdomain.EmptySHA())
+		must.NoError(t, err)
+		must.Eq(t, configdomain.Lineage{}, have)
+	})
+}
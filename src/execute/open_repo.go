@@ -75,6 +75,7 @@ func OpenRepo(args OpenRepoArgs) (*OpenRepoResult, error) {
 			Runner: newFrontendRunner(newFrontendRunnerArgs{
 				counter:          &commandsCounter,
 				dryRun:           args.DryRun,
+				enableRerere:     newEnableRerere(&backendCommands, gitVersionMajor, gitVersionMinor),
 				getCurrentBranch: backendCommands.CurrentBranch,
 				omitBranchNames:  args.OmitBranchNames,
 				printCommands:    args.PrintCommands,
@@ -131,6 +132,26 @@ type OpenRepoResult struct {
 	Runner         *git.ProdRunner
 }
 
+// newEnableRerere provides a function that indicates whether Git Town should enable Git's rerere feature.
+// Git Town enables rerere only for users who haven't configured it themselves.
+// It provides this setting to Git through environment variables, which requires Git 2.31 or higher.
+// Recording resolutions creates the .git/rr-cache folder,
+// and Git enables rerere in repositories that contain this folder unless "rerere.enabled" is configured,
+// so afterwards Git also records and replays resolutions for the merges and rebases the user runs.
+func newEnableRerere(backend *git.BackendCommands, gitVersionMajor, gitVersionMinor int) subshell.EnableRerereFunc {
+	if !validate.CanConfigureGitThroughEnv(gitVersionMajor, gitVersionMinor) {
+		return func() bool { return false }
+	}
+	var enable *bool
+	return func() bool {
+		if enable == nil {
+			result := !backend.IsRerereConfigured()
+			enable = &result
+		}
+		return *enable
+	}
+}
+
 // newFrontendRunner provides a FrontendRunner instance that behaves according to the given configuration.
 func newFrontendRunner(args newFrontendRunnerArgs) git.FrontendRunner {
 	if args.dryRun {
@@ -142,6 +163,7 @@ func newFrontendRunner(args newFrontendRunnerArgs) git.FrontendRunner {
 		}
 	}
 	return &subshell.FrontendRunner{
+		EnableRerere:     args.enableRerere,
 		GetCurrentBranch: args.getCurrentBranch,
 		OmitBranchNames:  args.omitBranchNames,
 		PrintCommands:    args.printCommands,
@@ -152,6 +174,7 @@ func newFrontendRunner(args newFrontendRunnerArgs) git.FrontendRunner {
 type newFrontendRunnerArgs struct {
 	counter          *gohacks.Counter
 	dryRun           bool
+	enableRerere     subshell.EnableRerereFunc
 	getCurrentBranch subshell.GetCurrentBranchFunc
 	omitBranchNames  bool
 	printCommands    bool
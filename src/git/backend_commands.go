@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -34,6 +35,15 @@ type BackendCommands struct {
 	Runner             BackendRunner  // executes shell commands in the directory of the Git repo
 }
 
+// AheadBehind provides how many commits the given branch is ahead and behind the given other branch.
+func (self *BackendCommands) AheadBehind(branch, other gitdomain.BranchName) (ahead, behind int, err error) { //nolint:nonamedreturns
+	output, err := self.Runner.QueryTrim("git", "rev-list", "--left-right", "--count", branch.String()+"..."+other.String())
+	if err != nil {
+		return 0, 0, fmt.Errorf(messages.BranchAheadBehindProblem, branch, other, err)
+	}
+	return ParseAheadBehind(output)
+}
+
 // Author provides the locally Git configured user.
 func (self *BackendCommands) Author() (string, error) {
 	email := self.Config.FullConfig.GitUserEmail
@@ -47,6 +57,11 @@ func (self *BackendCommands) Author() (string, error) {
 	return name + " <" + email + ">", nil
 }
 
+// BlobContent provides the content of the blob with the given SHA.
+func (self *BackendCommands) BlobContent(sha gitdomain.SHA) (string, error) {
+	return self.Runner.Query("git", "cat-file", "blob", sha.String())
+}
+
 // BranchAuthors provides the user accounts that contributed to the given branch.
 // Returns lines of "name <email>".
 func (self *BackendCommands) BranchAuthors(branch, parent gitdomain.LocalBranchName) ([]string, error) {
@@ -88,9 +103,22 @@ func (self *BackendCommands) BranchesSnapshot() (gitdomain.BranchesSnapshot, err
 	if !currentBranch.IsEmpty() {
 		self.CurrentBranchCache.Set(currentBranch)
 	}
+	worktrees := gitdomain.Worktrees{}
+	// only branches checked out in other worktrees make it necessary to look up the worktrees
+	for _, branch := range branches {
+		if branch.SyncStatus == gitdomain.SyncStatusOtherWorktree {
+			worktreesOutput, err := self.Runner.QueryTrim("git", "worktree", "list", "--porcelain")
+			if err != nil {
+				return gitdomain.EmptyBranchesSnapshot(), fmt.Errorf(messages.WorktreesProblem, err)
+			}
+			worktrees = ParseWorktrees(worktreesOutput).Other(self.RootDirectory())
+			break
+		}
+	}
 	return gitdomain.BranchesSnapshot{
-		Branches: branches,
-		Active:   currentBranch,
+		Branches:  branches,
+		Active:    currentBranch,
+		Worktrees: worktrees,
 	}, nil
 }
 
@@ -186,6 +214,29 @@ func (self *BackendCommands) CommentOutSquashCommitMessage(prefix string) error
 	return os.WriteFile(squashMessageFile, []byte(content), 0o600)
 }
 
+// CommitMessage provides the full message of the commit with the given SHA.
+func (self *BackendCommands) CommitMessage(sha gitdomain.SHA) (gitdomain.CommitMessage, error) {
+	out, err := self.Runner.QueryTrim("git", "log", "-1", "--format=%B", sha.String())
+	if err != nil {
+		return "", fmt.Errorf(messages.CommitMessageProblem, err)
+	}
+	return gitdomain.CommitMessage(out), nil
+}
+
+// CommitTree creates a commit with the given tree, parents, and message without changing any branch.
+func (self *BackendCommands) CommitTree(tree gitdomain.SHA, parents []gitdomain.SHA, message string) (gitdomain.SHA, error) {
+	args := []string{"commit-tree", tree.String()}
+	for _, parent := range parents {
+		args = append(args, "-p", parent.String())
+	}
+	args = append(args, "-m", message)
+	output, err := self.Runner.QueryTrim("git", args...)
+	if err != nil {
+		return gitdomain.EmptySHA(), fmt.Errorf(messages.CommitTreeProblem, err)
+	}
+	return gitdomain.NewSHA(output), nil
+}
+
 func (self *BackendCommands) CommitsInBranch(branch, parent gitdomain.LocalBranchName) (gitdomain.Commits, error) {
 	if parent.IsEmpty() {
 		return self.CommitsInPerennialBranch()
@@ -221,22 +272,16 @@ func (self *BackendCommands) CommitsInPerennialBranch() (gitdomain.Commits, erro
 	if err != nil {
 		return gitdomain.Commits{}, err
 	}
-	lines := stringslice.Lines(output)
-	result := make(gitdomain.Commits, 0, len(lines))
-	for _, line := range lines {
-		if len(line) == 0 {
-			continue
-		}
-		sha, message, ok := strings.Cut(line, " ")
-		if !ok {
-			continue
-		}
-		result = append(result, gitdomain.Commit{
-			Message: gitdomain.CommitMessage(message),
-			SHA:     gitdomain.NewSHA(sha),
-		})
+	return ParseCommits(output), nil
+}
+
+// CommitsSince provides the commits that the current branch contains on top of the given SHA, oldest first.
+func (self *BackendCommands) CommitsSince(sha gitdomain.SHA) (gitdomain.Commits, error) {
+	output, err := self.Runner.QueryTrim("git", "log", "--reverse", "--pretty=format:%h %s", sha.String()+"..HEAD")
+	if err != nil {
+		return gitdomain.Commits{}, err
 	}
-	return result, nil
+	return ParseCommits(output), nil
 }
 
 // CurrentBranch provides the name of the currently checked out branch.
@@ -281,6 +326,11 @@ func (self *BackendCommands) DefaultBranch() gitdomain.LocalBranchName {
 	return gitdomain.LocalBranchName(name)
 }
 
+// DiffParent provides the changes made on the given branch compared to its parent branch.
+func (self *BackendCommands) DiffParent(branch, parentBranch gitdomain.LocalBranchName) (string, error) {
+	return self.Runner.Query("git", "diff", parentBranch.String()+".."+branch.String())
+}
+
 func (self *BackendCommands) FirstExistingBranch(branches gitdomain.LocalBranchNames, mainBranch gitdomain.LocalBranchName) gitdomain.LocalBranchName {
 	for _, branch := range branches {
 		if self.BranchExists(branch) {
@@ -311,6 +361,24 @@ func (self *BackendCommands) HasShippableChanges(branch, mainBranch gitdomain.Lo
 	return out != "", nil
 }
 
+// IsAncestorCommit indicates whether the given ancestor commit is contained in the history of the given commit.
+func (self *BackendCommands) IsAncestorCommit(ancestor, commit gitdomain.SHA) (bool, error) {
+	err := self.Runner.Run("git", "merge-base", "--is-ancestor", ancestor.String(), commit.String())
+	if err == nil {
+		return true, nil
+	}
+	if exitCode(err) == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf(messages.MergeBaseProblem, ancestor, commit, err)
+}
+
+// IsRerereConfigured indicates whether the user has configured Git's rerere feature, enabled or disabled.
+func (self *BackendCommands) IsRerereConfigured() bool {
+	_, err := self.Runner.Query("git", "config", "--get", "rerere.enabled")
+	return err == nil
+}
+
 // LastCommitMessage provides the commit message for the last commit.
 func (self *BackendCommands) LastCommitMessage() (gitdomain.CommitMessage, error) {
 	out, err := self.Runner.QueryTrim("git", "log", "-1", "--format=%B")
@@ -320,6 +388,46 @@ func (self *BackendCommands) LastCommitMessage() (gitdomain.CommitMessage, error
 	return gitdomain.CommitMessage(out), nil
 }
 
+// MergeBase provides the SHA of the best common ancestor of the given branches.
+func (self *BackendCommands) MergeBase(branch, other gitdomain.BranchName) (gitdomain.SHA, error) {
+	output, err := self.Runner.QueryTrim("git", "merge-base", branch.String(), other.String())
+	if err != nil {
+		return gitdomain.EmptySHA(), fmt.Errorf(messages.MergeBaseProblem, branch, other, err)
+	}
+	return gitdomain.NewSHA(output), nil
+}
+
+// MergeInMemory provides the commit that "git merge --no-edit" would create when merging the given other commit into the given commit,
+// without changing any branch or the workspace.
+// Provides the files with conflicts if the merge is not possible without conflicts.
+func (self *BackendCommands) MergeInMemory(commit, other gitdomain.SHA, message string) (gitdomain.SHA, []string, error) {
+	alreadyMerged, err := self.IsAncestorCommit(other, commit)
+	if err != nil || alreadyMerged {
+		return commit, []string{}, err
+	}
+	fastForward, err := self.IsAncestorCommit(commit, other)
+	if err != nil || fastForward {
+		return other, []string{}, err
+	}
+	tree, conflicts, err := self.MergeTree(commit, other)
+	if err != nil || len(conflicts) > 0 {
+		return commit, conflicts, err
+	}
+	result, err := self.CommitTree(tree, []gitdomain.SHA{commit, other}, message)
+	return result, conflicts, err
+}
+
+// MergeTree merges the given commits in memory, without touching the workspace.
+// Provides the resulting tree and the files with conflicts.
+func (self *BackendCommands) MergeTree(ours, theirs gitdomain.SHA) (tree gitdomain.SHA, conflicts []string, err error) { //nolint:nonamedreturns
+	output, err := self.Runner.QueryTrim("git", "merge-tree", "--write-tree", "--no-messages", "--name-only", ours.String(), theirs.String())
+	if err != nil && exitCode(err) != 1 {
+		return gitdomain.EmptySHA(), []string{}, fmt.Errorf(messages.MergeTreeProblem, ours, theirs, err)
+	}
+	lines := stringslice.Lines(output)
+	return gitdomain.NewSHA(lines[0]), lines[1:], nil
+}
+
 // PreviouslyCheckedOutBranch provides the name of the branch that was previously checked out in this repo.
 func (self *BackendCommands) PreviouslyCheckedOutBranch() gitdomain.LocalBranchName {
 	output, err := self.Runner.QueryTrim("git", "rev-parse", "--verify", "--abbrev-ref", "@{-1}")
@@ -356,6 +464,55 @@ func (self *BackendCommands) RemotesUncached() (gitdomain.Remotes, error) {
 	return gitdomain.NewRemotes(stringslice.Lines(out)...), nil
 }
 
+// RecordConflictResolutions lets Git's rerere feature record how the user resolved the conflicts of the last merge or rebase.
+func (self *BackendCommands) RecordConflictResolutions() error {
+	return self.Runner.Run("git", "rerere")
+}
+
+// RebaseInMemory approximates the outcome of rebasing the given commit onto the given other commit,
+// without changing any branch or the workspace.
+// Replays all changes of the given commit as a single commit and provides the files with conflicts.
+func (self *BackendCommands) RebaseInMemory(commit, onto gitdomain.SHA) (gitdomain.SHA, []string, error) {
+	alreadyBased, err := self.IsAncestorCommit(onto, commit)
+	if err != nil || alreadyBased {
+		return commit, []string{}, err
+	}
+	fastForward, err := self.IsAncestorCommit(commit, onto)
+	if err != nil || fastForward {
+		return onto, []string{}, err
+	}
+	tree, conflicts, err := self.MergeTree(onto, commit)
+	if err != nil || len(conflicts) > 0 {
+		return commit, conflicts, err
+	}
+	result, err := self.CommitTree(tree, []gitdomain.SHA{onto}, "rebased "+commit.String())
+	return result, conflicts, err
+}
+
+// RerereResolvedFiles provides the files with conflicts in the current merge or rebase
+// if Git's rerere feature has resolved all of them with recorded resolutions.
+// Provides no files if there are no conflicts, some conflicts remain unresolved, or rerere is disabled.
+func (self *BackendCommands) RerereResolvedFiles() ([]string, error) {
+	unmerged, err := self.Runner.QueryTrim("git", "diff", "--name-only", "--diff-filter=U")
+	if err != nil || unmerged == "" {
+		return []string{}, err
+	}
+	remaining, err := self.Runner.QueryTrim("git", "rerere", "remaining")
+	if err != nil || remaining != "" {
+		return []string{}, err
+	}
+	// rerere doesn't report remaining conflicts if it is disabled, so verify that the conflicts are gone
+	files := stringslice.Lines(unmerged)
+	rootDir := self.RootDirectory()
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(rootDir.String(), file))
+		if err != nil || hasConflictMarkers(string(content)) {
+			return []string{}, err
+		}
+	}
+	return files, nil
+}
+
 // RemoveOutdatedConfiguration removes outdated Git Town configuration.
 func (self *BackendCommands) RemoveOutdatedConfiguration(localBranches gitdomain.LocalBranchNames) error {
 	for child, parent := range self.Config.FullConfig.Lineage {
@@ -365,25 +522,17 @@ func (self *BackendCommands) RemoveOutdatedConfiguration(localBranches gitdomain
 			self.Config.RemoveParent(child)
 		}
 	}
+	for _, branch := range self.Config.FullConfig.BranchSyncStrategies.Branches() {
+		if !localBranches.Contains(branch) {
+			self.Config.RemoveBranchSyncStrategy(branch)
+		}
+	}
 	return nil
 }
 
 // RepoStatus provides a summary of the state the current workspace is in right now: rebasing, has conflicts, has open changes, etc.
 func (self *BackendCommands) RepoStatus() (gitdomain.RepoStatus, error) {
-	output, err := self.Runner.QueryTrim("git", "status", "--long", "--ignore-submodules")
-	if err != nil {
-		return gitdomain.RepoStatus{}, fmt.Errorf(messages.ConflictDetectionProblem, err)
-	}
-	hasConflicts := strings.Contains(output, "Unmerged paths")
-	hasOpenChanges := outputIndicatesOpenChanges(output)
-	hasUntrackedChanges := outputIndicatesUntrackedChanges(output)
-	rebaseInProgress := outputIndicatesRebaseInProgress(output)
-	return gitdomain.RepoStatus{
-		Conflicts:        hasConflicts,
-		OpenChanges:      hasOpenChanges,
-		RebaseInProgress: rebaseInProgress,
-		UntrackedChanges: hasUntrackedChanges,
-	}, nil
+	return self.repoStatus("status", "--long", "--ignore-submodules")
 }
 
 // RootDirectory provides the path of the root directory of the current repository,
@@ -405,6 +554,21 @@ func (self *BackendCommands) SHAForBranch(name gitdomain.BranchName) (gitdomain.
 	return gitdomain.NewSHA(output), nil
 }
 
+// SetSharedLineageTrackingRef records the given blob as the shared lineage at the origin remote.
+func (self *BackendCommands) SetSharedLineageTrackingRef(sha gitdomain.SHA) error {
+	return self.Runner.Run("git", "update-ref", gitdomain.SharedLineageTrackingRef, sha.String())
+}
+
+// SharedLineageSHA provides the SHA of the shared lineage last fetched from the origin remote.
+// Provides an empty SHA if the origin remote contains no shared lineage.
+func (self *BackendCommands) SharedLineageSHA() gitdomain.SHA {
+	output, err := self.Runner.QueryTrim("git", "rev-parse", "-q", "--verify", gitdomain.SharedLineageTrackingRef)
+	if err != nil {
+		return gitdomain.EmptySHA()
+	}
+	return gitdomain.NewSHA(output)
+}
+
 // ShouldPushBranch returns whether the local branch with the given name
 // contains commits that have not been pushed to its tracking branch.
 func (self *BackendCommands) ShouldPushBranch(branch gitdomain.LocalBranchName, trackingBranch gitdomain.RemoteBranchName) (bool, error) {
@@ -421,6 +585,12 @@ func (self *BackendCommands) StashSize() (gitdomain.StashSize, error) {
 	return gitdomain.StashSize(len(stringslice.Lines(output))), err
 }
 
+// UpdateBranchRef points the given local branch to the given commit,
+// provided the branch still points to the given previous commit.
+func (self *BackendCommands) UpdateBranchRef(branch gitdomain.LocalBranchName, sha, previous gitdomain.SHA) error {
+	return self.Runner.Run("git", "update-ref", "refs/heads/"+branch.String(), sha.String(), previous.String())
+}
+
 // Version indicates whether the needed Git version is installed.
 func (self *BackendCommands) Version() (major int, minor int, err error) {
 	versionRegexp := regexp.MustCompile(`git version (\d+).(\d+).(\d+)`)
@@ -443,6 +613,49 @@ func (self *BackendCommands) Version() (major int, minor int, err error) {
 	return majorVersion, minorVersion, nil
 }
 
+// WorktreeStatus provides the RepoStatus of the worktree at the given path.
+func (self *BackendCommands) WorktreeStatus(path gitdomain.RepoRootDir) (gitdomain.RepoStatus, error) {
+	return self.repoStatus("-C", path.String(), "status", "--long", "--ignore-submodules")
+}
+
+// Worktrees provides the worktrees of this repository that have a branch checked out.
+func (self *BackendCommands) Worktrees() (gitdomain.Worktrees, error) {
+	output, err := self.Runner.QueryTrim("git", "worktree", "list", "--porcelain")
+	if err != nil {
+		return gitdomain.Worktrees{}, fmt.Errorf(messages.WorktreesProblem, err)
+	}
+	result := ParseWorktrees(output)
+	for w, worktree := range result {
+		status, err := self.WorktreeStatus(worktree.Path)
+		if err != nil {
+			return result, err
+		}
+		result[w].OpenChanges = status.OpenChanges
+	}
+	return result, nil
+}
+
+// WriteBlob stores the given content as a blob in the Git object database.
+func (self *BackendCommands) WriteBlob(content string) (gitdomain.SHA, error) {
+	file, err := os.CreateTemp("", "git-town-blob-*")
+	if err != nil {
+		return gitdomain.EmptySHA(), err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return gitdomain.EmptySHA(), err
+	}
+	output, err := self.Runner.QueryTrim("git", "hash-object", "-w", file.Name())
+	if err != nil {
+		return gitdomain.EmptySHA(), err
+	}
+	return gitdomain.NewSHA(output), nil
+}
+
 func (self *BackendCommands) currentBranchDuringRebase() (gitdomain.LocalBranchName, error) {
 	output, err := self.Runner.QueryTrim("git", "branch", "--list")
 	if err != nil {
@@ -460,6 +673,24 @@ func (self *BackendCommands) currentBranchDuringRebase() (gitdomain.LocalBranchN
 	return ParseActiveBranchDuringRebase(lineWithStar), nil
 }
 
+// repoStatus provides the RepoStatus described by the output of the given "git status" command.
+func (self *BackendCommands) repoStatus(args ...string) (gitdomain.RepoStatus, error) {
+	output, err := self.Runner.QueryTrim("git", args...)
+	if err != nil {
+		return gitdomain.RepoStatus{}, fmt.Errorf(messages.ConflictDetectionProblem, err)
+	}
+	hasConflicts := strings.Contains(output, "Unmerged paths")
+	hasOpenChanges := outputIndicatesOpenChanges(output)
+	hasUntrackedChanges := outputIndicatesUntrackedChanges(output)
+	rebaseInProgress := outputIndicatesRebaseInProgress(output)
+	return gitdomain.RepoStatus{
+		Conflicts:        hasConflicts,
+		OpenChanges:      hasOpenChanges,
+		RebaseInProgress: rebaseInProgress,
+		UntrackedChanges: hasUntrackedChanges,
+	}, nil
+}
+
 func ParseActiveBranchDuringRebase(lineWithStar string) gitdomain.LocalBranchName {
 	parts := strings.Split(lineWithStar, " ")
 	partsWithBranchName := parts[4:]
@@ -467,6 +698,43 @@ func ParseActiveBranchDuringRebase(lineWithStar string) gitdomain.LocalBranchNam
 	return gitdomain.NewLocalBranchName(branchNameWithClosingParen[:len(branchNameWithClosingParen)-1])
 }
 
+// ParseAheadBehind parses the output of "git rev-list --left-right --count".
+func ParseAheadBehind(output string) (ahead, behind int, err error) { //nolint:nonamedreturns
+	parts := strings.Fields(output)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf(messages.AheadBehindUnexpectedOutput, output)
+	}
+	ahead, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf(messages.AheadBehindUnexpectedOutput, output)
+	}
+	behind, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf(messages.AheadBehindUnexpectedOutput, output)
+	}
+	return ahead, behind, nil
+}
+
+// ParseCommits parses the output of "git log --pretty=format:'%h %s'".
+func ParseCommits(output string) gitdomain.Commits {
+	lines := stringslice.Lines(output)
+	result := make(gitdomain.Commits, 0, len(lines))
+	for _, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		sha, message, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		result = append(result, gitdomain.Commit{
+			Message: gitdomain.CommitMessage(message),
+			SHA:     gitdomain.NewSHA(sha),
+		})
+	}
+	return result
+}
+
 // ParseVerboseBranchesOutput provides the branches in the given Git output as well as the name of the currently checked out branch.
 func ParseVerboseBranchesOutput(output string) (gitdomain.BranchInfos, gitdomain.LocalBranchName) {
 	result := gitdomain.BranchInfos{}
@@ -495,6 +763,10 @@ func ParseVerboseBranchesOutput(output string) (gitdomain.BranchInfos, gitdomain
 			sha = gitdomain.NewSHA(parts[1])
 		}
 		remoteText := parts[2]
+		if line[0] == '+' && strings.HasPrefix(remoteText, "(") {
+			// branches checked out in other worktrees list the path of that worktree before the tracking branch
+			_, remoteText, _ = strings.Cut(remoteText, ") ")
+		}
 		if line[0] == '*' && branchName != "(no" { // "(no" as in "(no branch, rebasing main)" is what we get when a rebase is active, in which case no branch is checked out
 			checkedoutBranch = gitdomain.NewLocalBranchName(branchName)
 		}
@@ -535,6 +807,36 @@ func ParseVerboseBranchesOutput(output string) (gitdomain.BranchInfos, gitdomain
 	return result, checkedoutBranch
 }
 
+// ParseWorktrees provides the worktrees with a checked out branch in the given output of "git worktree list --porcelain".
+func ParseWorktrees(output string) gitdomain.Worktrees {
+	result := gitdomain.Worktrees{}
+	for _, block := range strings.Split(output, "\n\n") {
+		path := gitdomain.EmptyRepoRootDir()
+		branch := gitdomain.EmptyLocalBranchName()
+		prunable := false
+		for _, line := range stringslice.Lines(block) {
+			key, value, _ := strings.Cut(line, " ")
+			switch key {
+			case "worktree":
+				path = gitdomain.NewRepoRootDir(filepath.FromSlash(value))
+			case "branch":
+				branch = gitdomain.NewLocalBranchName(strings.TrimPrefix(value, "refs/heads/"))
+			case "prunable":
+				prunable = true
+			}
+		}
+		if path.IsEmpty() || branch.IsEmpty() || prunable {
+			continue
+		}
+		result = append(result, gitdomain.Worktree{
+			Branch:      branch,
+			OpenChanges: false,
+			Path:        path,
+		})
+	}
+	return result
+}
+
 func determineSyncStatus(branchName, remoteText string) (syncStatus gitdomain.SyncStatus, trackingBranchName gitdomain.RemoteBranchName) {
 	isInSync, trackingBranchName := IsInSync(branchName, remoteText)
 	if isInSync {
@@ -562,6 +864,25 @@ func determineSyncStatus(branchName, remoteText string) (syncStatus gitdomain.Sy
 	return gitdomain.SyncStatusLocalOnly, gitdomain.EmptyRemoteBranchName()
 }
 
+// exitCode provides the exit code of the Git command that failed with the given error.
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// hasConflictMarkers indicates whether the given file content contains the markers of unresolved conflicts.
+func hasConflictMarkers(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "<<<<<<< ") || strings.HasPrefix(line, ">>>>>>> ") {
+			return true
+		}
+	}
+	return false
+}
+
 // isLocalBranchName indicates whether the branch with the given Git ref is local or remote.
 func isLocalBranchName(branch string) bool {
 	return !strings.HasPrefix(branch, "remotes/")
@@ -17,6 +17,35 @@ func TestBackendCommands(t *testing.T) {
 	t.Parallel()
 	initial := gitdomain.NewLocalBranchName("initial")
 
+	t.Run("AheadBehind", func(t *testing.T) {
+		t.Parallel()
+		runtime := testruntime.Create(t)
+		branch := gitdomain.NewLocalBranchName("branch")
+		runtime.CreateBranch(branch, initial)
+		runtime.CreateCommit(testgit.Commit{
+			Branch:      branch,
+			FileContent: "file1",
+			FileName:    "file1",
+			Message:     "first commit",
+		})
+		runtime.CreateCommit(testgit.Commit{
+			Branch:      branch,
+			FileContent: "file2",
+			FileName:    "file2",
+			Message:     "second commit",
+		})
+		runtime.CreateCommit(testgit.Commit{
+			Branch:      initial,
+			FileContent: "file3",
+			FileName:    "file3",
+			Message:     "third commit",
+		})
+		ahead, behind, err := runtime.Backend.AheadBehind(branch.BranchName(), initial.BranchName())
+		must.NoError(t, err)
+		must.EqOp(t, 2, ahead)
+		must.EqOp(t, 1, behind)
+	})
+
 	t.Run("BranchAuthors", func(t *testing.T) {
 		t.Parallel()
 		runtime := testruntime.Create(t)
@@ -230,6 +259,48 @@ func TestBackendCommands(t *testing.T) {
 		must.False(t, runner.Backend.HasLocalBranch(gitdomain.NewLocalBranchName("b3")))
 	})
 
+	t.Run("IsRerereConfigured", func(t *testing.T) {
+		t.Parallel()
+		t.Run("not configured", func(t *testing.T) {
+			t.Parallel()
+			runner := testruntime.Create(t)
+			must.False(t, runner.Backend.IsRerereConfigured())
+		})
+		t.Run("disabled", func(t *testing.T) {
+			t.Parallel()
+			runner := testruntime.Create(t)
+			runner.MustRun("git", "config", "rerere.enabled", "false")
+			must.True(t, runner.Backend.IsRerereConfigured())
+		})
+	})
+
+	t.Run("ParseAheadBehind", func(t *testing.T) {
+		t.Parallel()
+		t.Run("valid output", func(t *testing.T) {
+			t.Parallel()
+			ahead, behind, err := git.ParseAheadBehind("3\t1")
+			must.NoError(t, err)
+			must.EqOp(t, 3, ahead)
+			must.EqOp(t, 1, behind)
+		})
+		t.Run("invalid output", func(t *testing.T) {
+			t.Parallel()
+			_, _, err := git.ParseAheadBehind("zonk")
+			must.Error(t, err)
+		})
+	})
+
+	t.Run("ParseCommits", func(t *testing.T) {
+		t.Parallel()
+		give := "111111a first commit\n222222b second commit\n"
+		have := git.ParseCommits(give)
+		want := gitdomain.Commits{
+			{Message: "first commit", SHA: gitdomain.NewSHA("111111a")},
+			{Message: "second commit", SHA: gitdomain.NewSHA("222222b")},
+		}
+		must.Eq(t, want, have)
+	})
+
 	t.Run("parseActiveBranchDuringRebase", func(t *testing.T) {
 		t.Parallel()
 		t.Run("branch name is one word", func(t *testing.T) {
@@ -603,6 +674,22 @@ func TestBackendCommands(t *testing.T) {
 					must.Eq(t, want, have)
 				})
 
+				t.Run("branch is active in another worktree and deleted at the remote", func(t *testing.T) {
+					t.Parallel()
+					give := `+ branch-1    3d0c4c13 (/path/to/other/worktree) [origin/branch-1: gone] commit message`
+					want := gitdomain.BranchInfos{
+						gitdomain.BranchInfo{
+							LocalName:  gitdomain.NewLocalBranchName("branch-1"),
+							LocalSHA:   gitdomain.NewSHA("3d0c4c13"),
+							SyncStatus: gitdomain.SyncStatusOtherWorktree,
+							RemoteName: gitdomain.NewRemoteBranchName("origin/branch-1"),
+							RemoteSHA:  gitdomain.EmptySHA(),
+						},
+					}
+					have, _ := git.ParseVerboseBranchesOutput(give)
+					must.Eq(t, want, have)
+				})
+
 				t.Run("ParseVerboseBranchesOutput", func(t *testing.T) {
 					t.Parallel()
 					give := `  branch-1                     01a7eded [origin/branch-1: gone] Commit message 1`
@@ -722,6 +809,34 @@ func TestBackendCommands(t *testing.T) {
 		})
 	})
 
+	t.Run("ParseWorktrees", func(t *testing.T) {
+		t.Parallel()
+		give := `
+worktree /repo
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/main
+
+worktree /worktrees/feature
+HEAD 2222222222222222222222222222222222222222
+branch refs/heads/feature
+
+worktree /worktrees/detached
+HEAD 3333333333333333333333333333333333333333
+detached
+
+worktree /worktrees/gone
+HEAD 4444444444444444444444444444444444444444
+branch refs/heads/gone
+prunable gitdir file points to non-existent location
+`[1:]
+		have := git.ParseWorktrees(give)
+		want := gitdomain.Worktrees{
+			{Branch: gitdomain.NewLocalBranchName("main"), OpenChanges: false, Path: gitdomain.NewRepoRootDir("/repo")},
+			{Branch: gitdomain.NewLocalBranchName("feature"), OpenChanges: false, Path: gitdomain.NewRepoRootDir("/worktrees/feature")},
+		}
+		must.Eq(t, want, have)
+	})
+
 	t.Run("PreviouslyCheckedOutBranch", func(t *testing.T) {
 		t.Parallel()
 		runtime := testruntime.Create(t)
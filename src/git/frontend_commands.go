@@ -3,6 +3,7 @@ package git
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/config/gitconfig"
@@ -35,6 +36,11 @@ func (self *FrontendCommands) AbortRebase() error {
 	return self.Runner.Run("git", "rebase", "--abort")
 }
 
+// AddWorktree creates a new worktree at the given path that has the given branch checked out.
+func (self *FrontendCommands) AddWorktree(path gitdomain.RepoRootDir, branch gitdomain.LocalBranchName) error {
+	return self.Runner.Run("git", "worktree", "add", relativeToWorkingDir(path), branch.String())
+}
+
 // CheckoutBranch checks out the Git branch with the given name in this repo.
 func (self *FrontendCommands) CheckoutBranch(name gitdomain.LocalBranchName) error {
 	err := self.Runner.Run("git", "checkout", name.String())
@@ -75,6 +81,11 @@ func (self *FrontendCommands) ContinueRebase() error {
 	return self.Runner.Run("git", "rebase", "--continue")
 }
 
+// ContinueRebaseNoEdit continues the currently ongoing rebase without opening an editor for the commit message.
+func (self *FrontendCommands) ContinueRebaseNoEdit() error {
+	return self.Runner.Run("git", "-c", "core.editor=true", "rebase", "--continue")
+}
+
 // CreateAndCheckoutBranch creates a new branch with the given name and checks it out using a single Git operation.
 // The created branch is a normal branch.
 // To create feature branches, use CreateFeatureBranch.
@@ -167,6 +178,11 @@ func (self *FrontendCommands) FetchUpstream(branch gitdomain.LocalBranchName) er
 	return self.Runner.Run("git", "fetch", gitdomain.RemoteUpstream.String(), branch.String())
 }
 
+// FetchSharedLineage retrieves the shared lineage from the origin repo.
+func (self *FrontendCommands) FetchSharedLineage() error {
+	return self.Runner.Run("git", "fetch", gitdomain.RemoteOrigin.String(), "+refs/git-town/*:refs/git-town/remotes/origin/*")
+}
+
 // PushBranch pushes the branch with the given name to origin.
 func (self *FrontendCommands) ForcePushBranchSafely(noPushHook configdomain.NoPushHook) error {
 	args := []string{"push", "--force-with-lease", "--force-if-includes"}
@@ -197,6 +213,17 @@ func (self *FrontendCommands) Pull() error {
 	return self.Runner.Run("git", "pull")
 }
 
+// PushBranches pushes the given local branches to their tracking branches at origin.
+func (self *FrontendCommands) PushBranches(branches gitdomain.LocalBranchNames, noPushHook configdomain.NoPushHook) error {
+	args := []string{"push"}
+	if noPushHook {
+		args = append(args, "--no-verify")
+	}
+	args = append(args, gitdomain.RemoteOrigin.String())
+	args = append(args, branches.Strings()...)
+	return self.Runner.Run("git", args...)
+}
+
 // PushCurrentBranch pushes the current branch to its tracking branch.
 func (self *FrontendCommands) PushCurrentBranch(noPushHook configdomain.NoPushHook) error {
 	args := []string{"push"}
@@ -206,6 +233,17 @@ func (self *FrontendCommands) PushCurrentBranch(noPushHook configdomain.NoPushHo
 	return self.Runner.Run("git", args...)
 }
 
+// PushSharedLineage pushes the given blob as the new shared lineage to origin,
+// provided the shared lineage at origin is still the given previous one.
+func (self *FrontendCommands) PushSharedLineage(sha, previous gitdomain.SHA, noPushHook configdomain.NoPushHook) error {
+	args := []string{"push", "--force-with-lease=" + gitdomain.SharedLineageRef + ":" + previous.String()}
+	if noPushHook {
+		args = append(args, "--no-verify")
+	}
+	args = append(args, gitdomain.RemoteOrigin.String(), sha.String()+":"+gitdomain.SharedLineageRef)
+	return self.Runner.Run("git", args...)
+}
+
 // PushTags pushes new the Git tags to origin.
 func (self *FrontendCommands) PushTags() error {
 	return self.Runner.Run("git", "push", "--tags")
@@ -216,8 +254,13 @@ func (self *FrontendCommands) Rebase(target gitdomain.BranchName) error {
 	return self.Runner.Run("git", "rebase", target.String())
 }
 
+// RebaseOnto moves the commits of the current branch that aren't in the given upstream onto the given branch.
+func (self *FrontendCommands) RebaseOnto(branchToRebaseOnto gitdomain.BranchName, upstream gitdomain.Location) error {
+	return self.Runner.Run("git", "rebase", "--onto", branchToRebaseOnto.String(), upstream.String())
+}
+
 // ResetCurrentBranchToSHA undoes all commits on the current branch all the way until the given SHA.
-func (self *FrontendCommands) RemoveCommitsInCurrentBranch(parent gitdomain.LocalBranchName) error {
+func (self *FrontendCommands) RemoveCommitsInCurrentBranch(parent gitdomain.Location) error {
 	return self.Runner.Run("git", "reset", "--soft", parent.String())
 }
 
@@ -227,6 +270,11 @@ func (self *FrontendCommands) RemoveGitAlias(aliasableCommand configdomain.Alias
 	return self.Runner.Run("git", "config", "--global", "--unset", aliasKey.String())
 }
 
+// RemoveWorktree removes the worktree at the given path.
+func (self *FrontendCommands) RemoveWorktree(path gitdomain.RepoRootDir) error {
+	return self.Runner.Run("git", "worktree", "remove", relativeToWorkingDir(path))
+}
+
 // ResetCurrentBranchToSHA undoes all commits on the current branch all the way until the given SHA.
 func (self *FrontendCommands) ResetCurrentBranchToSHA(sha gitdomain.SHA, hard bool) error {
 	args := []string{"reset"}
@@ -247,6 +295,16 @@ func (self *FrontendCommands) RevertCommit(sha gitdomain.SHA) error {
 	return self.Runner.Run("git", "revert", sha.String())
 }
 
+// SetAzureDevOpsToken sets the given API token for the Azure DevOps API.
+func (self *FrontendCommands) SetAzureDevOpsToken(value configdomain.AzureDevOpsToken) error {
+	return self.Runner.Run("git", "config", "git-town.azure-devops-token", value.String())
+}
+
+// SetBitbucketToken sets the given API token for the Bitbucket API.
+func (self *FrontendCommands) SetBitbucketToken(value configdomain.BitbucketToken) error {
+	return self.Runner.Run("git", "config", "git-town.bitbucket-token", value.String())
+}
+
 // SetGitAlias sets the given Git alias.
 func (self *FrontendCommands) SetGitAlias(aliasableCommand configdomain.AliasableCommand) error {
 	return self.Runner.Run("git", "config", "--global", gitconfig.KeyForAliasableCommand(aliasableCommand).String(), "town "+aliasableCommand.String())
@@ -304,3 +362,17 @@ func (self *FrontendCommands) Stash() error {
 func (self *FrontendCommands) UndoLastCommit() error {
 	return self.Runner.Run("git", "reset", "--soft", "HEAD~1")
 }
+
+// relativeToWorkingDir provides the given path relative to the current working directory if possible,
+// so that the commands printed to the user are easier to read.
+func relativeToWorkingDir(path gitdomain.RepoRootDir) string {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return path.String()
+	}
+	result, err := filepath.Rel(workingDir, path.String())
+	if err != nil {
+		return path.String()
+	}
+	return result
+}
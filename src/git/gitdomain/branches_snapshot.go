@@ -9,12 +9,16 @@ type BranchesSnapshot struct {
 	// Don't use these branches for business logic since businss logic might want to modify its in-memory cache of branches
 	// as it adds or removes branches.
 	Branches BranchInfos
+
+	// the other worktrees that had a branch checked out at the time the snapshot was taken
+	Worktrees Worktrees
 }
 
 func EmptyBranchesSnapshot() BranchesSnapshot {
 	return BranchesSnapshot{
-		Active:   EmptyLocalBranchName(),
-		Branches: BranchInfos{},
+		Active:    EmptyLocalBranchName(),
+		Branches:  BranchInfos{},
+		Worktrees: Worktrees{},
 	}
 }
 
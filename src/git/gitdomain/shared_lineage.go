@@ -0,0 +1,8 @@
+package gitdomain
+
+const (
+	// SharedLineageRef is the Git ref at the origin remote that contains the shared lineage.
+	SharedLineageRef = "refs/git-town/lineage"
+	// SharedLineageTrackingRef is the local Git ref that contains the shared lineage last fetched from the origin remote.
+	SharedLineageTrackingRef = "refs/git-town/remotes/origin/lineage"
+)
@@ -14,5 +14,5 @@ const (
 	SyncStatusLocalOnly       SyncStatus = "local only"                 // the branch was created locally and hasn't been pushed to the remote yet
 	SyncStatusRemoteOnly      SyncStatus = "remote only"                // the branch exists only at the remote
 	SyncStatusDeletedAtRemote SyncStatus = "deleted at remote"          // the branch was deleted on the remote
-	SyncStatusOtherWorktree   SyncStatus = "active in another worktree" // the branch is active in another worktree and should not be synced
+	SyncStatusOtherWorktree   SyncStatus = "active in another worktree" // the branch is checked out in another worktree and gets synced there
 )
@@ -0,0 +1,44 @@
+package gitdomain
+
+// Worktree describes a Git worktree that has a branch checked out.
+type Worktree struct {
+	Branch      LocalBranchName // the branch checked out in this worktree
+	OpenChanges bool            // whether this worktree contains uncommitted changes
+	Path        RepoRootDir     // the root directory of this worktree
+}
+
+// Worktrees is a collection of Worktree instances.
+type Worktrees []Worktree
+
+// FindByBranch provides the worktree that has the given branch checked out.
+func (self Worktrees) FindByBranch(branch LocalBranchName) *Worktree {
+	for w, worktree := range self {
+		if worktree.Branch == branch {
+			return &self[w]
+		}
+	}
+	return nil
+}
+
+// NotIn provides the worktrees that don't exist in the given worktrees with the same branch checked out.
+func (self Worktrees) NotIn(other Worktrees) Worktrees {
+	result := Worktrees{}
+	for _, worktree := range self {
+		otherWorktree := other.FindByBranch(worktree.Branch)
+		if otherWorktree == nil || otherWorktree.Path != worktree.Path {
+			result = append(result, worktree)
+		}
+	}
+	return result
+}
+
+// Other provides the worktrees except the one at the given root directory.
+func (self Worktrees) Other(rootDir RepoRootDir) Worktrees {
+	result := Worktrees{}
+	for _, worktree := range self {
+		if worktree.Path != rootDir {
+			result = append(result, worktree)
+		}
+	}
+	return result
+}
@@ -0,0 +1,54 @@
+package gitdomain_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/shoenig/test/must"
+)
+
+func TestWorktrees(t *testing.T) {
+	t.Parallel()
+
+	worktrees := gitdomain.Worktrees{
+		{Branch: gitdomain.NewLocalBranchName("main"), OpenChanges: false, Path: gitdomain.NewRepoRootDir("/repo")},
+		{Branch: gitdomain.NewLocalBranchName("feature"), OpenChanges: true, Path: gitdomain.NewRepoRootDir("/feature")},
+	}
+
+	t.Run("FindByBranch", func(t *testing.T) {
+		t.Parallel()
+		t.Run("branch is checked out in a worktree", func(t *testing.T) {
+			t.Parallel()
+			have := worktrees.FindByBranch(gitdomain.NewLocalBranchName("feature"))
+			must.NotNil(t, have)
+			must.EqOp(t, gitdomain.NewRepoRootDir("/feature"), have.Path)
+		})
+		t.Run("branch is not checked out", func(t *testing.T) {
+			t.Parallel()
+			have := worktrees.FindByBranch(gitdomain.NewLocalBranchName("other"))
+			must.Nil(t, have)
+		})
+	})
+
+	t.Run("NotIn", func(t *testing.T) {
+		t.Parallel()
+		other := gitdomain.Worktrees{
+			{Branch: gitdomain.NewLocalBranchName("main"), OpenChanges: false, Path: gitdomain.NewRepoRootDir("/repo")},
+			{Branch: gitdomain.NewLocalBranchName("feature"), OpenChanges: false, Path: gitdomain.NewRepoRootDir("/other")},
+		}
+		have := worktrees.NotIn(other)
+		want := gitdomain.Worktrees{
+			{Branch: gitdomain.NewLocalBranchName("feature"), OpenChanges: true, Path: gitdomain.NewRepoRootDir("/feature")},
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("Other", func(t *testing.T) {
+		t.Parallel()
+		have := worktrees.Other(gitdomain.NewRepoRootDir("/repo"))
+		want := gitdomain.Worktrees{
+			{Branch: gitdomain.NewLocalBranchName("feature"), OpenChanges: true, Path: gitdomain.NewRepoRootDir("/feature")},
+		}
+		must.Eq(t, want, have)
+	})
+}
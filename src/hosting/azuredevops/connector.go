@@ -0,0 +1,341 @@
+package azuredevops
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/git-town/git-town/v14/src/cli/print"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/git/giturl"
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+)
+
+// APIURL is the base URL of the Azure DevOps Services REST API.
+const APIURL = "https://dev.azure.com"
+
+// the version of the Azure DevOps REST API that this connector talks to
+const apiVersion = "7.1"
+
+// the prefix of fully qualified branch names in the Azure DevOps API
+const branchRefPrefix = "refs/heads/"
+
+// Azure DevOps completes pull requests asynchronously,
+// these values define how long to wait for the completion to finish
+const (
+	mergePollInterval = 1 * time.Second
+	mergeTimeout      = 60 * time.Second
+)
+
+// Connector provides access to the API of Azure DevOps.
+// The organization of Azure DevOps repositories has the format "<organization>/<project>".
+type Connector struct {
+	hostingdomain.Config
+	APIToken configdomain.AzureDevOpsToken
+	apiURL   string
+	client   *http.Client
+	log      print.Logger
+}
+
+// NewConnector provides an Azure DevOps connector instance if the current repo is hosted on Azure DevOps,
+// otherwise nil.
+func NewConnector(args NewConnectorArgs) (*Connector, error) {
+	return &Connector{
+		APIToken: args.APIToken,
+		Config: hostingdomain.Config{
+			Hostname:     args.OriginURL.Host,
+			Organization: args.OriginURL.Org,
+			Repository:   args.OriginURL.Repo,
+		},
+		apiURL: args.APIURL,
+		client: &http.Client{},
+		log:    args.Log,
+	}, nil
+}
+
+type NewConnectorArgs struct {
+	APIToken        configdomain.AzureDevOpsToken
+	APIURL          string
+	HostingPlatform configdomain.HostingPlatform
+	Log             print.Logger
+	OriginURL       *giturl.Parts
+}
+
+func (self *Connector) CloseProposal(_ int) error {
+	return fmt.Errorf(messages.HostingCloseProposalUnsupported, "Azure DevOps")
+}
+
+func (self *Connector) CreateProposal(_ hostingdomain.NewProposalData) (hostingdomain.Proposal, error) {
+	return hostingdomain.Proposal{}, fmt.Errorf(messages.HostingCreateProposalUnsupported, "Azure DevOps") //nolint:exhaustruct
+}
+
+func (self *Connector) DefaultProposalMessage(proposal hostingdomain.Proposal) string {
+	return fmt.Sprintf("Merged PR %d: %s", proposal.Number, proposal.Title)
+}
+
+func (self *Connector) FindProposal(branch, target gitdomain.LocalBranchName) (*hostingdomain.Proposal, error) {
+	if self.APIToken == "" {
+		// the Azure DevOps API requires authentication
+		return nil, nil //nolint:nilnil
+	}
+	query := url.Values{}
+	query.Add("searchCriteria.sourceRefName", branchRefPrefix+branch.String())
+	if !target.IsEmpty() {
+		query.Add("searchCriteria.targetRefName", branchRefPrefix+target.String())
+	}
+	query.Add("searchCriteria.status", "active")
+	var response pullRequestsResponse
+	err := self.request(http.MethodGet, self.pullRequestsURL(), query, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Value) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	if len(response.Value) > 1 {
+		return nil, fmt.Errorf(messages.ProposalMultipleFound, len(response.Value), branch, target)
+	}
+	proposal := parsePullRequest(response.Value[0])
+	return &proposal, nil
+}
+
+func (self *Connector) NewProposalURL(branch, parentBranch gitdomain.LocalBranchName) (string, error) {
+	query := url.Values{}
+	query.Add("sourceRef", branch.String())
+	query.Add("targetRef", parentBranch.String())
+	return fmt.Sprintf("%s/pullrequestcreate?%s", self.RepositoryURL(), query.Encode()), nil
+}
+
+func (self *Connector) ProposalURL(number int) string {
+	return fmt.Sprintf("%s/pullrequest/%d", self.RepositoryURL(), number)
+}
+
+func (self *Connector) RepositoryURL() string {
+	hostname := self.HostnameWithStandardPort()
+	if hostname == "ssh.dev.azure.com" {
+		hostname = "dev.azure.com"
+	}
+	return fmt.Sprintf("https://%s/%s/_git/%s", hostname, self.Organization, self.Repository)
+}
+
+func (self *Connector) SquashMergeProposal(number int, message gitdomain.CommitMessage) error {
+	if number <= 0 {
+		return errors.New(messages.ProposalNoNumberGiven)
+	}
+	self.log.Start(messages.HostingAzureDevOpsMergingViaAPI, number)
+	// the Azure DevOps API only completes pull requests when given their latest source commit
+	var pullRequest pullRequest
+	err := self.request(http.MethodGet, self.pullRequestURL(number), nil, nil, &pullRequest)
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	err = self.request(http.MethodPatch, self.pullRequestURL(number), nil, completePullRequest{
+		CompletionOptions: completionOptions{
+			// the branch will be deleted by Git Town
+			DeleteSourceBranch: false,
+			MergeCommitMessage: message.String(),
+			MergeStrategy:      "squash",
+		},
+		LastMergeSourceCommit: pullRequest.LastMergeSourceCommit,
+		Status:                "completed",
+	}, nil)
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	err = self.waitForCompletion(number)
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	self.log.Success()
+	return nil
+}
+
+func (self *Connector) UpdateProposalBody(number int, body string) error {
+	self.log.Start(messages.HostingAzureDevOpsUpdatePRBodyViaAPI, number)
+	err := self.request(http.MethodPatch, self.pullRequestURL(number), nil, updatePullRequestDescription{
+		Description: body,
+	}, nil)
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	self.log.Success()
+	return nil
+}
+
+func (self *Connector) UpdateProposalTarget(number int, target gitdomain.LocalBranchName) error {
+	self.log.Start(messages.HostingAzureDevOpsUpdatePRViaAPI, number, target)
+	err := self.request(http.MethodPatch, self.pullRequestURL(number), nil, updatePullRequest{
+		TargetRefName: branchRefPrefix + target.String(),
+	}, nil)
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	self.log.Success()
+	return nil
+}
+
+func (self *Connector) pullRequestURL(number int) string {
+	return fmt.Sprintf("%s/%d", self.pullRequestsURL(), number)
+}
+
+func (self *Connector) pullRequestsURL() string {
+	return fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests", self.apiURL, self.Organization, self.Repository)
+}
+
+// waitForCompletion polls the given pull request until Azure DevOps has merged it,
+// the merge has failed, or the merge takes too long.
+func (self *Connector) waitForCompletion(number int) error {
+	deadline := time.Now().Add(mergeTimeout)
+	for {
+		var pullRequest pullRequest
+		err := self.request(http.MethodGet, self.pullRequestURL(number), nil, nil, &pullRequest)
+		if err != nil {
+			return err
+		}
+		if pullRequest.Status == "completed" {
+			return nil
+		}
+		if failure, failed := mergeFailure(pullRequest); failed {
+			return fmt.Errorf(messages.HostingAzureDevOpsMergeFailed, number, failure)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf(messages.HostingAzureDevOpsMergeTimeout, number, mergeTimeout)
+		}
+		time.Sleep(mergePollInterval)
+	}
+}
+
+// request sends the given payload to the given endpoint of the Azure DevOps API
+// and unmarshals the response into the given result.
+func (self *Connector) request(method, endpoint string, query url.Values, payload, result any) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Add("api-version", apiVersion)
+	var body io.Reader
+	if payload != nil {
+		payloadJSON, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payloadJSON)
+	}
+	request, err := http.NewRequest(method, endpoint+"?"+query.Encode(), body) //nolint:noctx
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	if payload != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	// Azure DevOps expects personal access tokens as the password of HTTP basic authentication
+	request.SetBasicAuth("", self.APIToken.String())
+	response, err := self.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf(messages.HostingAzureDevOpsAPIProblem, response.StatusCode, parseErrorMessage(responseBody))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(responseBody, result)
+}
+
+type commit struct {
+	CommitID string `json:"commitId"`
+}
+
+type completePullRequest struct {
+	CompletionOptions     completionOptions `json:"completionOptions"`
+	LastMergeSourceCommit commit            `json:"lastMergeSourceCommit"`
+	Status                string            `json:"status"`
+}
+
+type completionOptions struct {
+	DeleteSourceBranch bool   `json:"deleteSourceBranch"`
+	MergeCommitMessage string `json:"mergeCommitMessage"`
+	MergeStrategy      string `json:"mergeStrategy"`
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+type pullRequest struct {
+	Description           string `json:"description"`
+	LastMergeSourceCommit commit `json:"lastMergeSourceCommit"`
+	MergeFailureMessage   string `json:"mergeFailureMessage"`
+	MergeStatus           string `json:"mergeStatus"`
+	PullRequestID         int    `json:"pullRequestId"`
+	Status                string `json:"status"`
+	TargetRefName         string `json:"targetRefName"`
+	Title                 string `json:"title"`
+}
+
+type pullRequestsResponse struct {
+	Value []pullRequest `json:"value"`
+}
+
+type updatePullRequest struct {
+	TargetRefName string `json:"targetRefName"`
+}
+
+type updatePullRequestDescription struct {
+	Description string `json:"description"`
+}
+
+// mergeFailure indicates whether Azure DevOps gave up on completing the given pull request, and why.
+func mergeFailure(pullRequest pullRequest) (string, bool) {
+	if pullRequest.Status == "abandoned" {
+		return "the pull request was abandoned", true
+	}
+	switch pullRequest.MergeStatus {
+	case "conflicts", "failure", "rejectedByPolicy":
+		if pullRequest.MergeFailureMessage != "" {
+			return pullRequest.MergeFailureMessage, true
+		}
+		return pullRequest.MergeStatus, true
+	}
+	return "", false
+}
+
+// parseErrorMessage extracts the error message from the given error response body of the Azure DevOps API.
+func parseErrorMessage(responseBody []byte) string {
+	var response errorResponse
+	err := json.Unmarshal(responseBody, &response)
+	if err != nil || response.Message == "" {
+		return string(responseBody)
+	}
+	return response.Message
+}
+
+// parsePullRequest extracts standardized proposal data from the given Azure DevOps pull request.
+func parsePullRequest(pullRequest pullRequest) hostingdomain.Proposal {
+	return hostingdomain.Proposal{
+		Body:         pullRequest.Description,
+		MergeWithAPI: true,
+		Number:       pullRequest.PullRequestID,
+		Target:       gitdomain.NewLocalBranchName(strings.TrimPrefix(pullRequest.TargetRefName, branchRefPrefix)),
+		Title:        pullRequest.Title,
+	}
+}
@@ -0,0 +1,375 @@
+package azuredevops_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/cli/print"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/git/giturl"
+	"github.com/git-town/git-town/v14/src/hosting/azuredevops"
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+	"github.com/shoenig/test/must"
+)
+
+func TestAzureDevOpsConnector(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NewConnector", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("HTTPS origin", func(t *testing.T) {
+			t.Parallel()
+			connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+				APIToken:        "",
+				APIURL:          azuredevops.APIURL,
+				HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("https://git-town@dev.azure.com/git-town/docs/_git/git-town"),
+			})
+			must.NoError(t, err)
+			wantConfig := hostingdomain.Config{
+				Hostname:     "dev.azure.com",
+				Organization: "git-town/docs",
+				Repository:   "git-town",
+			}
+			must.EqOp(t, wantConfig, connector.Config)
+		})
+
+		t.Run("SSH origin", func(t *testing.T) {
+			t.Parallel()
+			connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+				APIToken:        "",
+				APIURL:          azuredevops.APIURL,
+				HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/git-town/docs/git-town"),
+			})
+			must.NoError(t, err)
+			wantConfig := hostingdomain.Config{
+				Hostname:     "ssh.dev.azure.com",
+				Organization: "git-town/docs",
+				Repository:   "git-town",
+			}
+			must.EqOp(t, wantConfig, connector.Config)
+		})
+	})
+
+	t.Run("DefaultProposalMessage", func(t *testing.T) {
+		t.Parallel()
+		connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+			APIToken:        "",
+			APIURL:          azuredevops.APIURL,
+			HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+			Log:             print.Logger{},
+			OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/org/project/repo"),
+		})
+		must.NoError(t, err)
+		have := connector.DefaultProposalMessage(hostingdomain.Proposal{ //nolint:exhaustruct
+			Number: 12,
+			Title:  "my title",
+		})
+		must.EqOp(t, "Merged PR 12: my title", have)
+	})
+
+	t.Run("NewProposalURL", func(t *testing.T) {
+		t.Parallel()
+		connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+			APIToken:        "",
+			APIURL:          azuredevops.APIURL,
+			HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+			Log:             print.Logger{},
+			OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/org/project/repo"),
+		})
+		must.NoError(t, err)
+		have, err := connector.NewProposalURL("feature", gitdomain.NewLocalBranchName("parent"))
+		must.NoError(t, err)
+		must.EqOp(t, "https://dev.azure.com/org/project/_git/repo/pullrequestcreate?sourceRef=feature&targetRef=parent", have)
+	})
+
+	t.Run("ProposalURL", func(t *testing.T) {
+		t.Parallel()
+		connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+			APIToken:        "",
+			APIURL:          azuredevops.APIURL,
+			HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+			Log:             print.Logger{},
+			OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/org/project/repo"),
+		})
+		must.NoError(t, err)
+		must.EqOp(t, "https://dev.azure.com/org/project/_git/repo/pullrequest/12", connector.ProposalURL(12))
+	})
+
+	t.Run("RepositoryURL", func(t *testing.T) {
+		t.Parallel()
+		connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+			APIToken:        "",
+			APIURL:          azuredevops.APIURL,
+			HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+			Log:             print.Logger{},
+			OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/org/project/repo"),
+		})
+		must.NoError(t, err)
+		must.EqOp(t, "https://dev.azure.com/org/project/_git/repo", connector.RepositoryURL())
+	})
+
+	t.Run("CloseProposal", func(t *testing.T) {
+		t.Parallel()
+		connector := azuredevops.Connector{} //nolint:exhaustruct
+		err := connector.CloseProposal(12)
+		must.EqError(t, err, "Azure DevOps does not support closing proposals via the API")
+	})
+
+	t.Run("FindProposal", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("proposal exists", func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				must.EqOp(t, http.MethodGet, request.Method)
+				must.EqOp(t, "/org/project/_apis/git/repositories/repo/pullrequests", request.URL.Path)
+				query := request.URL.Query()
+				must.EqOp(t, "refs/heads/feature", query.Get("searchCriteria.sourceRefName"))
+				must.EqOp(t, "refs/heads/main", query.Get("searchCriteria.targetRefName"))
+				must.EqOp(t, "active", query.Get("searchCriteria.status"))
+				must.EqOp(t, "7.1", query.Get("api-version"))
+				_, password, ok := request.BasicAuth()
+				must.True(t, ok)
+				must.EqOp(t, "secret", password)
+				fmt.Fprint(writer, `{"count": 1, "value": [{"pullRequestId": 12, "title": "my title", "description": "my body", "targetRefName": "refs/heads/main"}]}`)
+			}))
+			defer server.Close()
+			connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/org/project/repo"),
+			})
+			must.NoError(t, err)
+			have, err := connector.FindProposal("feature", "main")
+			must.NoError(t, err)
+			want := &hostingdomain.Proposal{
+				Body:         "my body",
+				MergeWithAPI: true,
+				Number:       12,
+				Target:       "main",
+				Title:        "my title",
+			}
+			must.Eq(t, want, have)
+		})
+
+		t.Run("no proposal exists", func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(writer, `{"count": 0, "value": []}`)
+			}))
+			defer server.Close()
+			connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/org/project/repo"),
+			})
+			must.NoError(t, err)
+			have, err := connector.FindProposal("feature", "main")
+			must.NoError(t, err)
+			must.Nil(t, have)
+		})
+
+		t.Run("no API token", func(t *testing.T) {
+			t.Parallel()
+			connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+				APIToken:        "",
+				APIURL:          "http://localhost:1",
+				HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/org/project/repo"),
+			})
+			must.NoError(t, err)
+			have, err := connector.FindProposal("feature", "main")
+			must.NoError(t, err)
+			must.Nil(t, have)
+		})
+
+		t.Run("API error", func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+				writer.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(writer, `{"message": "TF401019: The Git repository does not exist."}`)
+			}))
+			defer server.Close()
+			connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/org/project/repo"),
+			})
+			must.NoError(t, err)
+			_, err = connector.FindProposal("feature", "main")
+			must.EqError(t, err, "Azure DevOps API responded with status 404: TF401019: The Git repository does not exist.")
+		})
+	})
+
+	t.Run("SquashMergeProposal", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("completes the proposal", func(t *testing.T) {
+			t.Parallel()
+			completed := false
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				must.EqOp(t, "/org/project/_apis/git/repositories/repo/pullrequests/12", request.URL.Path)
+				switch request.Method {
+				case http.MethodGet:
+					if completed {
+						fmt.Fprint(writer, `{"pullRequestId": 12, "status": "completed"}`)
+					} else {
+						fmt.Fprint(writer, `{"pullRequestId": 12, "title": "my title", "status": "active", "lastMergeSourceCommit": {"commitId": "abc123"}}`)
+					}
+				case http.MethodPatch:
+					body, err := io.ReadAll(request.Body)
+					must.NoError(t, err)
+					must.EqOp(t, `{"completionOptions":{"deleteSourceBranch":false,"mergeCommitMessage":"title\n\nbody","mergeStrategy":"squash"},"lastMergeSourceCommit":{"commitId":"abc123"},"status":"completed"}`, string(body))
+					completed = true
+					fmt.Fprint(writer, `{"pullRequestId": 12, "status": "active"}`)
+				default:
+					t.Fatalf("unexpected request method: %s", request.Method)
+				}
+			}))
+			defer server.Close()
+			connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/org/project/repo"),
+			})
+			must.NoError(t, err)
+			err = connector.SquashMergeProposal(12, "title\n\nbody")
+			must.NoError(t, err)
+			must.True(t, completed)
+		})
+
+		t.Run("waits until Azure DevOps has completed the proposal", func(t *testing.T) {
+			t.Parallel()
+			polls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				switch request.Method {
+				case http.MethodGet:
+					polls++
+					if polls > 2 {
+						fmt.Fprint(writer, `{"pullRequestId": 12, "status": "completed"}`)
+					} else {
+						fmt.Fprint(writer, `{"pullRequestId": 12, "status": "active", "mergeStatus": "queued"}`)
+					}
+				case http.MethodPatch:
+					fmt.Fprint(writer, `{"pullRequestId": 12, "status": "active"}`)
+				default:
+					t.Fatalf("unexpected request method: %s", request.Method)
+				}
+			}))
+			defer server.Close()
+			connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/org/project/repo"),
+			})
+			must.NoError(t, err)
+			err = connector.SquashMergeProposal(12, "title")
+			must.NoError(t, err)
+			must.EqOp(t, 3, polls)
+		})
+
+		t.Run("merge fails", func(t *testing.T) {
+			t.Parallel()
+			patched := false
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				switch request.Method {
+				case http.MethodGet:
+					if patched {
+						fmt.Fprint(writer, `{"pullRequestId": 12, "status": "active", "mergeStatus": "conflicts", "mergeFailureMessage": "merge conflicts in file.txt"}`)
+					} else {
+						fmt.Fprint(writer, `{"pullRequestId": 12, "status": "active"}`)
+					}
+				case http.MethodPatch:
+					patched = true
+					fmt.Fprint(writer, `{"pullRequestId": 12, "status": "active"}`)
+				default:
+					t.Fatalf("unexpected request method: %s", request.Method)
+				}
+			}))
+			defer server.Close()
+			connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/org/project/repo"),
+			})
+			must.NoError(t, err)
+			err = connector.SquashMergeProposal(12, "title")
+			must.EqError(t, err, "Azure DevOps could not complete PR #12: merge conflicts in file.txt")
+		})
+
+		t.Run("no proposal number", func(t *testing.T) {
+			t.Parallel()
+			connector := azuredevops.Connector{} //nolint:exhaustruct
+			err := connector.SquashMergeProposal(0, "title")
+			must.EqError(t, err, "no proposal number given")
+		})
+	})
+
+	t.Run("UpdateProposalBody", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			must.EqOp(t, http.MethodPatch, request.Method)
+			must.EqOp(t, "/org/project/_apis/git/repositories/repo/pullrequests/12", request.URL.Path)
+			body, err := io.ReadAll(request.Body)
+			must.NoError(t, err)
+			must.EqOp(t, `{"description":"new body"}`, string(body))
+			fmt.Fprint(writer, `{"pullRequestId": 12}`)
+		}))
+		defer server.Close()
+		connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+			APIToken:        "secret",
+			APIURL:          server.URL,
+			HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+			Log:             print.Logger{},
+			OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/org/project/repo"),
+		})
+		must.NoError(t, err)
+		err = connector.UpdateProposalBody(12, "new body")
+		must.NoError(t, err)
+	})
+
+	t.Run("UpdateProposalTarget", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			must.EqOp(t, http.MethodPatch, request.Method)
+			must.EqOp(t, "/org/project/_apis/git/repositories/repo/pullrequests/12", request.URL.Path)
+			body, err := io.ReadAll(request.Body)
+			must.NoError(t, err)
+			must.EqOp(t, `{"targetRefName":"refs/heads/new"}`, string(body))
+			fmt.Fprint(writer, `{"pullRequestId": 12}`)
+		}))
+		defer server.Close()
+		connector, err := azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+			APIToken:        "secret",
+			APIURL:          server.URL,
+			HostingPlatform: configdomain.HostingPlatformAzureDevOps,
+			Log:             print.Logger{},
+			OriginURL:       giturl.Parse("git@ssh.dev.azure.com:v3/org/project/repo"),
+		})
+		must.NoError(t, err)
+		err = connector.UpdateProposalTarget(12, "new")
+		must.NoError(t, err)
+	})
+}
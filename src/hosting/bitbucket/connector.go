@@ -1,10 +1,15 @@
 package bitbucket
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 
+	"github.com/git-town/git-town/v14/src/cli/print"
 	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
 	"github.com/git-town/git-town/v14/src/git/giturl"
@@ -12,34 +17,78 @@ import (
 	"github.com/git-town/git-town/v14/src/messages"
 )
 
+// APIURL is the base URL of the Bitbucket Cloud REST API.
+const APIURL = "https://api.bitbucket.org/2.0"
+
 // Connector provides access to the API of Bitbucket installations.
 type Connector struct {
 	hostingdomain.Config
+	APIToken configdomain.BitbucketToken
+	apiURL   string
+	client   *http.Client
+	log      print.Logger
 }
 
 // NewConnector provides a Bitbucket connector instance if the current repo is hosted on Bitbucket,
 // otherwise nil.
 func NewConnector(args NewConnectorArgs) (*Connector, error) {
 	return &Connector{
+		APIToken: args.APIToken,
 		Config: hostingdomain.Config{
 			Hostname:     args.OriginURL.Host,
 			Organization: args.OriginURL.Org,
 			Repository:   args.OriginURL.Repo,
 		},
+		apiURL: args.APIURL,
+		client: &http.Client{},
+		log:    args.Log,
 	}, nil
 }
 
 type NewConnectorArgs struct {
+	APIToken        configdomain.BitbucketToken
+	APIURL          string
 	HostingPlatform configdomain.HostingPlatform
+	Log             print.Logger
 	OriginURL       *giturl.Parts
 }
 
+func (self *Connector) CloseProposal(_ int) error {
+	return fmt.Errorf(messages.HostingCloseProposalUnsupported, "Bitbucket")
+}
+
+func (self *Connector) CreateProposal(_ hostingdomain.NewProposalData) (hostingdomain.Proposal, error) {
+	return hostingdomain.Proposal{}, fmt.Errorf(messages.HostingCreateProposalUnsupported, "Bitbucket") //nolint:exhaustruct
+}
+
 func (self *Connector) DefaultProposalMessage(proposal hostingdomain.Proposal) string {
 	return fmt.Sprintf("%s (#%d)", proposal.Title, proposal.Number)
 }
 
-func (self *Connector) FindProposal(_, _ gitdomain.LocalBranchName) (*hostingdomain.Proposal, error) {
-	return nil, errors.New(messages.HostingBitBucketNotImplemented)
+func (self *Connector) FindProposal(branch, target gitdomain.LocalBranchName) (*hostingdomain.Proposal, error) {
+	if self.APIToken == "" {
+		// the Bitbucket API requires authentication
+		return nil, nil //nolint:nilnil
+	}
+	query := url.Values{}
+	if target.IsEmpty() {
+		query.Add("q", fmt.Sprintf(`source.branch.name = %q AND state = "OPEN"`, branch))
+	} else {
+		query.Add("q", fmt.Sprintf(`source.branch.name = %q AND destination.branch.name = %q AND state = "OPEN"`, branch, target))
+	}
+	var response pullRequestsResponse
+	err := self.request(http.MethodGet, self.pullRequestsURL()+"?"+query.Encode(), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Values) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	if len(response.Values) > 1 {
+		return nil, fmt.Errorf(messages.ProposalMultipleFound, len(response.Values), branch, target)
+	}
+	proposal := parsePullRequest(response.Values[0])
+	return &proposal, nil
 }
 
 func (self *Connector) NewProposalURL(branch, parentBranch gitdomain.LocalBranchName) (string, error) {
@@ -52,14 +101,190 @@ func (self *Connector) NewProposalURL(branch, parentBranch gitdomain.LocalBranch
 		nil
 }
 
+func (self *Connector) ProposalURL(number int) string {
+	return fmt.Sprintf("%s/pull-requests/%d", self.RepositoryURL(), number)
+}
+
 func (self *Connector) RepositoryURL() string {
 	return fmt.Sprintf("https://%s/%s/%s", self.HostnameWithStandardPort(), self.Organization, self.Repository)
 }
 
-func (self *Connector) SquashMergeProposal(_ int, _ gitdomain.CommitMessage) error {
-	return errors.New(messages.HostingBitBucketNotImplemented)
+func (self *Connector) SquashMergeProposal(number int, message gitdomain.CommitMessage) error {
+	if number <= 0 {
+		return errors.New(messages.ProposalNoNumberGiven)
+	}
+	self.log.Start(messages.HostingBitbucketMergingViaAPI, number)
+	err := self.request(http.MethodPost, self.pullRequestURL(number)+"/merge", mergeRequest{
+		// the branch will be deleted by Git Town
+		CloseSourceBranch: false,
+		MergeStrategy:     "squash",
+		Message:           message.String(),
+	}, nil)
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	self.log.Success()
+	return nil
+}
+
+func (self *Connector) UpdateProposalBody(number int, body string) error {
+	self.log.Start(messages.HostingBitbucketUpdatePRBodyViaAPI, number)
+	// the Bitbucket API requires the title when updating a pull request
+	var pullRequest pullRequest
+	err := self.request(http.MethodGet, self.pullRequestURL(number), nil, &pullRequest)
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	err = self.request(http.MethodPut, self.pullRequestURL(number), updatePullRequestDescription{
+		Description: body,
+		Title:       pullRequest.Title,
+	}, nil)
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	self.log.Success()
+	return nil
+}
+
+func (self *Connector) UpdateProposalTarget(number int, target gitdomain.LocalBranchName) error {
+	self.log.Start(messages.HostingBitbucketUpdatePRViaAPI, number, target)
+	// the Bitbucket API requires the title when updating a pull request
+	var pullRequest pullRequest
+	err := self.request(http.MethodGet, self.pullRequestURL(number), nil, &pullRequest)
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	err = self.request(http.MethodPut, self.pullRequestURL(number), updatePullRequest{
+		Destination: pullRequestEndpoint{
+			Branch: pullRequestBranch{Name: target.String()},
+		},
+		Title: pullRequest.Title,
+	}, nil)
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	self.log.Success()
+	return nil
+}
+
+// authorize adds the credentials of this connector to the given request.
+func (self *Connector) authorize(request *http.Request) {
+	if username, password, isAppPassword := self.APIToken.AppPassword(); isAppPassword {
+		request.SetBasicAuth(username, password)
+		return
+	}
+	request.Header.Set("Authorization", "Bearer "+self.APIToken.String())
+}
+
+func (self *Connector) pullRequestURL(number int) string {
+	return fmt.Sprintf("%s/%d", self.pullRequestsURL(), number)
+}
+
+func (self *Connector) pullRequestsURL() string {
+	return fmt.Sprintf("%s/repositories/%s/%s/pullrequests", self.apiURL, url.PathEscape(self.Organization), url.PathEscape(self.Repository))
+}
+
+// request sends the given payload to the given endpoint of the Bitbucket API
+// and unmarshals the response into the given result.
+func (self *Connector) request(method, endpoint string, payload, result any) error {
+	var body io.Reader
+	if payload != nil {
+		payloadJSON, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payloadJSON)
+	}
+	request, err := http.NewRequest(method, endpoint, body) //nolint:noctx
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	if payload != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	self.authorize(request)
+	response, err := self.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf(messages.HostingBitbucketAPIProblem, response.StatusCode, parseErrorMessage(responseBody))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(responseBody, result)
+}
+
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type mergeRequest struct {
+	CloseSourceBranch bool   `json:"close_source_branch"`
+	MergeStrategy     string `json:"merge_strategy"`
+	Message           string `json:"message"`
+}
+
+type pullRequest struct {
+	Description string              `json:"description"`
+	Destination pullRequestEndpoint `json:"destination"`
+	ID          int                 `json:"id"`
+	Title       string              `json:"title"`
+}
+
+type pullRequestBranch struct {
+	Name string `json:"name"`
+}
+
+type pullRequestEndpoint struct {
+	Branch pullRequestBranch `json:"branch"`
+}
+
+type pullRequestsResponse struct {
+	Values []pullRequest `json:"values"`
+}
+
+type updatePullRequest struct {
+	Destination pullRequestEndpoint `json:"destination"`
+	Title       string              `json:"title"`
+}
+
+type updatePullRequestDescription struct {
+	Description string `json:"description"`
+	Title       string `json:"title"`
+}
+
+// parseErrorMessage extracts the error message from the given error response body of the Bitbucket API.
+func parseErrorMessage(responseBody []byte) string {
+	var response errorResponse
+	err := json.Unmarshal(responseBody, &response)
+	if err != nil || response.Error.Message == "" {
+		return string(responseBody)
+	}
+	return response.Error.Message
 }
 
-func (self *Connector) UpdateProposalTarget(_ int, _ gitdomain.LocalBranchName) error {
-	return errors.New(messages.HostingBitBucketNotImplemented)
+// parsePullRequest extracts standardized proposal data from the given Bitbucket pull request.
+func parsePullRequest(pullRequest pullRequest) hostingdomain.Proposal {
+	return hostingdomain.Proposal{
+		Body:         pullRequest.Description,
+		MergeWithAPI: true,
+		Number:       pullRequest.ID,
+		Target:       gitdomain.NewLocalBranchName(pullRequest.Destination.Branch.Name),
+		Title:        pullRequest.Title,
+	}
 }
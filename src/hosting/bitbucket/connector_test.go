@@ -1,8 +1,13 @@
 package bitbucket_test
 
 import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/git-town/git-town/v14/src/cli/print"
 	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
 	"github.com/git-town/git-town/v14/src/git/giturl"
@@ -20,7 +25,10 @@ func TestBitbucketConnector(t *testing.T) {
 		t.Run("Bitbucket SaaS", func(t *testing.T) {
 			t.Parallel()
 			have, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+				APIToken:        "",
+				APIURL:          bitbucket.APIURL,
 				HostingPlatform: configdomain.HostingPlatformNone,
+				Log:             print.Logger{},
 				OriginURL:       giturl.Parse("username@bitbucket.org:git-town/docs.git"),
 			})
 			must.NoError(t, err)
@@ -35,7 +43,10 @@ func TestBitbucketConnector(t *testing.T) {
 		t.Run("hosted service type provided manually", func(t *testing.T) {
 			t.Parallel()
 			have, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+				APIToken:        "",
+				APIURL:          bitbucket.APIURL,
 				HostingPlatform: configdomain.HostingPlatformBitbucket,
+				Log:             print.Logger{},
 				OriginURL:       giturl.Parse("git@custom-url.com:git-town/docs.git"),
 			})
 			must.NoError(t, err)
@@ -51,7 +62,10 @@ func TestBitbucketConnector(t *testing.T) {
 	t.Run("NewProposalURL", func(t *testing.T) {
 		t.Parallel()
 		connector, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+			APIToken:        "",
+			APIURL:          bitbucket.APIURL,
 			HostingPlatform: configdomain.HostingPlatformNone,
+			Log:             print.Logger{},
 			OriginURL:       giturl.Parse("username@bitbucket.org:org/repo.git"),
 		})
 		must.NoError(t, err)
@@ -60,4 +74,256 @@ func TestBitbucketConnector(t *testing.T) {
 		want := "https://bitbucket.org/org/repo/pull-requests/new?source=branch&dest=org%2Frepo%3Aparent-branch"
 		must.EqOp(t, want, have)
 	})
+
+	t.Run("ProposalURL", func(t *testing.T) {
+		t.Parallel()
+		connector, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+			APIToken:        "",
+			APIURL:          bitbucket.APIURL,
+			HostingPlatform: configdomain.HostingPlatformNone,
+			Log:             print.Logger{},
+			OriginURL:       giturl.Parse("username@bitbucket.org:org/repo.git"),
+		})
+		must.NoError(t, err)
+		must.EqOp(t, "https://bitbucket.org/org/repo/pull-requests/12", connector.ProposalURL(12))
+	})
+
+	t.Run("CloseProposal", func(t *testing.T) {
+		t.Parallel()
+		connector := bitbucket.Connector{} //nolint:exhaustruct
+		err := connector.CloseProposal(12)
+		must.EqError(t, err, "Bitbucket does not support closing proposals via the API")
+	})
+
+	t.Run("FindProposal", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("proposal exists", func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				must.EqOp(t, http.MethodGet, request.Method)
+				must.EqOp(t, "/repositories/org/repo/pullrequests", request.URL.Path)
+				must.EqOp(t, `source.branch.name = "feature" AND destination.branch.name = "main" AND state = "OPEN"`, request.URL.Query().Get("q"))
+				must.EqOp(t, "Bearer secret", request.Header.Get("Authorization"))
+				fmt.Fprint(writer, `{"values": [{"id": 12, "title": "my title", "description": "my body", "destination": {"branch": {"name": "main"}}}]}`)
+			}))
+			defer server.Close()
+			connector, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformBitbucket,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@bitbucket.org:org/repo.git"),
+			})
+			must.NoError(t, err)
+			have, err := connector.FindProposal("feature", "main")
+			must.NoError(t, err)
+			want := &hostingdomain.Proposal{
+				Body:         "my body",
+				MergeWithAPI: true,
+				Number:       12,
+				Target:       "main",
+				Title:        "my title",
+			}
+			must.Eq(t, want, have)
+		})
+
+		t.Run("no proposal exists", func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(writer, `{"values": []}`)
+			}))
+			defer server.Close()
+			connector, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformBitbucket,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@bitbucket.org:org/repo.git"),
+			})
+			must.NoError(t, err)
+			have, err := connector.FindProposal("feature", "main")
+			must.NoError(t, err)
+			must.Nil(t, have)
+		})
+
+		t.Run("multiple proposals exist", func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(writer, `{"values": [{"id": 1}, {"id": 2}]}`)
+			}))
+			defer server.Close()
+			connector, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformBitbucket,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@bitbucket.org:org/repo.git"),
+			})
+			must.NoError(t, err)
+			_, err = connector.FindProposal("feature", "main")
+			must.ErrorContains(t, err, "found 2 proposals")
+		})
+
+		t.Run("app password", func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				username, password, ok := request.BasicAuth()
+				must.True(t, ok)
+				must.EqOp(t, "user", username)
+				must.EqOp(t, "app-password", password)
+				fmt.Fprint(writer, `{"values": []}`)
+			}))
+			defer server.Close()
+			connector, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+				APIToken:        "user:app-password",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformBitbucket,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@bitbucket.org:org/repo.git"),
+			})
+			must.NoError(t, err)
+			_, err = connector.FindProposal("feature", "main")
+			must.NoError(t, err)
+		})
+
+		t.Run("no API token", func(t *testing.T) {
+			t.Parallel()
+			connector, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+				APIToken:        "",
+				APIURL:          "http://localhost:1",
+				HostingPlatform: configdomain.HostingPlatformBitbucket,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@bitbucket.org:org/repo.git"),
+			})
+			must.NoError(t, err)
+			have, err := connector.FindProposal("feature", "main")
+			must.NoError(t, err)
+			must.Nil(t, have)
+		})
+
+		t.Run("API error", func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+				writer.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(writer, `{"type": "error", "error": {"message": "Access token expired."}}`)
+			}))
+			defer server.Close()
+			connector, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformBitbucket,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@bitbucket.org:org/repo.git"),
+			})
+			must.NoError(t, err)
+			_, err = connector.FindProposal("feature", "main")
+			must.EqError(t, err, "Bitbucket API responded with status 401: Access token expired.")
+		})
+	})
+
+	t.Run("SquashMergeProposal", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("merges the proposal", func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				must.EqOp(t, http.MethodPost, request.Method)
+				must.EqOp(t, "/repositories/org/repo/pullrequests/12/merge", request.URL.Path)
+				body, err := io.ReadAll(request.Body)
+				must.NoError(t, err)
+				must.EqOp(t, `{"close_source_branch":false,"merge_strategy":"squash","message":"title\n\nbody"}`, string(body))
+				fmt.Fprint(writer, `{"id": 12}`)
+			}))
+			defer server.Close()
+			connector, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformBitbucket,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@bitbucket.org:org/repo.git"),
+			})
+			must.NoError(t, err)
+			err = connector.SquashMergeProposal(12, "title\n\nbody")
+			must.NoError(t, err)
+		})
+
+		t.Run("no proposal number", func(t *testing.T) {
+			t.Parallel()
+			connector, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          "http://localhost:1",
+				HostingPlatform: configdomain.HostingPlatformBitbucket,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@bitbucket.org:org/repo.git"),
+			})
+			must.NoError(t, err)
+			err = connector.SquashMergeProposal(0, "title")
+			must.EqError(t, err, "no proposal number given")
+		})
+	})
+
+	t.Run("UpdateProposalBody", func(t *testing.T) {
+		t.Parallel()
+		updated := false
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			must.EqOp(t, "/repositories/org/repo/pullrequests/12", request.URL.Path)
+			switch request.Method {
+			case http.MethodGet:
+				fmt.Fprint(writer, `{"id": 12, "title": "my title", "description": "old body"}`)
+			case http.MethodPut:
+				body, err := io.ReadAll(request.Body)
+				must.NoError(t, err)
+				must.EqOp(t, `{"description":"new body","title":"my title"}`, string(body))
+				updated = true
+				fmt.Fprint(writer, `{"id": 12}`)
+			default:
+				t.Fatalf("unexpected request method: %s", request.Method)
+			}
+		}))
+		defer server.Close()
+		connector, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+			APIToken:        "secret",
+			APIURL:          server.URL,
+			HostingPlatform: configdomain.HostingPlatformBitbucket,
+			Log:             print.Logger{},
+			OriginURL:       giturl.Parse("git@bitbucket.org:org/repo.git"),
+		})
+		must.NoError(t, err)
+		err = connector.UpdateProposalBody(12, "new body")
+		must.NoError(t, err)
+		must.True(t, updated)
+	})
+
+	t.Run("UpdateProposalTarget", func(t *testing.T) {
+		t.Parallel()
+		updated := false
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			must.EqOp(t, "/repositories/org/repo/pullrequests/12", request.URL.Path)
+			switch request.Method {
+			case http.MethodGet:
+				fmt.Fprint(writer, `{"id": 12, "title": "my title", "destination": {"branch": {"name": "old"}}}`)
+			case http.MethodPut:
+				body, err := io.ReadAll(request.Body)
+				must.NoError(t, err)
+				must.EqOp(t, `{"destination":{"branch":{"name":"new"}},"title":"my title"}`, string(body))
+				updated = true
+				fmt.Fprint(writer, `{"id": 12}`)
+			default:
+				t.Fatalf("unexpected request method: %s", request.Method)
+			}
+		}))
+		defer server.Close()
+		connector, err := bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+			APIToken:        "secret",
+			APIURL:          server.URL,
+			HostingPlatform: configdomain.HostingPlatformBitbucket,
+			Log:             print.Logger{},
+			OriginURL:       giturl.Parse("git@bitbucket.org:org/repo.git"),
+		})
+		must.NoError(t, err)
+		err = connector.UpdateProposalTarget(12, "new")
+		must.NoError(t, err)
+		must.True(t, updated)
+	})
 }
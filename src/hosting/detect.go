@@ -3,6 +3,7 @@ package hosting
 import (
 	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/git/giturl"
+	"github.com/git-town/git-town/v14/src/hosting/azuredevops"
 	"github.com/git-town/git-town/v14/src/hosting/bitbucket"
 	"github.com/git-town/git-town/v14/src/hosting/gitea"
 	"github.com/git-town/git-town/v14/src/hosting/github"
@@ -11,6 +12,8 @@ import (
 
 func Detect(originURL *giturl.Parts, hostingPlatform configdomain.HostingPlatform) configdomain.HostingPlatform {
 	switch {
+	case azuredevops.Detect(originURL, hostingPlatform):
+		return configdomain.HostingPlatformAzureDevOps
 	case bitbucket.Detect(originURL, hostingPlatform):
 		return configdomain.HostingPlatformBitbucket
 	case gitea.Detect(originURL, hostingPlatform):
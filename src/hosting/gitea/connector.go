@@ -16,6 +16,9 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// the title prefix that makes Gitea treat a pull request as work in progress
+const draftTitlePrefix = "WIP: "
+
 type Connector struct {
 	hostingdomain.Config
 	APIToken configdomain.GiteaToken
@@ -23,6 +26,53 @@ type Connector struct {
 	log      print.Logger
 }
 
+func (self *Connector) CloseProposal(number int) error {
+	if number <= 0 {
+		return errors.New(messages.ProposalNoNumberGiven)
+	}
+	self.log.Start(messages.HostingGiteaClosePRViaAPI, number)
+	// the Gitea API overwrites the title and body of the pull request with the given values
+	pullRequest, _, err := self.client.GetPullRequest(self.Organization, self.Repository, int64(number))
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	closed := gitea.StateClosed
+	_, _, err = self.client.EditPullRequest(self.Organization, self.Repository, int64(number), gitea.EditPullRequestOption{
+		Base:  pullRequest.Base.Ref,
+		Body:  pullRequest.Body,
+		State: &closed,
+		Title: pullRequest.Title,
+	})
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	self.log.Success()
+	return nil
+}
+
+func (self *Connector) CreateProposal(data hostingdomain.NewProposalData) (hostingdomain.Proposal, error) {
+	self.log.Start(messages.HostingGiteaCreatePRViaAPI, data.Branch, data.Target)
+	title := data.Title
+	if data.Draft {
+		// Gitea marks pull requests whose title starts with this prefix as work in progress
+		title = draftTitlePrefix + title
+	}
+	pullRequest, _, err := self.client.CreatePullRequest(self.Organization, self.Repository, gitea.CreatePullRequestOption{
+		Base:  data.Target.String(),
+		Body:  data.Body,
+		Head:  data.Branch.String(),
+		Title: title,
+	})
+	if err != nil {
+		self.log.Failed(err)
+		return hostingdomain.Proposal{}, err //nolint:exhaustruct
+	}
+	self.log.Success()
+	return parsePullRequest(pullRequest), nil
+}
+
 func (self *Connector) DefaultProposalMessage(proposal hostingdomain.Proposal) string {
 	return fmt.Sprintf("%s (#%d)", proposal.Title, proposal.Number)
 }
@@ -44,13 +94,8 @@ func (self *Connector) FindProposal(branch, target gitdomain.LocalBranchName) (*
 	if len(pullRequests) > 1 {
 		return nil, fmt.Errorf(messages.ProposalMultipleFound, len(pullRequests), branch, target)
 	}
-	pullRequest := pullRequests[0]
-	return &hostingdomain.Proposal{
-		MergeWithAPI: pullRequest.Mergeable,
-		Number:       int(pullRequest.Index),
-		Target:       gitdomain.NewLocalBranchName(pullRequest.Base.Ref),
-		Title:        pullRequest.Title,
-	}, nil
+	proposal := parsePullRequest(pullRequests[0])
+	return &proposal, nil
 }
 
 func (self *Connector) NewProposalURL(branch, parentBranch gitdomain.LocalBranchName) (string, error) {
@@ -58,6 +103,10 @@ func (self *Connector) NewProposalURL(branch, parentBranch gitdomain.LocalBranch
 	return fmt.Sprintf("%s/compare/%s", self.RepositoryURL(), url.PathEscape(toCompare)), nil
 }
 
+func (self *Connector) ProposalURL(number int) string {
+	return fmt.Sprintf("%s/pulls/%d", self.RepositoryURL(), number)
+}
+
 func (self *Connector) RepositoryURL() string {
 	return fmt.Sprintf("https://%s/%s/%s", self.HostnameWithStandardPort(), self.Organization, self.Repository)
 }
@@ -79,16 +128,52 @@ func (self *Connector) SquashMergeProposal(number int, message gitdomain.CommitM
 	return err
 }
 
-func (self *Connector) UpdateProposalTarget(_ int, _ gitdomain.LocalBranchName) error {
-	// TODO: update the client and uncomment
-	// if self.log != nil {
-	// 	self.log(message.HostingGiteaUpdateBasebranchViaAPI, number, target)
-	// }
-	// _, err := self.client.EditPullRequest(self.owner, self.repository, int64(number), gitea.EditPullRequestOption{
-	// 	Base: newBaseName,
-	// })
-	// return err
-	return errors.New(messages.HostingGiteaNotImplemented)
+func (self *Connector) UpdateProposalBody(number int, body string) error {
+	if number <= 0 {
+		return errors.New(messages.ProposalNoNumberGiven)
+	}
+	self.log.Start(messages.HostingGiteaUpdatePRBodyViaAPI, number)
+	// the Gitea API overwrites the title and base branch of the pull request with the given values
+	pullRequest, _, err := self.client.GetPullRequest(self.Organization, self.Repository, int64(number))
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	_, _, err = self.client.EditPullRequest(self.Organization, self.Repository, int64(number), gitea.EditPullRequestOption{
+		Base:  pullRequest.Base.Ref,
+		Body:  body,
+		Title: pullRequest.Title,
+	})
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	self.log.Success()
+	return nil
+}
+
+func (self *Connector) UpdateProposalTarget(number int, target gitdomain.LocalBranchName) error {
+	if number <= 0 {
+		return errors.New(messages.ProposalNoNumberGiven)
+	}
+	self.log.Start(messages.HostingGiteaUpdatePRViaAPI, number, target)
+	// the Gitea API overwrites the title and body of the pull request with the given values
+	pullRequest, _, err := self.client.GetPullRequest(self.Organization, self.Repository, int64(number))
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	_, _, err = self.client.EditPullRequest(self.Organization, self.Repository, int64(number), gitea.EditPullRequestOption{
+		Base:  target.String(),
+		Body:  pullRequest.Body,
+		Title: pullRequest.Title,
+	})
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	self.log.Success()
+	return nil
 }
 
 func FilterPullRequests(pullRequests []*gitea.PullRequest, organization string, branch, target gitdomain.LocalBranchName) []*gitea.PullRequest {
@@ -96,19 +181,24 @@ func FilterPullRequests(pullRequests []*gitea.PullRequest, organization string,
 	headName := organization + "/" + branch.String()
 	for p := range pullRequests {
 		pullRequest := pullRequests[p]
-		if pullRequest.Head.Name == headName && pullRequest.Base.Name == target.String() {
+		if pullRequest.Head.Name == headName && (target.IsEmpty() || pullRequest.Base.Name == target.String()) {
 			result = append(result, pullRequest)
 		}
 	}
 	return result
 }
 
+// APIURL provides the base URL of the Gitea API for the repository at the given origin.
+func APIURL(originURL *giturl.Parts) string {
+	return "https://" + originURL.Host
+}
+
 // NewGiteaConfig provides Gitea configuration data if the current repo is hosted on Gitea,
 // otherwise nil.
 func NewConnector(args NewConnectorArgs) (*Connector, error) {
 	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: args.APIToken.String()})
 	httpClient := oauth2.NewClient(context.Background(), tokenSource)
-	giteaClient := gitea.NewClientWithHTTP("https://"+args.OriginURL.Host, httpClient)
+	giteaClient := gitea.NewClientWithHTTP(args.APIURL, httpClient)
 	return &Connector{
 		APIToken: args.APIToken,
 		Config: hostingdomain.Config{
@@ -123,7 +213,19 @@ func NewConnector(args NewConnectorArgs) (*Connector, error) {
 
 type NewConnectorArgs struct {
 	APIToken        configdomain.GiteaToken
+	APIURL          string
 	HostingPlatform configdomain.HostingPlatform
 	Log             print.Logger
 	OriginURL       *giturl.Parts
 }
+
+// parsePullRequest extracts standardized proposal data from the given Gitea pull request.
+func parsePullRequest(pullRequest *gitea.PullRequest) hostingdomain.Proposal {
+	return hostingdomain.Proposal{
+		Body:         pullRequest.Body,
+		MergeWithAPI: pullRequest.Mergeable,
+		Number:       int(pullRequest.Index),
+		Target:       gitdomain.NewLocalBranchName(pullRequest.Base.Ref),
+		Title:        pullRequest.Title,
+	}
+}
@@ -1,10 +1,17 @@
 package gitea_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	giteasdk "code.gitea.io/sdk/gitea"
+	"github.com/git-town/git-town/v14/src/cli/print"
+	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/git/giturl"
 	"github.com/git-town/git-town/v14/src/hosting/gitea"
 	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
 	"github.com/shoenig/test/must"
@@ -77,6 +84,69 @@ func TestGitea(t *testing.T) {
 		must.EqOp(t, want, have)
 	})
 
+	t.Run("UpdateProposalTarget", func(t *testing.T) {
+		t.Run("changes the base branch and keeps title and body", func(t *testing.T) {
+			updated := false
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				switch request.Method + " " + request.URL.Path {
+				case "GET /api/v1/version":
+					fmt.Fprint(writer, `{"version": "1.21.0"}`)
+				case "GET /api/v1/repos/org/repo/pulls/12":
+					fmt.Fprint(writer, `{"number": 12, "title": "my title", "body": "my body", "base": {"ref": "old"}}`)
+				case "PATCH /api/v1/repos/org/repo/pulls/12":
+					var body giteasdk.EditPullRequestOption
+					must.NoError(t, json.NewDecoder(request.Body).Decode(&body))
+					must.EqOp(t, "new", body.Base)
+					must.EqOp(t, "my body", body.Body)
+					must.EqOp(t, "my title", body.Title)
+					updated = true
+					fmt.Fprint(writer, `{"number": 12}`)
+				default:
+					t.Fatalf("unexpected request: %s %s", request.Method, request.URL.Path)
+				}
+			}))
+			defer server.Close()
+			connector, err := gitea.NewConnector(gitea.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformGitea,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@gitea.com:org/repo.git"),
+			})
+			must.NoError(t, err)
+			err = connector.UpdateProposalTarget(12, gitdomain.NewLocalBranchName("new"))
+			must.NoError(t, err)
+			must.True(t, updated)
+		})
+
+		t.Run("API error", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				if request.URL.Path == "/api/v1/version" {
+					fmt.Fprint(writer, `{"version": "1.21.0"}`)
+					return
+				}
+				writer.WriteHeader(http.StatusNotFound)
+			}))
+			defer server.Close()
+			connector, err := gitea.NewConnector(gitea.NewConnectorArgs{
+				APIToken:        "secret",
+				APIURL:          server.URL,
+				HostingPlatform: configdomain.HostingPlatformGitea,
+				Log:             print.Logger{},
+				OriginURL:       giturl.Parse("git@gitea.com:org/repo.git"),
+			})
+			must.NoError(t, err)
+			err = connector.UpdateProposalTarget(12, gitdomain.NewLocalBranchName("new"))
+			must.Error(t, err)
+		})
+
+		t.Run("no proposal number", func(t *testing.T) {
+			connector := gitea.Connector{} //nolint:exhaustruct
+			err := connector.UpdateProposalTarget(0, gitdomain.NewLocalBranchName("new"))
+			must.EqError(t, err, "no proposal number given")
+		})
+	})
+
 	// THIS TEST CONNECTS TO AN EXTERNAL INTERNET HOST,
 	// WHICH MAKES IT SLOW AND FLAKY.
 	// DISABLE AS NEEDED TO DEBUG THE GITEA CONNECTOR.
@@ -168,3 +238,39 @@ func TestNewGiteaConnector(t *testing.T) {
 	// 	must.NoError(t, err)
 	// })
 }
+
+func TestFilterGiteaPullRequestsWithoutTarget(t *testing.T) {
+	t.Parallel()
+	give := []*giteasdk.PullRequest{
+		// matching branch
+		{
+			Head: &giteasdk.PRBranchInfo{
+				Name: "organization/branch",
+			},
+			Base: &giteasdk.PRBranchInfo{
+				Name: "target",
+			},
+		},
+		// branch with different name
+		{
+			Head: &giteasdk.PRBranchInfo{
+				Name: "organization/other",
+			},
+			Base: &giteasdk.PRBranchInfo{
+				Name: "target",
+			},
+		},
+	}
+	want := []*giteasdk.PullRequest{
+		{
+			Head: &giteasdk.PRBranchInfo{
+				Name: "organization/branch",
+			},
+			Base: &giteasdk.PRBranchInfo{
+				Name: "target",
+			},
+		},
+	}
+	have := gitea.FilterPullRequests(give, "organization", gitdomain.NewLocalBranchName("branch"), gitdomain.EmptyLocalBranchName())
+	must.Eq(t, want, have)
+}
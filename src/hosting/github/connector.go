@@ -27,6 +27,39 @@ type Connector struct {
 	log        print.Logger
 }
 
+func (self *Connector) CloseProposal(number int) error {
+	if number <= 0 {
+		return errors.New(messages.ProposalNoNumberGiven)
+	}
+	self.log.Start(messages.HostingGithubClosePRViaAPI, number)
+	_, _, err := self.client.PullRequests.Edit(context.Background(), self.Organization, self.Repository, number, &github.PullRequest{
+		State: github.String("closed"),
+	})
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	self.log.Success()
+	return nil
+}
+
+func (self *Connector) CreateProposal(data hostingdomain.NewProposalData) (hostingdomain.Proposal, error) {
+	self.log.Start(messages.HostingGithubCreatePRViaAPI, data.Branch, data.Target)
+	pullRequest, _, err := self.client.PullRequests.Create(context.Background(), self.Organization, self.Repository, &github.NewPullRequest{
+		Base:  github.String(data.Target.String()),
+		Body:  github.String(data.Body),
+		Draft: github.Bool(data.Draft),
+		Head:  github.String(data.Branch.String()),
+		Title: github.String(data.Title),
+	})
+	if err != nil {
+		self.log.Failed(err)
+		return hostingdomain.Proposal{}, err //nolint:exhaustruct
+	}
+	self.log.Success()
+	return parsePullRequest(pullRequest), nil
+}
+
 func (self *Connector) DefaultProposalMessage(proposal hostingdomain.Proposal) string {
 	return fmt.Sprintf("%s (#%d)", proposal.Title, proposal.Number)
 }
@@ -58,6 +91,10 @@ func (self *Connector) NewProposalURL(branch, parentBranch gitdomain.LocalBranch
 	return fmt.Sprintf("%s/compare/%s?expand=1", self.RepositoryURL(), url.PathEscape(toCompare)), nil
 }
 
+func (self *Connector) ProposalURL(number int) string {
+	return fmt.Sprintf("%s/pull/%d", self.RepositoryURL(), number)
+}
+
 func (self *Connector) RepositoryURL() string {
 	return fmt.Sprintf("https://%s/%s/%s", self.HostnameWithStandardPort(), self.Organization, self.Repository)
 }
@@ -76,6 +113,19 @@ func (self *Connector) SquashMergeProposal(number int, message gitdomain.CommitM
 	return err
 }
 
+func (self *Connector) UpdateProposalBody(number int, body string) error {
+	self.log.Start(messages.HostingGithubUpdatePRBodyViaAPI, number)
+	_, _, err := self.client.PullRequests.Edit(context.Background(), self.Organization, self.Repository, number, &github.PullRequest{
+		Body: github.String(body),
+	})
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	self.log.Success()
+	return nil
+}
+
 func (self *Connector) UpdateProposalTarget(number int, target gitdomain.LocalBranchName) error {
 	self.log.Start(messages.HostingGithubUpdatePRViaAPI, number)
 	targetName := target.String()
@@ -137,6 +187,7 @@ type NewConnectorArgs struct {
 // parsePullRequest extracts standardized proposal data from the given GitHub pull-request.
 func parsePullRequest(pullRequest *github.PullRequest) hostingdomain.Proposal {
 	return hostingdomain.Proposal{
+		Body:         pullRequest.GetBody(),
 		Number:       pullRequest.GetNumber(),
 		Target:       gitdomain.NewLocalBranchName(pullRequest.Base.GetRef()),
 		Title:        pullRequest.GetTitle(),
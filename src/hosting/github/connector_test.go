@@ -68,6 +68,19 @@ func TestConnector(t *testing.T) {
 		}
 	})
 
+	t.Run("ProposalURL", func(t *testing.T) {
+		t.Parallel()
+		connector := github.Connector{ //nolint:exhaustruct
+			Config: hostingdomain.Config{
+				Hostname:     "github.com",
+				Organization: "organization",
+				Repository:   "repo",
+			},
+		}
+		have := connector.ProposalURL(12)
+		must.EqOp(t, "https://github.com/organization/repo/pull/12", have)
+	})
+
 	t.Run("RepositoryURL", func(t *testing.T) {
 		t.Parallel()
 		connector := github.Connector{ //nolint:exhaustruct
@@ -25,6 +25,10 @@ func (self *Config) NewProposalURL(branch, parentBranch gitdomain.LocalBranchNam
 	return fmt.Sprintf("%s/-/merge_requests/new?%s", self.RepositoryURL(), query.Encode()), nil
 }
 
+func (self *Config) ProposalURL(number int) string {
+	return fmt.Sprintf("%s/-/merge_requests/%d", self.RepositoryURL(), number)
+}
+
 func (self *Config) RepositoryURL() string {
 	return fmt.Sprintf("%s/%s", self.baseURL(), self.projectPath())
 }
@@ -14,6 +14,9 @@ import (
 	"github.com/xanzy/go-gitlab"
 )
 
+// the title prefix that makes GitLab treat a merge request as a draft
+const draftTitlePrefix = "Draft: "
+
 // Connector provides standardized connectivity for the given repository (gitlab.com/owner/repo)
 // via the GitLab API.
 type Connector struct {
@@ -22,11 +25,50 @@ type Connector struct {
 	log print.Logger
 }
 
+func (self *Connector) CloseProposal(number int) error {
+	if number <= 0 {
+		return errors.New(messages.ProposalNoNumberGiven)
+	}
+	self.log.Start(messages.HostingGitlabCloseMRViaAPI, number)
+	_, _, err := self.client.MergeRequests.UpdateMergeRequest(self.projectPath(), number, &gitlab.UpdateMergeRequestOptions{
+		StateEvent: gitlab.Ptr("close"),
+	})
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	self.log.Success()
+	return nil
+}
+
+func (self *Connector) CreateProposal(data hostingdomain.NewProposalData) (hostingdomain.Proposal, error) {
+	self.log.Start(messages.HostingGitlabCreateMRViaAPI, data.Branch, data.Target)
+	title := data.Title
+	if data.Draft {
+		// GitLab marks merge requests whose title starts with this prefix as drafts
+		title = draftTitlePrefix + title
+	}
+	mergeRequest, _, err := self.client.MergeRequests.CreateMergeRequest(self.projectPath(), &gitlab.CreateMergeRequestOptions{
+		Description:  gitlab.Ptr(data.Body),
+		SourceBranch: gitlab.Ptr(data.Branch.String()),
+		TargetBranch: gitlab.Ptr(data.Target.String()),
+		Title:        gitlab.Ptr(title),
+	})
+	if err != nil {
+		self.log.Failed(err)
+		return hostingdomain.Proposal{}, err //nolint:exhaustruct
+	}
+	self.log.Success()
+	return parseMergeRequest(mergeRequest), nil
+}
+
 func (self *Connector) FindProposal(branch, target gitdomain.LocalBranchName) (*hostingdomain.Proposal, error) {
 	opts := &gitlab.ListProjectMergeRequestsOptions{
 		State:        gitlab.Ptr("opened"),
 		SourceBranch: gitlab.Ptr(branch.String()),
-		TargetBranch: gitlab.Ptr(target.String()),
+	}
+	if !target.IsEmpty() {
+		opts.TargetBranch = gitlab.Ptr(target.String())
 	}
 	mergeRequests, _, err := self.client.MergeRequests.ListProjectMergeRequests(self.projectPath(), opts)
 	if err != nil {
@@ -62,6 +104,19 @@ func (self *Connector) SquashMergeProposal(number int, message gitdomain.CommitM
 	return nil
 }
 
+func (self *Connector) UpdateProposalBody(number int, body string) error {
+	self.log.Start(messages.HostingGitlabUpdateMRBodyViaAPI, number)
+	_, _, err := self.client.MergeRequests.UpdateMergeRequest(self.projectPath(), number, &gitlab.UpdateMergeRequestOptions{
+		Description: gitlab.Ptr(body),
+	})
+	if err != nil {
+		self.log.Failed(err)
+		return err
+	}
+	self.log.Success()
+	return nil
+}
+
 func (self *Connector) UpdateProposalTarget(number int, target gitdomain.LocalBranchName) error {
 	self.log.Start(messages.HostingGitlabUpdateMRViaAPI, number, target)
 	_, _, err := self.client.MergeRequests.UpdateMergeRequest(self.projectPath(), number, &gitlab.UpdateMergeRequestOptions{
@@ -109,6 +164,7 @@ type NewConnectorArgs struct {
 
 func parseMergeRequest(mergeRequest *gitlab.MergeRequest) hostingdomain.Proposal {
 	return hostingdomain.Proposal{
+		Body:         mergeRequest.Description,
 		Number:       mergeRequest.IID,
 		Target:       gitdomain.NewLocalBranchName(mergeRequest.TargetBranch),
 		Title:        mergeRequest.Title,
@@ -77,6 +77,22 @@ func TestGitlabConnector(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("ProposalURL", func(t *testing.T) {
+		t.Parallel()
+		connector := gitlab.Connector{ //nolint:exhaustruct
+			Config: gitlab.Config{
+				APIToken: "apiToken",
+				Config: hostingdomain.Config{
+					Hostname:     "gitlab.com",
+					Organization: "organization",
+					Repository:   "repo",
+				},
+			},
+		}
+		have := connector.ProposalURL(12)
+		must.EqOp(t, "https://gitlab.com/organization/repo/-/merge_requests/12", have)
+	})
 }
 
 func TestNewGitlabConnector(t *testing.T) {
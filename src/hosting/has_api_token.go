@@ -0,0 +1,36 @@
+package hosting
+
+import (
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/hosting/github"
+)
+
+// HasAPIToken indicates whether the user has provided an API token
+// for the code hosting platform that the given connector arguments describe.
+func HasAPIToken(args NewConnectorArgs) bool {
+	switch Detect(args.OriginURL, args.HostingPlatform) {
+	case configdomain.HostingPlatformAzureDevOps:
+		return args.AzureDevOpsToken != ""
+	case configdomain.HostingPlatformBitbucket:
+		return args.BitbucketToken != ""
+	case configdomain.HostingPlatformGitea:
+		return args.GiteaToken != ""
+	case configdomain.HostingPlatformGitHub:
+		return github.GetAPIToken(args.GitHubToken) != ""
+	case configdomain.HostingPlatformGitLab:
+		return args.GitLabToken != ""
+	case configdomain.HostingPlatformNone:
+		return false
+	}
+	return false
+}
+
+// HasAnyAPIToken indicates whether the user has provided an API token for any code hosting platform.
+// Unlike HasAPIToken, this doesn't need to determine the URL of the origin remote.
+func HasAnyAPIToken(config *configdomain.FullConfig) bool {
+	return config.AzureDevOpsToken != "" ||
+		config.BitbucketToken != "" ||
+		config.GiteaToken != "" ||
+		github.GetAPIToken(config.GitHubToken) != "" ||
+		config.GitLabToken != ""
+}
@@ -5,11 +5,19 @@ import "github.com/git-town/git-town/v14/src/git/gitdomain"
 // Connector describes the activities that Git Town can perform on code hosting platforms.
 // Individual implementations exist to talk to specific hosting platforms.
 type Connector interface {
+	// CloseProposal closes the proposal with the given number without merging it.
+	CloseProposal(number int) error
+
+	// CreateProposal creates a new proposal with the given data
+	// and provides the resulting proposal.
+	CreateProposal(data NewProposalData) (Proposal, error)
+
 	// DefaultProposalMessage provides the text that the form for creating new proposals
 	// on the respective hosting platform is prepopulated with.
 	DefaultProposalMessage(proposal Proposal) string
 
 	// FindProposal provides details about the proposal for the given branch into the given target branch.
+	// If the given target branch is empty, finds the proposal for the given branch into any target branch.
 	// Returns nil if no proposal exists.
 	FindProposal(branch, target gitdomain.LocalBranchName) (*Proposal, error)
 
@@ -21,9 +29,16 @@ type Connector interface {
 	// to create a new proposal online.
 	NewProposalURL(branch, parentBranch gitdomain.LocalBranchName) (string, error)
 
+	// ProposalURL provides the URL of the page
+	// that shows the proposal with the given number online.
+	ProposalURL(number int) string
+
 	// RepositoryURL provides the URL where the current repository can be found online.
 	RepositoryURL() string
 
+	// UpdateProposalBody replaces the description of the proposal with the given number.
+	UpdateProposalBody(number int, body string) error
+
 	// UpdateProposalTarget updates the target branch of the given proposal.
 	UpdateProposalTarget(number int, target gitdomain.LocalBranchName) error
 }
@@ -0,0 +1,21 @@
+package hostingdomain
+
+import "github.com/git-town/git-town/v14/src/git/gitdomain"
+
+// NewProposalData contains the information needed to create a new proposal via the API of a code hosting platform.
+type NewProposalData struct {
+	// textual description of the proposal
+	Body string
+
+	// name of the branch that the proposal merges ("head")
+	Branch gitdomain.LocalBranchName
+
+	// whether to create the proposal as a draft
+	Draft bool
+
+	// name of the branch that the proposal merges into ("base")
+	Target gitdomain.LocalBranchName
+
+	// textual title of the proposal
+	Title string
+}
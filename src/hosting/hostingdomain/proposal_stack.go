@@ -0,0 +1,77 @@
+package hostingdomain
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ProposalStackStart marks the beginning of the section that Git Town maintains in proposal bodies.
+	ProposalStackStart = "<!-- git-town-stack-start -->"
+
+	// ProposalStackEnd marks the end of the section that Git Town maintains in proposal bodies.
+	ProposalStackEnd = "<!-- git-town-stack-end -->"
+)
+
+// ProposalStackEntry describes a branch in the stack overview that Git Town adds to proposal bodies.
+type ProposalStackEntry struct {
+	// nesting level of the branch, the root branch of the stack has depth 0
+	Depth int
+
+	// whether this entry describes the proposal that contains the stack overview
+	IsCurrent bool
+
+	// textual description of the branch or its proposal
+	Text string
+
+	// URL of the proposal for this branch, empty if the branch has no proposal
+	URL string
+}
+
+// RenderProposalStack provides the section describing the given stack in proposal bodies,
+// including the markers that delimit it.
+func RenderProposalStack(entries []ProposalStackEntry) string {
+	result := strings.Builder{}
+	result.WriteString(ProposalStackStart)
+	result.WriteString("\n")
+	result.WriteString("This proposal is part of a stack managed by [Git Town](https://www.git-town.com):\n\n")
+	for _, entry := range entries {
+		result.WriteString(strings.Repeat("  ", entry.Depth))
+		result.WriteString("- ")
+		text := entry.Text
+		if entry.URL != "" {
+			text = fmt.Sprintf("[%s](%s)", entry.Text, entry.URL)
+		}
+		if entry.IsCurrent {
+			text = fmt.Sprintf("**%s** ⬅ this proposal", text)
+		}
+		result.WriteString(text)
+		result.WriteString("\n")
+	}
+	result.WriteString(ProposalStackEnd)
+	return result.String()
+}
+
+// UpdateProposalStack provides the given proposal body with its stack section replaced by the given one.
+// It appends the given stack section to bodies that don't contain one yet.
+// An empty stack section removes the existing stack section.
+// This leaves all text outside the stack section markers untouched.
+func UpdateProposalStack(body, stack string) string {
+	start := strings.Index(body, ProposalStackStart)
+	end := strings.Index(body, ProposalStackEnd)
+	if start == -1 || end < start {
+		if stack == "" {
+			return body
+		}
+		if strings.TrimSpace(body) == "" {
+			return stack
+		}
+		return strings.TrimRight(body, "\n") + "\n\n" + stack
+	}
+	before := body[:start]
+	after := body[end+len(ProposalStackEnd):]
+	if stack == "" {
+		return strings.TrimRight(before, "\n") + after
+	}
+	return before + stack + after
+}
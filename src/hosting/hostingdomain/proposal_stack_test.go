@@ -0,0 +1,74 @@
+package hostingdomain_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+	"github.com/shoenig/test/must"
+)
+
+func TestProposalStack(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RenderProposalStack", func(t *testing.T) {
+		t.Parallel()
+		have := hostingdomain.RenderProposalStack([]hostingdomain.ProposalStackEntry{
+			{Depth: 0, IsCurrent: false, Text: "main", URL: ""},
+			{Depth: 1, IsCurrent: false, Text: "alpha (#1)", URL: "https://github.com/org/repo/pull/1"},
+			{Depth: 2, IsCurrent: true, Text: "beta (#2)", URL: "https://github.com/org/repo/pull/2"},
+			{Depth: 3, IsCurrent: false, Text: "gamma", URL: ""},
+		})
+		want := `<!-- git-town-stack-start -->
+This proposal is part of a stack managed by [Git Town](https://www.git-town.com):
+
+- main
+  - [alpha (#1)](https://github.com/org/repo/pull/1)
+    - **[beta (#2)](https://github.com/org/repo/pull/2)** ⬅ this proposal
+      - gamma
+<!-- git-town-stack-end -->`
+		must.EqOp(t, want, have)
+	})
+
+	t.Run("UpdateProposalStack", func(t *testing.T) {
+		t.Parallel()
+		stack := hostingdomain.ProposalStackStart + "\nnew stack\n" + hostingdomain.ProposalStackEnd
+		oldStack := hostingdomain.ProposalStackStart + "\nold stack\n" + hostingdomain.ProposalStackEnd
+		tests := map[string]struct {
+			body  string
+			stack string
+			want  string
+		}{
+			"empty body": {
+				body:  "",
+				stack: stack,
+				want:  stack,
+			},
+			"body without stack": {
+				body:  "user text\n",
+				stack: stack,
+				want:  "user text\n\n" + stack,
+			},
+			"body with stack": {
+				body:  "before\n\n" + oldStack + "\n\nafter",
+				stack: stack,
+				want:  "before\n\n" + stack + "\n\nafter",
+			},
+			"remove existing stack": {
+				body:  "user text\n\n" + oldStack,
+				stack: "",
+				want:  "user text",
+			},
+			"remove non-existing stack": {
+				body:  "user text",
+				stack: "",
+				want:  "user text",
+			},
+		}
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				have := hostingdomain.UpdateProposalStack(tt.body, tt.stack)
+				must.EqOp(t, tt.want, have)
+			})
+		}
+	})
+}
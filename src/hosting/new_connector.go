@@ -4,6 +4,7 @@ import (
 	"github.com/git-town/git-town/v14/src/cli/print"
 	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/git/giturl"
+	"github.com/git-town/git-town/v14/src/hosting/azuredevops"
 	"github.com/git-town/git-town/v14/src/hosting/bitbucket"
 	"github.com/git-town/git-town/v14/src/hosting/gitea"
 	"github.com/git-town/git-town/v14/src/hosting/github"
@@ -14,14 +15,26 @@ import (
 // NewConnector provides an instance of the code hosting connector to use based on the given gitConfig.
 func NewConnector(args NewConnectorArgs) (hostingdomain.Connector, error) {
 	switch Detect(args.OriginURL, args.HostingPlatform) {
+	case configdomain.HostingPlatformAzureDevOps:
+		return azuredevops.NewConnector(azuredevops.NewConnectorArgs{
+			APIToken:        args.AzureDevOpsToken,
+			APIURL:          azuredevops.APIURL,
+			HostingPlatform: args.HostingPlatform,
+			Log:             args.Log,
+			OriginURL:       args.OriginURL,
+		})
 	case configdomain.HostingPlatformBitbucket:
 		return bitbucket.NewConnector(bitbucket.NewConnectorArgs{
+			APIToken:        args.BitbucketToken,
+			APIURL:          bitbucket.APIURL,
 			HostingPlatform: args.HostingPlatform,
+			Log:             args.Log,
 			OriginURL:       args.OriginURL,
 		})
 	case configdomain.HostingPlatformGitea:
 		return gitea.NewConnector(gitea.NewConnectorArgs{
 			APIToken:        args.GiteaToken,
+			APIURL:          gitea.APIURL(args.OriginURL),
 			HostingPlatform: args.HostingPlatform,
 			Log:             args.Log,
 			OriginURL:       args.OriginURL,
@@ -53,3 +66,12 @@ type NewConnectorArgs struct {
 	Log             print.Logger
 	OriginURL       *giturl.Parts
 }
+
+// NewAPIConnector provides a connector for the code hosting platform that the given arguments describe
+// if the user has provided an API token for it, otherwise nil.
+func NewAPIConnector(args NewConnectorArgs) (hostingdomain.Connector, error) {
+	if !HasAPIToken(args) {
+		return nil, nil
+	}
+	return NewConnector(args)
+}
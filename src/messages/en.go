@@ -2,8 +2,12 @@ package messages
 
 const (
 	UndoContinueGuidance               = "\n\nTo continue after having resolved conflicts, run \"git town continue\".\nTo go back to where you started, run \"git town undo\".\n"
+	AheadBehindUnexpectedOutput        = "unexpected output of git rev-list: %q"
 	AliasedCommands                    = "Aliased commands: %s\n"
 	ArgumentUnknown                    = "unknown argument: %q"
+	AzureDevOpsToken                   = "Azure DevOps token: %s\n"
+	BitbucketToken                     = "Bitbucket token: %s\n"
+	BranchAheadBehindProblem           = "cannot determine how far branch %q is ahead of and behind %q: %w"
 	BranchAlreadyExistsLocally         = "there is already a branch %q"
 	BranchAlreadyExistsRemotely        = "there is already a branch %q at the \"origin\" remote"
 	BranchAuthorMultiple               = "\nMultiple people authored the %q branch.\n\n"
@@ -18,13 +22,19 @@ const (
 	BranchIsAlreadyContribution        = "branch %q is already a contribution branch"
 	BranchIsAlreadyObserved            = "branch %q is already observed"
 	BranchIsAlreadyParked              = "branch %q is already parked"
+	BranchIsAlreadyPrototype           = "branch %q is already a prototype branch"
 	BranchLocalSHAProblem              = "cannot determine SHA of local branch %q: %w"
 	BranchLocalProblem                 = "cannot determine whether the local branch %q exists: %w"
 	BranchParentChanged                = "branch %q is now a child of %q"
+	BranchesAheadBehind                = "%d ahead, %d behind %s"
+	BranchesNotFound                   = "not found"
+	BranchesProposal                   = "proposal #%d: %s"
+	BranchesProposalUnknown            = "proposal unknown"
 	BrowserOpen                        = "Please open in a browser: %s\n"
 	CacheUnitialized                   = "using a cached value before initialization"
 	CodeHosting                        = "Code hosting: %s\n"
 	CommandsRun                        = "Ran %d shell commands."
+	CommitTreeProblem                  = "cannot create the merge commit: %w"
 	CommitMessageProblem               = "cannot determine last commit message: %w"
 	CompressUnsynced                   = "please sync branch %q before compressing it"
 	CompressIsPerennial                = "better not compress perennial branches"
@@ -91,25 +101,56 @@ END OUTPUT FROM 'git branch -vva'
 	HackBranchIsNowFeature                = "branch %q is now a feature branch\n"
 	HackCannotFeatureMainBranch           = "cannot make the main branch a feature branch"
 	HackCannotFeaturePerennialBranch      = "branch %q is a perennial branch and therefore be a feature branch"
-	HostingBitBucketNotImplemented        = "shipping pull requests via the Bitbucket API is currently not supported. If you need this functionality, please vote for it by opening a ticket at https://github.com/git-town/git-town/issues"
+	HostingAzureDevOpsAPIProblem          = "Azure DevOps API responded with status %d: %s"
+	HostingAzureDevOpsMergeFailed         = "Azure DevOps could not complete PR #%d: %s"
+	HostingAzureDevOpsMergeTimeout        = "Azure DevOps did not complete PR #%d within %s"
+	HostingAzureDevOpsMergingViaAPI       = "Azure DevOps API: completing PR #%d ... "
+	HostingAzureDevOpsUpdatePRBodyViaAPI  = "Azure DevOps API: updating description of PR #%d ... "
+	HostingAzureDevOpsUpdatePRViaAPI      = "Azure DevOps API: updating target branch for PR #%d to %q ... "
+	HostingBitbucketAPIProblem            = "Bitbucket API responded with status %d: %s"
+	HostingBitbucketMergingViaAPI         = "Bitbucket API: merging PR #%d ... "
+	HostingBitbucketUpdatePRBodyViaAPI    = "Bitbucket API: updating description of PR #%d ... "
+	HostingBitbucketUpdatePRViaAPI        = "Bitbucket API: updating destination branch for PR #%d to %q ... "
+	HostingCloseProposalUnsupported       = "%s does not support closing proposals via the API"
+	HostingCreateProposalUnsupported      = "%s does not support creating proposals via the API"
+	HostingGitlabCloseMRViaAPI            = "GitLab API: Closing MR !%d ... "
+	HostingGitlabCreateMRViaAPI           = "GitLab API: Creating MR from %q to %q ... "
 	HostingGitlabMergingViaAPI            = "GitLab API: Merging MR !%d ... "
+	HostingGitlabUpdateMRBodyViaAPI       = "GitLab API: Updating description of MR !%d ... "
 	HostingGitlabUpdateMRViaAPI           = "GitLab API: Updating target branch for MR !%d to %q ... "
-	HostingGiteaNotImplemented            = "shipping pull requests via the Gitea API is currently not supported. If you need this functionality, please vote for it by opening a ticket at https://github.com/git-town/git-town/issues"
-	HostingGiteaUpdatePRViaAPI            = "Gitea API: Updating base branch for PR #%d to #%s"
+	HostingGiteaClosePRViaAPI             = "Gitea API: closing PR #%d ... "
+	HostingGiteaCreatePRViaAPI            = "Gitea API: creating PR from %q to %q ... "
+	HostingGiteaUpdatePRBodyViaAPI        = "Gitea API: updating description of PR #%d ... "
+	HostingGiteaUpdatePRViaAPI            = "Gitea API: updating base branch for PR #%d to %q ... "
+	HostingGithubClosePRViaAPI            = "GitHub API: closing PR #%d ... "
+	HostingGithubCreatePRViaAPI           = "GitHub API: creating PR from %q to %q ... "
 	HostingGithubMergingViaAPI            = "GitHub API: merging PR #%d ... "
+	HostingGithubUpdatePRBodyViaAPI       = "GitHub API: updating description of PR #%d ... "
 	HostingGithubUpdatePRViaAPI           = "GitHub API: updating base branch for PR #%d ... "
 	HostingPlatformUnknown                = "unknown hosting platform: %q"
 	InputAddOrRemove                      = `invalid argument %q. Please provide either "add" or "remove"`
 	InputYesOrNo                          = `invalid argument: %q. Please provide either "yes" or "no".\n`
-	KillBranchOtherWorktree               = `branch %q is active in another worktree`
 	KillCannotKillMainBranch              = "you cannot kill the main branch"
 	KillCannotKillPerennialBranches       = "you cannot kill perennial branches"
+	LineageFileCycle                      = "cannot import the lineage because branch %q would be its own ancestor"
+	LineageFileFormatUnknown              = `unknown lineage file format %q, please use "toml" or "json"`
+	LineageFileInvalid                    = "the lineage file contains invalid data: %w"
+	LineageFileMultipleTypes              = "the lineage file lists branch %q as %s and as %s"
+	LineageImported                       = "Imported %d lineage entries and %d branch types.\n"
+	LineageInferNothing                   = "All local branches have a known parent branch."
+	LineageInferProposalsProblem          = "Cannot look up proposals, inferring the lineage without them: %v\n"
+	LineageInferred                       = "Git Town inferred this lineage:"
+	LineageInferredSelection              = "Inferred lineage: %s\n"
 	MainBranch                            = "Main branch: %s\n"
 	MainBranchCannotMakeContribution      = "cannot make the main branch a contribution branch"
 	MainBranchCannotObserve               = "cannot observe the main branch"
 	MainBranchCannotPark                  = "cannot park the main branch"
 	MainBranchCannotPropose               = "cannot propose the main branch"
+	MainBranchCannotPrototype             = "cannot make the main branch a prototype branch"
 	MainBranchCannotShip                  = "cannot ship the main branch"
+	MergeBaseProblem                      = "cannot determine the merge base of %q and %q: %w"
+	MergeTreeProblem                      = "cannot merge %q and %q in memory: %w"
+	MoveBranchOtherWorktree               = "cannot move branch %q because it is active in another worktree"
 	ObservedBranchCannotPark              = "cannot park observed branches"
 	ObservedBranchCannotPropose           = "cannot propose observed branches"
 	ObservedBranchCannotShip              = "cannot ship observed branches"
@@ -118,47 +159,77 @@ END OUTPUT FROM 'git branch -vva'
 	OpcodeUnknown                         = "unknown opcode: %q, run \"git town status reset\" to reset it"
 	OpenChangesProblem                    = "cannot determine open changes: %w"
 	OriginHostname                        = "Origin hostname: %s\n"
+	OutputFormatUnknown                   = "unknown output format %q, supported formats are \"text\" and \"json\""
 	ParentDialogSelected                  = "Selected parent branch for %q: %s\n"
 	ParkedBranchIsNowParked               = "branch %q is now parked\n"
 	PerennialBranchCannotMakeContribution = "cannot make perennial branches contribution branches"
 	PerennialBranchCannotObserve          = "cannot observe perennial branches"
 	PerennialBranchCannotPark             = "cannot park perennial branches"
 	PerennialBranchCannotPropose          = "cannot propose perennial branches"
+	PerennialBranchCannotPrototype        = "cannot make perennial branches prototype branches"
 	PerennialBranchCannotShip             = "cannot ship perennial branches"
 	PerennialBranches                     = "Perennial branches: %s\n"
 	PerennialBranchRemovedParentEntry     = "Removed parent entry for perennial branch %q\n"
 	PerennialRegex                        = "Perennial regex: %s\n"
 	PreviousCommandFinished               = "The previous Git Town command (%s) finished successfully.\n"
 	PreviousCommandProblem                = "The last Git Town command (%s) hit a problem %v ago.\n"
+	ProposalCreated                       = "created proposal %s: %s\n"
 	ProposalMultipleFound                 = "found %d proposals from branch %q to branch %q"
 	ProposalNoNumberGiven                 = "no proposal number given"
+	ProposalNone                          = "(none)"
 	ProposalNotFoundForBranch             = "cannot determine proposal for branch %q: %w"
+	ProposalStackUpdateProblem            = "cannot update the stack overview in the proposals of branch %q: %v"
+	ProposalTitleMissing                  = "creating a proposal via the API requires a title, please provide it using --title"
 	ProposalTargetBranchUpdateProblem     = "cannot update the target branch of proposal %d via the API"
 	ProposalURLProblem                    = "cannot determine proposal URL from %q to %q: %w"
+	ProposalsHeader                       = "Proposals"
+	ProposeBodyAndBodyFile                = "please provide the proposal body either via --body or via --body-file"
+	ProposeBodyFileProblem                = "cannot read the proposal body from file %q: %w"
+	ProposeStackWithTitle                 = "cannot use --title, --body, or --body-file together with --stack because each branch gets its own proposal"
+	PrototypeBranchCannotPropose          = "cannot propose prototype branches, run \"git town hack\" to make it a feature branch first"
+	PrototypeBranchCannotShip             = "cannot ship prototype branches, run \"git town hack\" to make it a feature branch first"
+	PrototypeBranchIsNowPrototype         = "branch %q is now a prototype branch\n"
 	PullRequestDeprecation                = `DEPRECATION NOTICE
 
 This command has been renamed to "git town propose"
 nd will be removed in future versions of Git Town.`
-	PushHook                       = "Push hook: %s\n"
-	PushNewBranches                = "Push new branches: %s\n"
-	RebaseProblem                  = "cannot determine rebase in progress: %w"
-	RemoteExistsProblem            = "cannot determine if remote %q exists: %w"
-	RemotesProblem                 = "cannot determine remotes: %w"
-	RenameBranchNotInSync          = "%q is not in sync with its tracking branch, please sync the branches before renaming"
-	RenameMainBranch               = "the main branch cannot be renamed"
-	RenamePerennialBranchWarning   = "%q is a perennial branch. Renaming a perennial branch typically requires other updates. If you are sure you want to do this, use '--force'"
-	RenameToSameName               = "cannot rename branch to current name"
-	RepoOutside                    = "this is not a Git repository"
-	RunAutoUndo                    = "%s\nAuto-undo... "
-	RunCommandProblem              = "error running command %q: %w"
-	RunstateDeleted                = "Runstate file deleted."
-	RunstateDeleteProblem          = "cannot delete previous run state: %w"
-	RunstateLoadProblem            = "cannot load previous run state: %w"
-	RunstateSerializeProblem       = "cannot encode run-state: %w"
-	RunstatePathProblem            = "cannot determine the runstate file path: %w"
-	RunstateSaveProblem            = "cannot save run state: %w"
-	SetParentNoFeatureBranch       = "the branch %q is not a feature branch. Only feature branches can have parent branches"
-	SettingDeprecatedGlobalMessage = `
+	PushHook                        = "Push hook: %s\n"
+	PushNewBranches                 = "Push new branches: %s\n"
+	RebaseProblem                   = "cannot determine rebase in progress: %w"
+	RedoNothingToDo                 = "nothing to redo"
+	RemoteExistsProblem             = "cannot determine if remote %q exists: %w"
+	RemotesProblem                  = "cannot determine remotes: %w"
+	RenameBranchNotInSync           = "%q is not in sync with its tracking branch, please sync the branches before renaming"
+	RenameMainBranch                = "the main branch cannot be renamed"
+	RenamePerennialBranchWarning    = "%q is a perennial branch. Renaming a perennial branch typically requires other updates. If you are sure you want to do this, use '--force'"
+	RenameToSameName                = "cannot rename branch to current name"
+	RepairBranchMissing             = "branch %q doesn't exist anymore, removing it from the lineage"
+	RepairConfirm                   = "Repair the configuration: %s\n"
+	RepairCycle                     = "branch %q is its own ancestor, making %q its new parent"
+	RepairNoMainBranch              = "the main branch is not configured, please run \"git town config setup\" first"
+	RepairNoProblems                = "The Git Town configuration has no problems."
+	RepairParentDeletedAtRemote     = "the parent branch %q of %q was deleted at the remote, making %q its new parent"
+	RepairParentMissing             = "the parent branch %q of %q doesn't exist, making %q its new parent"
+	RepairProblemsFound             = "Found these problems in the Git Town configuration:"
+	RepairSyncStrategyBranchMissing = "branch %q with a sync strategy override doesn't exist, removing the override"
+	RepairTypedBranchMissing        = "%s %q doesn't exist, removing it from the configuration"
+	RepoOutside                     = "this is not a Git repository"
+	RerereReplayed                  = "replayed the recorded conflict resolutions for %s in branch %q"
+	RunAutoUndo                     = "%s\nAuto-undo... "
+	RunCommandProblem               = "error running command %q: %w"
+	RunstateDeleted                 = "Runstate file deleted."
+	RunstateDeleteProblem           = "cannot delete previous run state: %w"
+	RunstateLoadProblem             = "cannot load previous run state: %w"
+	RunstateSerializeProblem        = "cannot encode run-state: %w"
+	RunstatePathProblem             = "cannot determine the runstate file path: %w"
+	RunstateSaveProblem             = "cannot save run state: %w"
+	SharedLineageAdopted            = "Using the shared parent branch %q for branch %q.\n"
+	SharedLineageUnavailable        = "Cannot load the shared lineage, continuing with the local lineage: %v\n"
+	SetParentCycle                  = "cannot make %q a child of its descendant %q"
+	SetParentMergeAndRebase         = "cannot use --merge and --rebase at the same time"
+	SetParentNoFeatureBranch        = "the branch %q is not a feature branch. Only feature branches can have parent branches"
+	SetParentSelf                   = "cannot make branch %q its own parent"
+	SettingDeprecatedGlobalMessage  = `
 I found the deprecated global setting %q.
 I am upgrading this setting to the new format %q.
 `
@@ -169,36 +240,60 @@ I am upgrading this setting to the new format %q.
 I found the deprecated local setting %q.
 I am upgrading this setting to the new format %q.
 `
-	SettingLocalCannotRemove    = "ERROR: cannot remove local Git setting %q: %v"
-	SettingLocalCannotWrite     = "ERROR: cannot write local Git setting %q: %v"
-	ShipAbortedMergeError       = "aborted because commit exited with error"
-	ShipBranchOtherWorktree     = "branch %q is active in another worktree"
-	ShipBranchNothingToDo       = "the branch %q has no shippable changes"
-	ShipChildBranch             = "shipping this branch would ship %s as well,\nplease ship %q first"
-	ShipDeletesTrackingBranches = "Ship deletes tracking branches: %s\n"
-	ShipOpenChanges             = "you have uncommitted changes. Did you mean to commit them before shipping?"
-	ShippableChangesProblem     = "cannot determine whether branch %q has shippable changes: %w"
-	SkipBranchHasConflicts      = "cannot skip branch that resulted in conflicts"
-	SkipMessage                 = `You can run "git town skip" to skip the currently failing operation.`
-	SkipNothingToDo             = "nothing to skip"
-	SquashCannotReadFile        = "cannot read squash message file %q: %w"
-	SquashCommitAuthorQuery     = "Please choose an author for the squash commit:"
-	SquashCommitAuthorProblem   = "error getting squash commit author: %w"
-	SquashCommitAuthorSelection = "Selected squash commit author: %s\n"
-	SquashMessageProblem        = "cannot comment out the squash commit message: %w"
-	StatusFileNotFound          = "No status file found for this repository."
-	SyncBeforeShip              = "Sync before ship: %s\n"
-	SyncFeatureBranches         = "Sync feature branches: %s\n"
-	SyncPerennialBranches       = "Sync perennial branches: %s\n"
-	SyncStatusNotRecognized     = "cannot determine the sync status for Git remote %q and branch name %q"
-	SyncWithUpstream            = "Sync with upstream: %s\n"
-	UndoCreateOpcodeProblem     = "cannot create undo operations for %q: %w"
-	UndoMessage                 = `You can run "git town undo" to go back to where you started.`
-	UndoNothingToDo             = "nothing to undo"
-	UnfinishedCommandHandle     = "Handle unfinished command: %s\n"
-	UnfinishedRunStateContinue  = "Continue the \"%s\" command after having resolved conflicts"
-	UnfinishedRunStateDiscard   = "Discard the unfinished state and run the new command"
-	UnfinishedRunStateQuit      = "Quit without running anything"
-	UnfinishedRunStateSkip      = "Skip the current branch and continue the \"%s\" command on the next branch"
-	UnfinishedRunStateUndo      = "Undo the previous \"%s\" command"
+	SettingLocalCannotRemove      = "ERROR: cannot remove local Git setting %q: %v"
+	SettingLocalCannotWrite       = "ERROR: cannot write local Git setting %q: %v"
+	ShipAbortedMergeError         = "aborted because commit exited with error"
+	ShipBranchNothingToDo         = "the branch %q has no shippable changes"
+	ShipChildBranch               = "shipping this branch would ship %s as well,\nplease ship %q first or use \"git town ship --stack\""
+	ShipDeletesTrackingBranches   = "Ship deletes tracking branches: %s\n"
+	ShipOpenChanges               = "you have uncommitted changes. Did you mean to commit them before shipping?"
+	ShipStackWithMessage          = "cannot use --message together with --stack because each shipped branch gets its own commit message"
+	ShippableChangesProblem       = "cannot determine whether branch %q has shippable changes: %w"
+	SkipBranchHasConflicts        = "cannot skip branch that resulted in conflicts"
+	SkipMessage                   = `You can run "git town skip" to skip the currently failing operation.`
+	SkipNothingToDo               = "nothing to skip"
+	SquashCannotReadFile          = "cannot read squash message file %q: %w"
+	SquashCommitAuthorQuery       = "Please choose an author for the squash commit:"
+	SquashCommitAuthorProblem     = "error getting squash commit author: %w"
+	SquashCommitAuthorSelection   = "Selected squash commit author: %s\n"
+	SquashMessageProblem          = "cannot comment out the squash commit message: %w"
+	StatusFileNotFound            = "No status file found for this repository."
+	SyncBeforeShip                = "Sync before ship: %s\n"
+	SyncCheckApproximate          = " (approximate)"
+	SyncCheckApproximateNote      = "\nRebases are simulated as a whole rather than commit by commit, so the outcomes marked as approximate can differ from the actual sync."
+	SyncCheckBlocked              = "%s: cannot be checked because its parent branch %q has conflicts"
+	SyncCheckConflict             = "%s: conflicts in %s"
+	SyncCheckConflicts            = "syncing would cause conflicts in %s"
+	SyncCheckDeleted              = "%s: deleted at the remote"
+	SyncCheckFlagConflict         = "cannot use --check together with --dry-run or --parallel"
+	SyncCheckForcePush            = "%s: syncs without conflicts, needs a force-push"
+	SyncCheckGitVersion           = "--check requires Git 2.38 or higher"
+	SyncCheckUpToDate             = "%s: up to date"
+	SyncCheckUpdatable            = "%s: syncs without conflicts"
+	SyncFeatureBranches           = "Sync feature branches: %s\n"
+	SyncParallelGitVersion        = "Syncing the branches one at a time because --parallel requires Git 2.38 or higher."
+	SyncPerennialBranches         = "Sync perennial branches: %s\n"
+	SyncStatusNotRecognized       = "cannot determine the sync status for Git remote %q and branch name %q"
+	SyncStrategyBranchType        = "branch %q is a %s branch, only feature, parked, and prototype branches can have their own sync strategy"
+	SyncStrategyIsDefault         = "branch %q now syncs using the sync-feature-strategy\n"
+	SyncStrategyIsNow             = "branch %q now syncs using the %q strategy\n"
+	SyncWithUpstream              = "Sync with upstream: %s\n"
+	UndoCreateOpcodeProblem       = "cannot create undo operations for %q: %w"
+	UndoHistoryEntry              = "%d. %s (%s): %s\n"
+	UndoHistoryNoBranches         = "no branch changes"
+	UndoHistoryUnfinished         = "unfinished"
+	UndoMessage                   = `You can run "git town undo" to go back to where you started.`
+	UndoNothingToDo               = "nothing to undo"
+	UnfinishedCommandHandle       = "Handle unfinished command: %s\n"
+	UnfinishedRunStateContinue    = "Continue the \"%s\" command after having resolved conflicts"
+	UnfinishedRunStateDiscard     = "Discard the unfinished state and run the new command"
+	UnfinishedRunStateQuit        = "Quit without running anything"
+	UnfinishedRunStateSkip        = "Skip the current branch and continue the \"%s\" command on the next branch"
+	UnfinishedRunStateUndo        = "Undo the previous \"%s\" command"
+	WorktreeBranchDeletedAtRemote = "branch %q was deleted at the remote but is checked out in the worktree at %s, please remove that worktree to delete the branch"
+	WorktreeBranchSynced          = "synced branch %q in the worktree at %s"
+	WorktreeOpenChanges           = "branch %q is checked out in the worktree at %s, which contains uncommitted changes, please commit or discard them first"
+	WorktreePathExists            = "cannot create a worktree at %s because this path already exists"
+	WorktreeProblemLocation       = "\n\nThis happened in the worktree at %s.\nPlease resolve the problem there and run the commands below in %s."
+	WorktreesProblem              = "cannot determine the worktrees: %w"
 )
@@ -5,6 +5,7 @@ import (
 	"github.com/git-town/git-town/v14/src/git"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
 	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+	"github.com/git-town/git-town/v14/src/undo"
 	"github.com/git-town/git-town/v14/src/undo/undobranches"
 	fullInterpreter "github.com/git-town/git-town/v14/src/vm/interpreter/full"
 	lightInterpreter "github.com/git-town/git-town/v14/src/vm/interpreter/light"
@@ -15,8 +16,16 @@ import (
 
 // executes the "skip" command at the given runstate
 func Execute(args ExecuteArgs) error {
-	lightInterpreter.Execute(args.RunState.AbortProgram, args.Runner, args.Runner.Config.FullConfig.Lineage)
-	revertChangesToCurrentBranch(args)
+	lightInterpreter.Execute(lightInterpreter.ExecuteArgs{
+		Connector: args.Connector,
+		Lineage:   args.Runner.Config.FullConfig.Lineage,
+		Prog:      args.RunState.AbortProgram,
+		Runner:    args.Runner,
+	})
+	err := revertChangesToCurrentBranch(args)
+	if err != nil {
+		return err
+	}
 	args.RunState.RunProgram = removeOpcodesForCurrentBranch(args.RunState.RunProgram)
 	return fullInterpreter.Execute(fullInterpreter.ExecuteArgs{
 		Connector:               args.Connector,
@@ -60,18 +69,35 @@ func removeOpcodesForCurrentBranch(prog program.Program) program.Program {
 	return result
 }
 
-func revertChangesToCurrentBranch(args ExecuteArgs) {
+func revertChangesToCurrentBranch(args ExecuteArgs) error {
+	worktrees, err := undo.DetermineWorktrees(&args.Runner.Backend, args.RootDir, *args.RunState)
+	if err != nil {
+		return err
+	}
+	// the branch to skip is checked out in another worktree if the problem happened there
+	branch := args.CurrentBranch
+	if worktrees.FindByBranch(args.RunState.UnfinishedDetails.EndBranch) != nil {
+		branch = args.RunState.UnfinishedDetails.EndBranch
+	}
 	spans := undobranches.BranchSpans{
 		undobranches.BranchSpan{
-			Before: *args.RunState.BeginBranchesSnapshot.Branches.FindByLocalName(args.CurrentBranch),
-			After:  *args.RunState.EndBranchesSnapshot.Branches.FindByLocalName(args.CurrentBranch),
+			Before: *args.RunState.BeginBranchesSnapshot.Branches.FindByLocalName(branch),
+			After:  *args.RunState.EndBranchesSnapshot.Branches.FindByLocalName(branch),
 		},
 	}
 	undoCurrentBranchProgram := spans.Changes().UndoProgram(undobranches.BranchChangesUndoProgramArgs{
 		BeginBranch:              args.CurrentBranch,
 		Config:                   &args.Runner.Config.FullConfig,
 		EndBranch:                args.CurrentBranch,
+		RootDir:                  args.RootDir,
 		UndoablePerennialCommits: args.RunState.UndoablePerennialCommits,
+		Worktrees:                worktrees,
+	})
+	lightInterpreter.Execute(lightInterpreter.ExecuteArgs{
+		Connector: args.Connector,
+		Lineage:   args.Runner.Config.FullConfig.Lineage,
+		Prog:      undoCurrentBranchProgram,
+		Runner:    args.Runner,
 	})
-	lightInterpreter.Execute(undoCurrentBranchProgram, args.Runner, args.Runner.Config.FullConfig.Lineage)
+	return nil
 }
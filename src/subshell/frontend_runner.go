@@ -16,6 +16,7 @@ import (
 // FrontendRunner executes frontend shell commands.
 type FrontendRunner struct {
 	CommandsCounter  *gohacks.Counter
+	EnableRerere     EnableRerereFunc
 	GetCurrentBranch GetCurrentBranchFunc
 	OmitBranchNames  bool
 	PrintCommands    bool
@@ -53,6 +54,7 @@ func PrintCommand(branch gitdomain.LocalBranchName, omitBranch bool, cmd string,
 
 // Run runs the given command in this ShellRunner's directory.
 func (self *FrontendRunner) Run(cmd string, args ...string) (err error) {
+	env := gitTownEnv(os.Environ(), self.EnableRerere, cmd, args)
 	self.CommandsCounter.Register()
 	var branchName gitdomain.LocalBranchName
 	if !self.OmitBranchNames {
@@ -69,6 +71,7 @@ func (self *FrontendRunner) Run(cmd string, args ...string) (err error) {
 		cmd = "cmd"
 	}
 	subProcess := exec.Command(cmd, args...) // #nosec
+	subProcess.Env = env
 	subProcess.Stderr = os.Stderr
 	subProcess.Stdin = os.Stdin
 	subProcess.Stdout = os.Stdout
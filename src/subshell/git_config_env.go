@@ -0,0 +1,62 @@
+package subshell
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// gitConfigCountKey is the environment variable through which Git reads how many configuration entries the environment provides.
+const gitConfigCountKey = "GIT_CONFIG_COUNT"
+
+// AddGitConfig provides the given environment variables with the given Git configuration entry added.
+// Git applies such entries to all Git commands that run in this environment,
+// with higher precedence than the configuration files.
+func AddGitConfig(env []string, key, value string) []string {
+	count := 0
+	result := make([]string, 0, len(env)+3)
+	for _, entry := range env {
+		if text, isCount := strings.CutPrefix(entry, gitConfigCountKey+"="); isCount {
+			count, _ = strconv.Atoi(text)
+			continue
+		}
+		result = append(result, entry)
+	}
+	return append(result,
+		fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", count, key),
+		fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", count, value),
+		fmt.Sprintf("%s=%d", gitConfigCountKey, count+1),
+	)
+}
+
+// EnableRerereFunc indicates whether Git Town should enable Git's "rerere" feature for the Git commands it runs.
+type EnableRerereFunc func() bool
+
+// rerereCommands are the Git subcommands that record and replay conflict resolutions when Git's "rerere" feature is enabled.
+var rerereCommands = []string{"cherry-pick", "merge", "pull", "rebase"}
+
+// gitTownEnv provides the given environment variables with the Git configuration that Git Town uses for the given command.
+// Git Town enables Git's "rerere" feature for Git commands that merge or rebase
+// so that it can replay recorded resolutions when the same conflict appears again,
+// for example in each branch of a stack.
+func gitTownEnv(env []string, enableRerere EnableRerereFunc, executable string, args []string) []string {
+	if executable != "git" || !slices.Contains(rerereCommands, gitSubcommand(args)) || !enableRerere() {
+		return env
+	}
+	return AddGitConfig(env, "rerere.enabled", "true")
+}
+
+// gitSubcommand provides the subcommand of the Git command with the given arguments.
+func gitSubcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "-c" || args[i] == "-C":
+			i++
+		case strings.HasPrefix(args[i], "-"):
+		default:
+			return args[i]
+		}
+	}
+	return ""
+}
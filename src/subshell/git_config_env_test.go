@@ -0,0 +1,27 @@
+package subshell_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/subshell"
+	"github.com/shoenig/test/must"
+)
+
+func TestAddGitConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no existing entries", func(t *testing.T) {
+		t.Parallel()
+		have := subshell.AddGitConfig([]string{"ONE=1"}, "rerere.enabled", "true")
+		want := []string{"ONE=1", "GIT_CONFIG_KEY_0=rerere.enabled", "GIT_CONFIG_VALUE_0=true", "GIT_CONFIG_COUNT=1"}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("keeps existing entries", func(t *testing.T) {
+		t.Parallel()
+		env := []string{"GIT_CONFIG_COUNT=1", "GIT_CONFIG_KEY_0=user.name", "GIT_CONFIG_VALUE_0=tester"}
+		have := subshell.AddGitConfig(env, "rerere.enabled", "true")
+		want := []string{"GIT_CONFIG_KEY_0=user.name", "GIT_CONFIG_VALUE_0=tester", "GIT_CONFIG_KEY_1=rerere.enabled", "GIT_CONFIG_VALUE_1=true", "GIT_CONFIG_COUNT=2"}
+		must.Eq(t, want, have)
+	})
+}
@@ -1,8 +1,11 @@
 package sync
 
 import (
+	"fmt"
+
 	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/messages"
 	"github.com/git-town/git-town/v14/src/vm/opcodes"
 	"github.com/git-town/git-town/v14/src/vm/program"
 )
@@ -10,12 +13,15 @@ import (
 // BranchProgram syncs the given branch.
 func BranchProgram(branch gitdomain.BranchInfo, args BranchProgramArgs) {
 	parentBranchInfo := args.BranchInfos.FindByLocalName(args.Config.Lineage.Parent(branch.LocalName))
-	parentOtherWorktree := parentBranchInfo != nil && parentBranchInfo.SyncStatus == gitdomain.SyncStatusOtherWorktree
+	parentOtherWorktree := parentBranchInfo != nil && parentBranchInfo.SyncStatus == gitdomain.SyncStatusOtherWorktree && args.Worktrees.FindByBranch(parentBranchInfo.LocalName) == nil
 	switch {
 	case branch.SyncStatus == gitdomain.SyncStatusDeletedAtRemote:
 		syncDeletedBranchProgram(args.Program, branch, parentOtherWorktree, args)
 	case branch.SyncStatus == gitdomain.SyncStatusOtherWorktree:
-		// Git Town doesn't sync branches that are active in another worktree
+		worktree := args.Worktrees.FindByBranch(branch.LocalName)
+		if worktree != nil {
+			worktreeBranchProgram(branch, *worktree, parentOtherWorktree, args)
+		}
 	default:
 		ExistingBranchProgram(args.Program, branch, parentOtherWorktree, args)
 	}
@@ -29,6 +35,8 @@ type BranchProgramArgs struct {
 	Program       *program.Program
 	PushBranch    bool
 	Remotes       gitdomain.Remotes
+	RootDir       gitdomain.RepoRootDir // the worktree in which Git Town runs
+	Worktrees     gitdomain.Worktrees   // the other worktrees, branches checked out in them get synced there
 }
 
 // ExistingBranchProgram provides the opcode to sync a particular branch.
@@ -41,13 +49,13 @@ func ExistingBranchProgram(list *program.Program, branch gitdomain.BranchInfo, p
 	list.Add(&opcodes.Checkout{Branch: branch.LocalName})
 	branchType := args.Config.BranchType(branch.LocalName)
 	switch branchType {
-	case configdomain.BranchTypeFeatureBranch:
+	case configdomain.BranchTypeFeatureBranch, configdomain.BranchTypePrototypeBranch:
 		FeatureBranchProgram(featureBranchArgs{
 			branch:              branch,
 			offline:             args.Config.Offline,
 			parentOtherWorktree: parentOtherWorktree,
 			program:             list,
-			syncStrategy:        args.Config.SyncFeatureStrategy,
+			syncStrategy:        args.Config.SyncFeatureStrategyForBranch(branch.LocalName),
 		})
 	case configdomain.BranchTypePerennialBranch, configdomain.BranchTypeMainBranch:
 		PerennialBranchProgram(branch, args)
@@ -57,7 +65,7 @@ func ExistingBranchProgram(list *program.Program, branch gitdomain.BranchInfo, p
 			offline:             args.Config.Offline,
 			parentOtherWorktree: parentOtherWorktree,
 			program:             list,
-			syncStrategy:        args.Config.SyncFeatureStrategy,
+			syncStrategy:        args.Config.SyncFeatureStrategyForBranch(branch.LocalName),
 		})
 	case configdomain.BranchTypeContributionBranch:
 		ContributionBranchProgram(args.Program, branch)
@@ -71,7 +79,7 @@ func ExistingBranchProgram(list *program.Program, branch gitdomain.BranchInfo, p
 		case isMainOrPerennialBranch:
 			list.Add(&opcodes.PushCurrentBranch{CurrentBranch: branch.LocalName})
 		default:
-			pushFeatureBranchProgram(list, branch.LocalName, args.Config.SyncFeatureStrategy)
+			pushFeatureBranchProgram(list, branch.LocalName, args.Config.SyncFeatureStrategyForBranch(branch.LocalName))
 		}
 	}
 }
@@ -81,7 +89,7 @@ func pullParentBranchOfCurrentFeatureBranchOpcode(args featureBranchArgs) {
 	switch args.syncStrategy {
 	case configdomain.SyncFeatureStrategyMerge:
 		args.program.Add(&opcodes.MergeParent{CurrentBranch: args.branch.LocalName, ParentActiveInOtherWorktree: args.parentOtherWorktree})
-	case configdomain.SyncFeatureStrategyRebase:
+	case configdomain.SyncFeatureStrategyRebase, configdomain.SyncFeatureStrategyCompress:
 		args.program.Add(&opcodes.RebaseParent{CurrentBranch: args.branch.LocalName, ParentActiveInOtherWorktree: args.parentOtherWorktree})
 	}
 }
@@ -90,7 +98,7 @@ func pushFeatureBranchProgram(list *program.Program, branch gitdomain.LocalBranc
 	switch syncFeatureStrategy {
 	case configdomain.SyncFeatureStrategyMerge:
 		list.Add(&opcodes.PushCurrentBranch{CurrentBranch: branch})
-	case configdomain.SyncFeatureStrategyRebase:
+	case configdomain.SyncFeatureStrategyRebase, configdomain.SyncFeatureStrategyCompress:
 		list.Add(&opcodes.ForcePushCurrentBranch{})
 	}
 }
@@ -104,3 +112,25 @@ func updateCurrentPerennialBranchOpcode(list *program.Program, otherBranch gitdo
 		list.Add(&opcodes.RebaseBranch{Branch: otherBranch.BranchName()})
 	}
 }
+
+// worktreeBranchProgram syncs the given branch inside the given worktree that has it checked out.
+func worktreeBranchProgram(branch gitdomain.BranchInfo, worktree gitdomain.Worktree, parentOtherWorktree bool, args BranchProgramArgs) {
+	if !branch.RemoteName.IsEmpty() && branch.RemoteSHA.IsEmpty() {
+		// the tracking branch is gone, but Git doesn't allow deleting a branch that is checked out in a worktree
+		args.Program.Add(&opcodes.QueueMessage{Message: fmt.Sprintf(messages.WorktreeBranchDeletedAtRemote, branch.LocalName, worktree.Path)})
+		return
+	}
+	if args.Config.IsMainOrPerennialBranch(branch.LocalName) && !args.Remotes.HasOrigin() {
+		return
+	}
+	args.Program.Add(&opcodes.ChangeWorktree{Path: worktree.Path})
+	if worktree.OpenChanges {
+		args.Program.Add(&opcodes.StashOpenChanges{})
+	}
+	ExistingBranchProgram(args.Program, branch, parentOtherWorktree, args)
+	if worktree.OpenChanges {
+		args.Program.Add(&opcodes.RestoreOpenChanges{})
+	}
+	args.Program.Add(&opcodes.ChangeWorktree{Path: args.RootDir})
+	args.Program.Add(&opcodes.QueueMessage{Message: fmt.Sprintf(messages.WorktreeBranchSynced, branch.LocalName, worktree.Path)})
+}
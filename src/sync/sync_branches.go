@@ -8,7 +8,12 @@ import (
 
 // BranchesProgram syncs all given branches.
 func BranchesProgram(args BranchesProgramArgs) {
-	for _, branch := range args.BranchesToSync {
+	branchesToSync := args.BranchesToSync
+	// syncing in parallel changes branches directly, so dry runs display the sequential sync instead
+	if args.Parallel && !args.DryRun {
+		branchesToSync = parallelBranchesProgram(args)
+	}
+	for _, branch := range branchesToSync {
 		BranchProgram(branch, args.BranchProgramArgs)
 	}
 	args.Program.Add(&opcodes.CheckoutIfExists{Branch: args.InitialBranch})
@@ -29,6 +34,7 @@ type BranchesProgramArgs struct {
 	DryRun         bool
 	HasOpenChanges bool
 	InitialBranch  gitdomain.LocalBranchName
+	Parallel       bool // whether to sync feature branches without checking them out, independent stacks concurrently
 	PreviousBranch gitdomain.LocalBranchName
 	ShouldPushTags bool
 }
@@ -0,0 +1,96 @@
+package sync
+
+import (
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/opcodes"
+)
+
+// ParallelStacks groups the given branches into stacks that can be synced independently of each other.
+// Each stack starts with a branch whose parent is not among the given branches, followed by its descendants.
+// The given branches must be ordered hierarchically.
+func ParallelStacks(branches gitdomain.LocalBranchNames, lineage configdomain.Lineage) []gitdomain.LocalBranchNames {
+	result := []gitdomain.LocalBranchNames{}
+	stackOf := map[gitdomain.LocalBranchName]int{}
+	for _, branch := range branches {
+		stack, hasStack := stackOf[lineage.Parent(branch)]
+		if !hasStack {
+			stack = len(result)
+			result = append(result, gitdomain.LocalBranchNames{})
+		}
+		result[stack] = append(result[stack], branch)
+		stackOf[branch] = stack
+	}
+	return result
+}
+
+// canSyncInParallel indicates whether the given branch can be synced without checking it out.
+// This is the case for feature branches using the "merge" sync strategy whose parent is either a main or perennial branch,
+// which get synced beforehand, or another branch that can be synced in parallel.
+func canSyncInParallel(branch gitdomain.BranchInfo, parallelBranches gitdomain.LocalBranchNames, args BranchesProgramArgs) bool {
+	if args.Config.BranchType(branch.LocalName) != configdomain.BranchTypeFeatureBranch {
+		return false
+	}
+	if args.Config.SyncFeatureStrategyForBranch(branch.LocalName) != configdomain.SyncFeatureStrategyMerge {
+		return false
+	}
+	if !isInThisWorktree(branch) {
+		return false
+	}
+	parent := args.Config.Lineage.Parent(branch.LocalName)
+	if parallelBranches.Contains(parent) {
+		return true
+	}
+	parentInfo := args.BranchInfos.FindByLocalName(parent)
+	return parentInfo != nil && args.Config.IsMainOrPerennialBranch(parent) && isInThisWorktree(*parentInfo)
+}
+
+// isInThisWorktree indicates whether the given branch exists in this worktree.
+func isInThisWorktree(branch gitdomain.BranchInfo) bool {
+	switch branch.SyncStatus {
+	case gitdomain.SyncStatusUpToDate, gitdomain.SyncStatusNotInSync, gitdomain.SyncStatusLocalOnly:
+		return true
+	case gitdomain.SyncStatusRemoteOnly, gitdomain.SyncStatusDeletedAtRemote, gitdomain.SyncStatusOtherWorktree:
+	}
+	return false
+}
+
+// parallelBranchesProgram adds the opcodes to sync the main and perennial branches among the given branches,
+// followed by the opcode that syncs all feature branches that don't need a checkout in parallel.
+// Provides the remaining branches, which need to be synced sequentially.
+func parallelBranchesProgram(args BranchesProgramArgs) gitdomain.BranchInfos {
+	parallelBranches := gitdomain.LocalBranchNames{}
+	result := gitdomain.BranchInfos{}
+	for _, branch := range args.BranchesToSync {
+		switch {
+		case args.Config.IsMainOrPerennialBranch(branch.LocalName):
+			BranchProgram(branch, args.BranchProgramArgs)
+		case canSyncInParallel(branch, parallelBranches, args):
+			parallelBranches = append(parallelBranches, branch.LocalName)
+		default:
+			result = append(result, branch)
+		}
+	}
+	if len(parallelBranches) == 0 {
+		return result
+	}
+	stacks := [][]opcodes.ParallelSyncBranch{}
+	for _, stack := range ParallelStacks(parallelBranches, args.Config.Lineage) {
+		branches := make([]opcodes.ParallelSyncBranch, len(stack))
+		for b, branchName := range stack {
+			branch := args.BranchesToSync.FindByLocalName(branchName)
+			trackingBranch := gitdomain.EmptyRemoteBranchName()
+			if branch.HasTrackingBranch() {
+				trackingBranch = branch.RemoteName
+			}
+			branches[b] = opcodes.ParallelSyncBranch{
+				Branch:         branchName,
+				Push:           args.PushBranch && args.Remotes.HasOrigin() && args.Config.IsOnline(),
+				TrackingBranch: trackingBranch,
+			}
+		}
+		stacks = append(stacks, branches)
+	}
+	args.Program.Add(&opcodes.SyncFeatureBranchesInParallel{Stacks: stacks})
+	return result
+}
@@ -0,0 +1,56 @@
+package sync_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/sync"
+	"github.com/shoenig/test/must"
+)
+
+func TestParallelStacks(t *testing.T) {
+	t.Parallel()
+
+	main := gitdomain.NewLocalBranchName("main")
+	production := gitdomain.NewLocalBranchName("production")
+	alpha := gitdomain.NewLocalBranchName("alpha")
+	alpha1 := gitdomain.NewLocalBranchName("alpha1")
+	alpha2 := gitdomain.NewLocalBranchName("alpha2")
+	beta := gitdomain.NewLocalBranchName("beta")
+	hotfix := gitdomain.NewLocalBranchName("hotfix")
+	lineage := configdomain.Lineage{
+		alpha:  main,
+		alpha1: alpha,
+		alpha2: alpha1,
+		beta:   main,
+		hotfix: production,
+	}
+
+	t.Run("groups the branches by their root branches", func(t *testing.T) {
+		t.Parallel()
+		have := sync.ParallelStacks(gitdomain.LocalBranchNames{alpha, beta, hotfix, alpha1, alpha2}, lineage)
+		want := []gitdomain.LocalBranchNames{
+			{alpha, alpha1, alpha2},
+			{beta},
+			{hotfix},
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("branches whose parent is not given start their own stack", func(t *testing.T) {
+		t.Parallel()
+		have := sync.ParallelStacks(gitdomain.LocalBranchNames{alpha1, alpha2, beta}, lineage)
+		want := []gitdomain.LocalBranchNames{
+			{alpha1, alpha2},
+			{beta},
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("no branches", func(t *testing.T) {
+		t.Parallel()
+		have := sync.ParallelStacks(gitdomain.LocalBranchNames{}, lineage)
+		must.Eq(t, []gitdomain.LocalBranchNames{}, have)
+	})
+}
@@ -14,6 +14,8 @@ func FeatureBranchProgram(args featureBranchArgs) {
 		syncFeatureBranchMergeProgram(args)
 	case configdomain.SyncFeatureStrategyRebase:
 		syncFeatureBranchRebaseProgram(args)
+	case configdomain.SyncFeatureStrategyCompress:
+		syncFeatureBranchCompressProgram(args)
 	}
 }
 
@@ -25,6 +27,22 @@ type featureBranchArgs struct {
 	syncStrategy        configdomain.SyncFeatureStrategy // the sync-feature-strategy
 }
 
+// syncs the given feature branch using the "compress" sync strategy
+func syncFeatureBranchCompressProgram(args featureBranchArgs) {
+	// integrate new commits from the tracking branch before rewriting the local commits
+	if args.branch.HasTrackingBranch() && !args.offline.Bool() {
+		args.program.Add(&opcodes.Merge{Branch: args.branch.RemoteName.BranchName()})
+	}
+	args.program.Add(&opcodes.CompressBranch{
+		CurrentBranch:               args.branch.LocalName,
+		ParentActiveInOtherWorktree: args.parentOtherWorktree,
+	})
+	args.program.Add(&opcodes.RebaseParent{
+		CurrentBranch:               args.branch.LocalName,
+		ParentActiveInOtherWorktree: args.parentOtherWorktree,
+	})
+}
+
 // syncs the given feature branch using the "merge" sync strategy
 func syncFeatureBranchMergeProgram(args featureBranchArgs) {
 	if args.branch.HasTrackingBranch() {
@@ -0,0 +1,203 @@
+// Package synccheck determines the outcome of syncing branches without changing any branch or the workspace.
+package synccheck
+
+import (
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/sync"
+	"github.com/git-town/git-town/v14/src/vm/opcodes"
+	"github.com/git-town/git-town/v14/src/vm/program"
+)
+
+// Check simulates syncing the given branches by running the merges and rebases
+// of the programs that "git town sync" would execute in memory, and provides the outcome for each branch.
+// Parent branches get checked before their children.
+func Check(args CheckArgs) ([]BranchResult, error) {
+	results := make([]BranchResult, 0, len(args.BranchesToSync))
+	simulated := map[gitdomain.LocalBranchName]gitdomain.SHA{}
+	conflicting := map[gitdomain.LocalBranchName]bool{}
+	for _, branch := range parentsFirst(args.BranchesToSync, args.Config.Lineage) {
+		result, err := checkBranch(branch, simulated, conflicting, args)
+		if err != nil {
+			return results, err
+		}
+		if result.Outcome == OutcomeConflict || result.Outcome == OutcomeBlocked {
+			conflicting[branch.LocalName] = true
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+type CheckArgs struct {
+	Backend        opcodes.InMemoryBackend
+	BranchInfos    gitdomain.BranchInfos // all branches in the repo
+	BranchesToSync gitdomain.BranchInfos
+	Config         *configdomain.FullConfig
+	InitialBranch  gitdomain.LocalBranchName
+	Remotes        gitdomain.Remotes
+	RootDir        gitdomain.RepoRootDir // the worktree in which Git Town runs
+	Worktrees      gitdomain.Worktrees   // the other worktrees
+}
+
+// BranchResult describes the outcome of syncing a particular branch.
+type BranchResult struct {
+	Approximate bool // whether the outcome involves rebases that got simulated as a whole rather than commit by commit
+	Branch      gitdomain.LocalBranchName
+	Conflicts   []string // the files that would have conflicts
+	ForcePush   bool     // whether syncing this branch requires a force-push
+	Outcome     Outcome
+	Parent      gitdomain.LocalBranchName // the parent branch that has conflicts, for blocked branches
+}
+
+// Outcome describes what would happen when syncing a branch.
+type Outcome string
+
+const (
+	OutcomeBlocked   Outcome = "blocked"    // the parent branch would have conflicts
+	OutcomeConflict  Outcome = "conflict"   // the branch would have conflicts
+	OutcomeDeleted   Outcome = "deleted"    // the branch was deleted at the remote
+	OutcomeSkipped   Outcome = "skipped"    // sync doesn't touch this branch
+	OutcomeUpToDate  Outcome = "up to date" // the branch is already in sync
+	OutcomeUpdatable Outcome = "updatable"  // the branch would get synced without conflicts
+)
+
+func checkBranch(branch gitdomain.BranchInfo, simulated map[gitdomain.LocalBranchName]gitdomain.SHA, conflicting map[gitdomain.LocalBranchName]bool, args CheckArgs) (BranchResult, error) {
+	result := BranchResult{
+		Approximate: false,
+		Branch:      branch.LocalName,
+		Conflicts:   []string{},
+		ForcePush:   false,
+		Outcome:     OutcomeSkipped,
+		Parent:      gitdomain.EmptyLocalBranchName(),
+	}
+	if branch.SyncStatus == gitdomain.SyncStatusDeletedAtRemote {
+		result.Outcome = OutcomeDeleted
+		return result, nil
+	}
+	if branch.LocalName.IsEmpty() {
+		return result, nil
+	}
+	switch args.Config.BranchType(branch.LocalName) {
+	case configdomain.BranchTypeMainBranch, configdomain.BranchTypePerennialBranch:
+		if !args.Remotes.HasOrigin() {
+			return result, nil
+		}
+	case configdomain.BranchTypeContributionBranch, configdomain.BranchTypeObservedBranch:
+	case configdomain.BranchTypeParkedBranch:
+		if branch.LocalName != args.InitialBranch {
+			return result, nil
+		}
+		if parent := args.Config.Lineage.Parent(branch.LocalName); conflicting[parent] {
+			return blockedResult(result, parent), nil
+		}
+	case configdomain.BranchTypeFeatureBranch, configdomain.BranchTypePrototypeBranch:
+		if parent := args.Config.Lineage.Parent(branch.LocalName); conflicting[parent] {
+			return blockedResult(result, parent), nil
+		}
+	}
+	trackingSHA := gitdomain.EmptySHA()
+	if branch.HasTrackingBranch() {
+		var err error
+		trackingSHA, err = args.Backend.SHAForBranch(branch.RemoteName.BranchName())
+		if err != nil {
+			return result, err
+		}
+	}
+	syncResult, err := opcodes.SyncInMemory(opcodes.SyncInMemoryArgs{
+		Backend: args.Backend,
+		Branch:  branch.LocalName,
+		Lineage: args.Config.Lineage,
+		Opcodes: syncOpcodes(branch, args),
+		Synced:  simulated,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Approximate = syncResult.Approximate
+	return finishResult(result, syncResult.Before, syncResult.After, trackingSHA, syncResult.Conflicts, simulated, args.Backend)
+}
+
+// blockedResult completes the given result for a branch whose given parent branch has conflicts.
+func blockedResult(result BranchResult, parent gitdomain.LocalBranchName) BranchResult {
+	result.Outcome = OutcomeBlocked
+	result.Parent = parent
+	return result
+}
+
+// finishResult completes the given result with the given outcome of the simulation.
+func finishResult(result BranchResult, before, after, trackingSHA gitdomain.SHA, conflicts []string, simulated map[gitdomain.LocalBranchName]gitdomain.SHA, backend opcodes.InMemoryBackend) (BranchResult, error) {
+	if len(conflicts) > 0 {
+		result.Outcome = OutcomeConflict
+		result.Conflicts = conflicts
+		return result, nil
+	}
+	simulated[result.Branch] = after
+	if after == before && (trackingSHA.IsEmpty() || after == trackingSHA) {
+		result.Outcome = OutcomeUpToDate
+		return result, nil
+	}
+	result.Outcome = OutcomeUpdatable
+	if !trackingSHA.IsEmpty() {
+		missingCommits, err := isMissingCommits(after, trackingSHA, backend)
+		if err != nil {
+			return result, err
+		}
+		result.ForcePush = missingCommits
+	}
+	return result, nil
+}
+
+// isMissingCommits indicates whether the given other commit contains commits that the given commit doesn't contain.
+func isMissingCommits(commit, other gitdomain.SHA, backend opcodes.InMemoryBackend) (bool, error) {
+	if commit == other {
+		return false, nil
+	}
+	contained, err := backend.IsAncestorCommit(other, commit)
+	return !contained, err
+}
+
+// parentsFirst provides the given branches ordered so that each branch comes after its parent.
+// Unlike Lineage.OrderHierarchically, this works for branches with unknown parents.
+func parentsFirst(branches gitdomain.BranchInfos, lineage configdomain.Lineage) gitdomain.BranchInfos {
+	result := make(gitdomain.BranchInfos, 0, len(branches))
+	added := map[gitdomain.LocalBranchName]bool{}
+	var add func(gitdomain.BranchInfo)
+	add = func(branch gitdomain.BranchInfo) {
+		if branch.LocalName.IsEmpty() {
+			result = append(result, branch)
+			return
+		}
+		if added[branch.LocalName] {
+			return
+		}
+		added[branch.LocalName] = true
+		if parentName := lineage.Parent(branch.LocalName); !parentName.IsEmpty() {
+			if parent := branches.FindByLocalName(parentName); parent != nil {
+				add(*parent)
+			}
+		}
+		result = append(result, branch)
+	}
+	for _, branch := range branches {
+		add(branch)
+	}
+	return result
+}
+
+// syncOpcodes provides the opcodes that "git town sync" runs to sync the given branch.
+// Pushing doesn't change the outcome of the sync, so they don't contain push opcodes.
+func syncOpcodes(branch gitdomain.BranchInfo, args CheckArgs) program.Program {
+	result := program.Program{}
+	sync.BranchProgram(branch, sync.BranchProgramArgs{
+		BranchInfos:   args.BranchInfos,
+		Config:        args.Config,
+		InitialBranch: args.InitialBranch,
+		Program:       &result,
+		PushBranch:    false,
+		Remotes:       args.Remotes,
+		RootDir:       args.RootDir,
+		Worktrees:     args.Worktrees,
+	})
+	return result
+}
@@ -0,0 +1,221 @@
+package synccheck_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/sync/synccheck"
+	"github.com/shoenig/test/must"
+)
+
+// fakeBackend simulates merges and rebases of commits named after their branches.
+// Integrating commits creates a commit whose name contains the names of both commits.
+type fakeBackend struct {
+	conflicts map[string][]string // the conflicting files when integrating the second given commit into the first one, keyed by "first second"
+	shas      map[string]string   // the commits of the branches
+}
+
+func (self fakeBackend) IsAncestorCommit(ancestor, commit gitdomain.SHA) (bool, error) {
+	return strings.Contains(commit.String(), ancestor.String()), nil
+}
+
+func (self fakeBackend) MergeInMemory(commit, other gitdomain.SHA, _ string) (gitdomain.SHA, []string, error) {
+	return self.integrate(commit, other)
+}
+
+func (self fakeBackend) RebaseInMemory(commit, onto gitdomain.SHA) (gitdomain.SHA, []string, error) {
+	return self.integrate(commit, onto)
+}
+
+func (self fakeBackend) SHAForBranch(name gitdomain.BranchName) (gitdomain.SHA, error) {
+	return gitdomain.NewSHA(self.shas[name.String()]), nil
+}
+
+func (self fakeBackend) integrate(commit, other gitdomain.SHA) (gitdomain.SHA, []string, error) {
+	if commit == other {
+		return commit, []string{}, nil
+	}
+	if conflicts, has := self.conflicts[commit.String()+" "+other.String()]; has {
+		return commit, conflicts, nil
+	}
+	return gitdomain.NewSHA(commit.String() + "0" + other.String()), []string{}, nil
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	main := gitdomain.NewLocalBranchName("main")
+	alpha := gitdomain.NewLocalBranchName("alpha")
+	beta := gitdomain.NewLocalBranchName("beta")
+	child := gitdomain.NewLocalBranchName("child")
+	config := configdomain.FullConfig{ //nolint:exhaustruct
+		Lineage: configdomain.Lineage{
+			alpha: main,
+			beta:  main,
+			child: beta,
+		},
+		MainBranch:            main,
+		SyncFeatureStrategy:   configdomain.SyncFeatureStrategyMerge,
+		SyncPerennialStrategy: configdomain.SyncPerennialStrategyMerge,
+	}
+	branches := gitdomain.BranchInfos{
+		{LocalName: main, LocalSHA: "111111", SyncStatus: gitdomain.SyncStatusUpToDate, RemoteName: main.TrackingBranch(), RemoteSHA: "111111"},
+		{LocalName: alpha, LocalSHA: "222222", SyncStatus: gitdomain.SyncStatusUpToDate, RemoteName: alpha.TrackingBranch(), RemoteSHA: "222222"},
+		{LocalName: beta, LocalSHA: "333333", SyncStatus: gitdomain.SyncStatusUpToDate, RemoteName: beta.TrackingBranch(), RemoteSHA: "333333"},
+		{LocalName: child, LocalSHA: "444444", SyncStatus: gitdomain.SyncStatusUpToDate, RemoteName: child.TrackingBranch(), RemoteSHA: "444444"},
+	}
+
+	t.Run("reports conflicts and the branches they block", func(t *testing.T) {
+		t.Parallel()
+		backend := fakeBackend{
+			conflicts: map[string][]string{
+				"333333 111111": {"file1", "file2"},
+			},
+			shas: map[string]string{
+				"main": "111111", "origin/main": "111111",
+				"alpha": "222222", "origin/alpha": "222222",
+				"beta": "333333", "origin/beta": "333333",
+				"child": "444444", "origin/child": "444444",
+			},
+		}
+		have, err := synccheck.Check(synccheck.CheckArgs{
+			Backend:        backend,
+			BranchInfos:    branches,
+			BranchesToSync: branches,
+			Config:         &config,
+			InitialBranch:  alpha,
+			Remotes:        gitdomain.Remotes{gitdomain.RemoteOrigin},
+			RootDir:        gitdomain.EmptyRepoRootDir(),
+			Worktrees:      gitdomain.Worktrees{},
+		})
+		must.NoError(t, err)
+		want := []synccheck.BranchResult{
+			{Approximate: false, Branch: main, Conflicts: []string{}, ForcePush: false, Outcome: synccheck.OutcomeUpToDate, Parent: ""},
+			{Approximate: false, Branch: alpha, Conflicts: []string{}, ForcePush: false, Outcome: synccheck.OutcomeUpdatable, Parent: ""},
+			{Approximate: false, Branch: beta, Conflicts: []string{"file1", "file2"}, ForcePush: false, Outcome: synccheck.OutcomeConflict, Parent: ""},
+			{Approximate: false, Branch: child, Conflicts: []string{}, ForcePush: false, Outcome: synccheck.OutcomeBlocked, Parent: beta},
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("branches without origin are skipped", func(t *testing.T) {
+		t.Parallel()
+		backend := fakeBackend{
+			conflicts: map[string][]string{},
+			shas: map[string]string{
+				"main":  "111111",
+				"alpha": "111111",
+			},
+		}
+		localBranches := gitdomain.BranchInfos{
+			{LocalName: main, LocalSHA: "", SyncStatus: gitdomain.SyncStatusLocalOnly, RemoteName: "", RemoteSHA: ""},
+			{LocalName: alpha, LocalSHA: "", SyncStatus: gitdomain.SyncStatusLocalOnly, RemoteName: "", RemoteSHA: ""},
+		}
+		have, err := synccheck.Check(synccheck.CheckArgs{
+			Backend:        backend,
+			BranchInfos:    localBranches,
+			BranchesToSync: localBranches,
+			Config:         &config,
+			InitialBranch:  alpha,
+			Remotes:        gitdomain.Remotes{},
+			RootDir:        gitdomain.EmptyRepoRootDir(),
+			Worktrees:      gitdomain.Worktrees{},
+		})
+		must.NoError(t, err)
+		want := []synccheck.BranchResult{
+			{Approximate: false, Branch: main, Conflicts: []string{}, ForcePush: false, Outcome: synccheck.OutcomeSkipped, Parent: ""},
+			{Approximate: false, Branch: alpha, Conflicts: []string{}, ForcePush: false, Outcome: synccheck.OutcomeUpToDate, Parent: ""},
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("rebases are approximate", func(t *testing.T) {
+		t.Parallel()
+		rebaseConfig := config
+		rebaseConfig.SyncFeatureStrategy = configdomain.SyncFeatureStrategyRebase
+		backend := fakeBackend{
+			conflicts: map[string][]string{
+				"333333 111111": {"file1"},
+			},
+			shas: map[string]string{
+				"main": "111111", "origin/main": "111111",
+				"alpha": "222222", "origin/alpha": "222222",
+				"beta": "333333", "origin/beta": "333333",
+			},
+		}
+		have, err := synccheck.Check(synccheck.CheckArgs{
+			Backend:        backend,
+			BranchInfos:    branches,
+			BranchesToSync: branches[:3],
+			Config:         &rebaseConfig,
+			InitialBranch:  alpha,
+			Remotes:        gitdomain.Remotes{gitdomain.RemoteOrigin},
+			RootDir:        gitdomain.EmptyRepoRootDir(),
+			Worktrees:      gitdomain.Worktrees{},
+		})
+		must.NoError(t, err)
+		want := []synccheck.BranchResult{
+			{Approximate: false, Branch: main, Conflicts: []string{}, ForcePush: false, Outcome: synccheck.OutcomeUpToDate, Parent: ""},
+			{Approximate: true, Branch: alpha, Conflicts: []string{}, ForcePush: false, Outcome: synccheck.OutcomeUpdatable, Parent: ""},
+			{Approximate: true, Branch: beta, Conflicts: []string{"file1"}, ForcePush: false, Outcome: synccheck.OutcomeConflict, Parent: ""},
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("perennial branches use the sync-perennial-strategy", func(t *testing.T) {
+		t.Parallel()
+		rebaseConfig := config
+		rebaseConfig.SyncPerennialStrategy = configdomain.SyncPerennialStrategyRebase
+		backend := fakeBackend{
+			conflicts: map[string][]string{},
+			shas: map[string]string{
+				"main": "111111", "origin/main": "555555",
+			},
+		}
+		have, err := synccheck.Check(synccheck.CheckArgs{
+			Backend:        backend,
+			BranchInfos:    branches,
+			BranchesToSync: branches[:1],
+			Config:         &rebaseConfig,
+			InitialBranch:  main,
+			Remotes:        gitdomain.Remotes{gitdomain.RemoteOrigin},
+			RootDir:        gitdomain.EmptyRepoRootDir(),
+			Worktrees:      gitdomain.Worktrees{},
+		})
+		must.NoError(t, err)
+		want := []synccheck.BranchResult{
+			{Approximate: true, Branch: main, Conflicts: []string{}, ForcePush: false, Outcome: synccheck.OutcomeUpdatable, Parent: ""},
+		}
+		must.Eq(t, want, have)
+	})
+
+	t.Run("checks parent branches before their children", func(t *testing.T) {
+		t.Parallel()
+		backend := fakeBackend{
+			conflicts: map[string][]string{},
+			shas: map[string]string{
+				"main": "111111", "origin/main": "555555",
+				"beta": "333333", "origin/beta": "333333",
+				"child": "444444", "origin/child": "444444",
+			},
+		}
+		have, err := synccheck.Check(synccheck.CheckArgs{
+			Backend:        backend,
+			BranchInfos:    branches,
+			BranchesToSync: gitdomain.BranchInfos{branches[3], branches[2], branches[0]},
+			Config:         &config,
+			InitialBranch:  child,
+			Remotes:        gitdomain.Remotes{gitdomain.RemoteOrigin},
+			RootDir:        gitdomain.EmptyRepoRootDir(),
+			Worktrees:      gitdomain.Worktrees{},
+		})
+		must.NoError(t, err)
+		names := gitdomain.LocalBranchNames{}
+		for _, result := range have {
+			names = append(names, result.Branch)
+		}
+		must.Eq(t, gitdomain.LocalBranchNames{main, beta, child}, names)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/git"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
 	"github.com/git-town/git-town/v14/src/messages"
 	lightInterpreter "github.com/git-town/git-town/v14/src/vm/interpreter/light"
 	"github.com/git-town/git-town/v14/src/vm/runstate"
@@ -16,17 +17,28 @@ import (
 // undoes the persisted runstate
 func Execute(args ExecuteArgs) error {
 	if args.RunState.DryRun {
-		return nil
+		return statefile.Rewind(args.RootDir)
+	}
+	worktrees, err := DetermineWorktrees(&args.Runner.Backend, args.RootDir, args.RunState)
+	if err != nil {
+		return err
 	}
 	program := CreateUndoForFinishedProgram(CreateUndoProgramArgs{
 		DryRun:         args.Runner.Config.DryRun,
 		HasOpenChanges: args.HasOpenChanges,
 		NoPushHook:     args.FullConfig.NoPushHook(),
+		RootDir:        args.RootDir,
 		Run:            args.Runner,
 		RunState:       args.RunState,
+		Worktrees:      worktrees,
+	})
+	lightInterpreter.Execute(lightInterpreter.ExecuteArgs{
+		Connector: args.Connector,
+		Lineage:   args.Lineage,
+		Prog:      program,
+		Runner:    args.Runner,
 	})
-	lightInterpreter.Execute(program, args.Runner, args.Lineage)
-	err := statefile.Delete(args.RootDir)
+	err = statefile.Rewind(args.RootDir)
 	if err != nil {
 		return fmt.Errorf(messages.RunstateDeleteProblem, err)
 	}
@@ -35,6 +47,7 @@ func Execute(args ExecuteArgs) error {
 }
 
 type ExecuteArgs struct {
+	Connector        hostingdomain.Connector
 	FullConfig       *configdomain.FullConfig
 	HasOpenChanges   bool
 	InitialStashSize gitdomain.StashSize
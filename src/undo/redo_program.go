@@ -0,0 +1,25 @@
+package undo
+
+import (
+	"github.com/git-town/git-town/v14/src/cmd/cmdhelpers"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/undo/undobranches"
+	"github.com/git-town/git-town/v14/src/undo/undoconfig"
+	"github.com/git-town/git-town/v14/src/undo/undostash"
+	"github.com/git-town/git-town/v14/src/vm/program"
+)
+
+// creates the program that re-applies the changes of a finished program that got undone
+func CreateRedoProgram(args CreateUndoProgramArgs) program.Program {
+	result := program.Program{}
+	result.AddProgram(undobranches.DetermineUndoBranchesProgram(args.RunState.EndBranchesSnapshot, args.RunState.BeginBranchesSnapshot, gitdomain.SHAs{}, &args.Run.Config.FullConfig, args.RootDir, args.Worktrees))
+	result.AddProgram(undoconfig.DetermineUndoConfigProgram(args.RunState.EndConfigSnapshot, args.RunState.BeginConfigSnapshot))
+	result.AddProgram(undostash.DetermineRedoStashProgram(args.RunState.BeginStashSize, args.RunState.EndStashSize))
+	cmdhelpers.Wrap(&result, cmdhelpers.WrapOptions{
+		DryRun:                   args.DryRun,
+		RunInGitRoot:             true,
+		StashOpenChanges:         args.HasOpenChanges,
+		PreviousBranchCandidates: gitdomain.LocalBranchNames{args.RunState.EndBranchesSnapshot.Active},
+	})
+	return result
+}
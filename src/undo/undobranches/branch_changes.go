@@ -1,12 +1,15 @@
 package undobranches
 
 import (
+	"slices"
+
 	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
 	"github.com/git-town/git-town/v14/src/gohacks/slice"
 	"github.com/git-town/git-town/v14/src/undo/undodomain"
 	"github.com/git-town/git-town/v14/src/vm/opcodes"
 	"github.com/git-town/git-town/v14/src/vm/program"
+	"github.com/git-town/git-town/v14/src/vm/shared"
 )
 
 // BranchChanges describes the changes made to the branches in a Git repo.
@@ -78,18 +81,22 @@ func (self BranchChanges) UndoProgram(args BranchChangesUndoProgramArgs) program
 	for _, branch := range omniChangedPerennials.BranchNames() {
 		change := omniChangedPerennials[branch]
 		if slice.Contains(args.UndoablePerennialCommits, change.After) {
-			result.Add(&opcodes.Checkout{Branch: branch})
-			result.Add(&opcodes.RevertCommit{SHA: change.After})
-			result.Add(&opcodes.PushCurrentBranch{CurrentBranch: branch})
+			branchProgram := program.Program{}
+			for _, commit := range undoablePerennialCommitsUntil(args.UndoablePerennialCommits, change.After) {
+				branchProgram.Add(&opcodes.RevertCommit{SHA: commit})
+			}
+			branchProgram.Add(&opcodes.PushCurrentBranch{CurrentBranch: branch})
+			result.Add(args.onBranch(branch, branchProgram...)...)
 		}
 	}
 
 	// reset omni-changed feature branches
 	for _, branch := range omniChangedFeatures.BranchNames() {
 		change := omniChangedFeatures[branch]
-		result.Add(&opcodes.Checkout{Branch: branch})
-		result.Add(&opcodes.ResetCurrentBranchToSHA{MustHaveSHA: change.After, SetToSHA: change.Before, Hard: true})
-		result.Add(&opcodes.ForcePushCurrentBranch{})
+		result.Add(args.onBranch(branch,
+			&opcodes.ResetCurrentBranchToSHA{MustHaveSHA: change.After, SetToSHA: change.Before, Hard: true},
+			&opcodes.ForcePushCurrentBranch{},
+		)...)
 	}
 
 	// re-create removed omni-branches
@@ -105,26 +112,30 @@ func (self BranchChanges) UndoProgram(args BranchChangesUndoProgramArgs) program
 	for _, inconsistentlyChangedPerennial := range inconsistentlyChangedPerennials {
 		if inconsistentlyChangedPerennial.After.IsOmniBranch() {
 			if slice.Contains(args.UndoablePerennialCommits, inconsistentlyChangedPerennial.After.LocalSHA) {
-				result.Add(&opcodes.Checkout{Branch: inconsistentlyChangedPerennial.Before.LocalName})
-				result.Add(&opcodes.RevertCommit{SHA: inconsistentlyChangedPerennial.After.LocalSHA})
-				result.Add(&opcodes.PushCurrentBranch{CurrentBranch: inconsistentlyChangedPerennial.After.LocalName})
+				branchProgram := program.Program{}
+				for _, commit := range undoablePerennialCommitsUntil(args.UndoablePerennialCommits, inconsistentlyChangedPerennial.After.LocalSHA) {
+					branchProgram.Add(&opcodes.RevertCommit{SHA: commit})
+				}
+				branchProgram.Add(&opcodes.PushCurrentBranch{CurrentBranch: inconsistentlyChangedPerennial.After.LocalName})
+				result.Add(args.onBranch(inconsistentlyChangedPerennial.Before.LocalName, branchProgram...)...)
 			}
 		}
 	}
 
 	// reset inconsintently changed feature branches
 	for _, inconsistentChange := range inconsistentChangedFeatures {
-		result.Add(&opcodes.Checkout{Branch: inconsistentChange.Before.LocalName})
-		result.Add(&opcodes.ResetCurrentBranchToSHA{
-			MustHaveSHA: inconsistentChange.After.LocalSHA,
-			SetToSHA:    inconsistentChange.Before.LocalSHA,
-			Hard:        true,
-		})
-		result.Add(&opcodes.ResetRemoteBranchToSHA{
-			Branch:      inconsistentChange.Before.RemoteName,
-			MustHaveSHA: inconsistentChange.After.RemoteSHA,
-			SetToSHA:    inconsistentChange.Before.RemoteSHA,
-		})
+		result.Add(args.onBranch(inconsistentChange.Before.LocalName,
+			&opcodes.ResetCurrentBranchToSHA{
+				MustHaveSHA: inconsistentChange.After.LocalSHA,
+				SetToSHA:    inconsistentChange.Before.LocalSHA,
+				Hard:        true,
+			},
+			&opcodes.ResetRemoteBranchToSHA{
+				Branch:      inconsistentChange.Before.RemoteName,
+				MustHaveSHA: inconsistentChange.After.RemoteSHA,
+				SetToSHA:    inconsistentChange.Before.RemoteSHA,
+			},
+		)...)
 	}
 
 	// remove remotely added branches
@@ -149,8 +160,9 @@ func (self BranchChanges) UndoProgram(args BranchChangesUndoProgramArgs) program
 	// reset locally changed branches
 	for _, localBranch := range self.LocalChanged.BranchNames() {
 		change := self.LocalChanged[localBranch]
-		result.Add(&opcodes.Checkout{Branch: localBranch})
-		result.Add(&opcodes.ResetCurrentBranchToSHA{MustHaveSHA: change.After, SetToSHA: change.Before, Hard: true})
+		result.Add(args.onBranch(localBranch,
+			&opcodes.ResetCurrentBranchToSHA{MustHaveSHA: change.After, SetToSHA: change.Before, Hard: true},
+		)...)
 	}
 
 	// re-create locally removed branches
@@ -194,5 +206,36 @@ type BranchChangesUndoProgramArgs struct {
 	BeginBranch              gitdomain.LocalBranchName
 	Config                   *configdomain.FullConfig
 	EndBranch                gitdomain.LocalBranchName
+	RootDir                  gitdomain.RepoRootDir // the worktree in which the undo runs
 	UndoablePerennialCommits []gitdomain.SHA
+	Worktrees                gitdomain.Worktrees // the other worktrees, branches checked out in them get undone there
+}
+
+// onBranch provides the given opcodes, which operate on the current branch, so that they run on the given branch.
+// Branches checked out in another worktree get changed inside that worktree.
+func (self BranchChangesUndoProgramArgs) onBranch(branch gitdomain.LocalBranchName, ops ...shared.Opcode) []shared.Opcode {
+	worktree := self.Worktrees.FindByBranch(branch)
+	if worktree == nil {
+		return append([]shared.Opcode{&opcodes.Checkout{Branch: branch}}, ops...)
+	}
+	result := []shared.Opcode{&opcodes.ChangeWorktree{Path: worktree.Path}}
+	if worktree.OpenChanges {
+		result = append(result, &opcodes.StashOpenChanges{})
+	}
+	result = append(result, ops...)
+	if worktree.OpenChanges {
+		result = append(result, &opcodes.RestoreOpenChanges{})
+	}
+	return append(result, &opcodes.ChangeWorktree{Path: self.RootDir})
+}
+
+// undoablePerennialCommitsUntil provides the given undoable commits up to and including the given last commit, newest first.
+// Git Town commands create undoable commits on only one perennial branch,
+// so all undoable commits before the given one exist on the same branch.
+func undoablePerennialCommitsUntil(undoableCommits []gitdomain.SHA, last gitdomain.SHA) []gitdomain.SHA {
+	result := []gitdomain.SHA{}
+	for c := slices.Index(undoableCommits, last); c >= 0; c-- {
+		result = append(result, undoableCommits[c])
+	}
+	return result
 }
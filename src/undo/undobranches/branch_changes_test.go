@@ -18,8 +18,9 @@ func TestChanges(t *testing.T) {
 	t.Run("local-only branch added", func(t *testing.T) {
 		t.Parallel()
 		before := gitdomain.BranchesSnapshot{
-			Branches: gitdomain.BranchInfos{},
-			Active:   gitdomain.NewLocalBranchName("main"),
+			Branches:  gitdomain.BranchInfos{},
+			Active:    gitdomain.NewLocalBranchName("main"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -31,7 +32,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.EmptySHA(),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("branch-1"),
+			Active:    gitdomain.NewLocalBranchName("branch-1"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		haveSpan := undobranches.NewBranchSpans(before, after)
 		wantSpan := undobranches.BranchSpans{
@@ -79,7 +81,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			&opcodes.Checkout{Branch: gitdomain.NewLocalBranchName("main")},
@@ -101,11 +105,13 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.EmptySHA(),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("branch-1"),
+			Active:    gitdomain.NewLocalBranchName("branch-1"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
-			Branches: gitdomain.BranchInfos{},
-			Active:   gitdomain.NewLocalBranchName("main"),
+			Branches:  gitdomain.BranchInfos{},
+			Active:    gitdomain.NewLocalBranchName("main"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -133,7 +139,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			&opcodes.CreateBranch{
@@ -165,7 +173,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.EmptySHA(),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -184,7 +193,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.EmptySHA(),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -222,7 +232,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			&opcodes.Checkout{Branch: gitdomain.NewLocalBranchName("feature-branch")},
@@ -242,6 +254,66 @@ func TestChanges(t *testing.T) {
 		must.Eq(t, wantProgram, haveProgram)
 	})
 
+	t.Run("local-only branch changed in another worktree", func(t *testing.T) {
+		t.Parallel()
+		before := gitdomain.BranchesSnapshot{
+			Branches: gitdomain.BranchInfos{
+				gitdomain.BranchInfo{
+					LocalName:  gitdomain.NewLocalBranchName("feature-branch"),
+					LocalSHA:   gitdomain.NewSHA("111111"),
+					SyncStatus: gitdomain.SyncStatusOtherWorktree,
+					RemoteName: gitdomain.EmptyRemoteBranchName(),
+					RemoteSHA:  gitdomain.EmptySHA(),
+				},
+			},
+			Active:    gitdomain.NewLocalBranchName("main"),
+			Worktrees: gitdomain.Worktrees{},
+		}
+		after := gitdomain.BranchesSnapshot{
+			Branches: gitdomain.BranchInfos{
+				gitdomain.BranchInfo{
+					LocalName:  gitdomain.NewLocalBranchName("feature-branch"),
+					LocalSHA:   gitdomain.NewSHA("222222"),
+					SyncStatus: gitdomain.SyncStatusOtherWorktree,
+					RemoteName: gitdomain.EmptyRemoteBranchName(),
+					RemoteSHA:  gitdomain.EmptySHA(),
+				},
+			},
+			Active:    gitdomain.NewLocalBranchName("main"),
+			Worktrees: gitdomain.Worktrees{},
+		}
+		haveChanges := undobranches.NewBranchSpans(before, after).Changes()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage: configdomain.Lineage{
+				gitdomain.NewLocalBranchName("feature-branch"): gitdomain.NewLocalBranchName("main"),
+			},
+			MainBranch: gitdomain.NewLocalBranchName("main"),
+		}
+		haveProgram := haveChanges.UndoProgram(undobranches.BranchChangesUndoProgramArgs{
+			BeginBranch:              before.Active,
+			Config:                   &config,
+			EndBranch:                after.Active,
+			RootDir:                  gitdomain.NewRepoRootDir("/repo"),
+			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees: gitdomain.Worktrees{
+				{Branch: gitdomain.NewLocalBranchName("feature-branch"), OpenChanges: true, Path: gitdomain.NewRepoRootDir("/feature")},
+			},
+		})
+		wantProgram := program.Program{
+			&opcodes.ChangeWorktree{Path: gitdomain.NewRepoRootDir("/feature")},
+			&opcodes.StashOpenChanges{},
+			&opcodes.ResetCurrentBranchToSHA{
+				MustHaveSHA: gitdomain.NewSHA("222222"),
+				SetToSHA:    gitdomain.NewSHA("111111"),
+				Hard:        true,
+			},
+			&opcodes.RestoreOpenChanges{},
+			&opcodes.ChangeWorktree{Path: gitdomain.NewRepoRootDir("/repo")},
+			&opcodes.CheckoutIfExists{Branch: gitdomain.NewLocalBranchName("main")},
+		}
+		must.Eq(t, wantProgram, haveProgram)
+	})
+
 	t.Run("local-only branch pushed to origin", func(t *testing.T) {
 		t.Parallel()
 		before := gitdomain.BranchesSnapshot{
@@ -261,7 +333,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.EmptySHA(),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -280,7 +353,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("222222"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -312,7 +386,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			&opcodes.DeleteTrackingBranch{
@@ -345,7 +421,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("222222"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("main"),
+			Active:    gitdomain.NewLocalBranchName("main"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -364,7 +441,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("222222"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("main"),
+			Active:    gitdomain.NewLocalBranchName("main"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -396,7 +474,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			&opcodes.DeleteLocalBranch{Branch: gitdomain.NewLocalBranchName("perennial-branch")},
@@ -409,8 +489,9 @@ func TestChanges(t *testing.T) {
 	t.Run("omnibranch added", func(t *testing.T) {
 		t.Parallel()
 		before := gitdomain.BranchesSnapshot{
-			Branches: gitdomain.BranchInfos{},
-			Active:   gitdomain.NewLocalBranchName("main"),
+			Branches:  gitdomain.BranchInfos{},
+			Active:    gitdomain.NewLocalBranchName("main"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -429,7 +510,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("222222"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -464,7 +546,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			&opcodes.DeleteTrackingBranch{
@@ -500,7 +584,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("222222"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -519,7 +604,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("222222"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -557,7 +643,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			&opcodes.Checkout{Branch: gitdomain.NewLocalBranchName("feature-branch")},
@@ -596,7 +684,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("333333"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -615,7 +704,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("444444"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -653,7 +743,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			// It doesn't reset the remote perennial branch since those are assumed to be protected against force-pushes
@@ -694,7 +786,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("333333"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -720,7 +813,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("666666"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -762,9 +856,11 @@ func TestChanges(t *testing.T) {
 			BeginBranch: before.Active,
 			Config:      &config,
 			EndBranch:   after.Active,
+			RootDir:     gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{
 				gitdomain.NewSHA("444444"),
 			},
+			Worktrees: gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			// revert the commit on the perennial branch
@@ -781,6 +877,63 @@ func TestChanges(t *testing.T) {
 		must.Eq(t, wantProgram, haveProgram)
 	})
 
+	t.Run("multiple branches shipped into the same perennial branch", func(t *testing.T) {
+		t.Parallel()
+		before := gitdomain.BranchesSnapshot{
+			Branches: gitdomain.BranchInfos{
+				gitdomain.BranchInfo{
+					LocalName:  gitdomain.NewLocalBranchName("main"),
+					LocalSHA:   gitdomain.NewSHA("111111"),
+					SyncStatus: gitdomain.SyncStatusUpToDate,
+					RemoteName: gitdomain.NewRemoteBranchName("origin/main"),
+					RemoteSHA:  gitdomain.NewSHA("111111"),
+				},
+			},
+			Active:    gitdomain.NewLocalBranchName("main"),
+			Worktrees: gitdomain.Worktrees{},
+		}
+		after := gitdomain.BranchesSnapshot{
+			Branches: gitdomain.BranchInfos{
+				gitdomain.BranchInfo{
+					LocalName:  gitdomain.NewLocalBranchName("main"),
+					LocalSHA:   gitdomain.NewSHA("333333"),
+					SyncStatus: gitdomain.SyncStatusUpToDate,
+					RemoteName: gitdomain.NewRemoteBranchName("origin/main"),
+					RemoteSHA:  gitdomain.NewSHA("333333"),
+				},
+			},
+			Active:    gitdomain.NewLocalBranchName("main"),
+			Worktrees: gitdomain.Worktrees{},
+		}
+		span := undobranches.NewBranchSpans(before, after)
+		haveChanges := span.Changes()
+		config := configdomain.FullConfig{ //nolint:exhaustruct
+			Lineage:    configdomain.Lineage{},
+			MainBranch: gitdomain.NewLocalBranchName("main"),
+		}
+		haveProgram := haveChanges.UndoProgram(undobranches.BranchChangesUndoProgramArgs{
+			BeginBranch: before.Active,
+			Config:      &config,
+			EndBranch:   after.Active,
+			RootDir:     gitdomain.EmptyRepoRootDir(),
+			UndoablePerennialCommits: []gitdomain.SHA{
+				gitdomain.NewSHA("222222"),
+				gitdomain.NewSHA("333333"),
+			},
+			Worktrees: gitdomain.Worktrees{},
+		})
+		wantProgram := program.Program{
+			// revert the commits on the perennial branch, newest first
+			&opcodes.Checkout{Branch: gitdomain.NewLocalBranchName("main")},
+			&opcodes.RevertCommit{SHA: gitdomain.NewSHA("333333")},
+			&opcodes.RevertCommit{SHA: gitdomain.NewSHA("222222")},
+			&opcodes.PushCurrentBranch{CurrentBranch: gitdomain.NewLocalBranchName("main")},
+			// check out the initial branch
+			&opcodes.CheckoutIfExists{Branch: gitdomain.NewLocalBranchName("main")},
+		}
+		must.Eq(t, wantProgram, haveProgram)
+	})
+
 	t.Run("upstream commit downloaded and branch shipped at the same time", func(t *testing.T) {
 		t.Parallel()
 		before := gitdomain.BranchesSnapshot{
@@ -807,7 +960,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("333333"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -826,7 +980,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("333333"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("main"),
+			Active:    gitdomain.NewLocalBranchName("main"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -862,9 +1017,11 @@ func TestChanges(t *testing.T) {
 			BeginBranch: before.Active,
 			Config:      &config,
 			EndBranch:   after.Active,
+			RootDir:     gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{
 				gitdomain.NewSHA("444444"),
 			},
+			Worktrees: gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			// revert the undoable commit on the main branch
@@ -899,7 +1056,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("222222"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -918,7 +1076,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("666666"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -980,7 +1139,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			// It doesn't revert the perennial branch because it cannot force-push the changes to the remote branch.
@@ -1019,7 +1180,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("444444"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -1038,7 +1200,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("444444"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -1076,7 +1239,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			&opcodes.Checkout{Branch: gitdomain.NewLocalBranchName("feature-branch")},
@@ -1115,7 +1280,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("333333"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -1134,7 +1300,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("444444"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -1172,7 +1339,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			// It doesn't revert the remote perennial branch because it cannot force-push the changes to it.
@@ -1205,7 +1374,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("222222"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -1224,7 +1394,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("222222"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("main"),
+			Active:    gitdomain.NewLocalBranchName("main"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -1256,7 +1427,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			&opcodes.CreateBranch{
@@ -1291,7 +1464,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("222222"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -1310,7 +1484,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.EmptySHA(),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -1342,7 +1517,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			// don't re-create the tracking branch for the perennial branch
@@ -1368,7 +1545,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("111111"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("main"),
+			Active:    gitdomain.NewLocalBranchName("main"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		after := gitdomain.BranchesSnapshot{
 			Branches: gitdomain.BranchInfos{
@@ -1387,7 +1565,8 @@ func TestChanges(t *testing.T) {
 					RemoteSHA:  gitdomain.NewSHA("222222"),
 				},
 			},
-			Active: gitdomain.NewLocalBranchName("feature-branch"),
+			Active:    gitdomain.NewLocalBranchName("feature-branch"),
+			Worktrees: gitdomain.Worktrees{},
 		}
 		span := undobranches.NewBranchSpans(before, after)
 		haveChanges := span.Changes()
@@ -1423,7 +1602,9 @@ func TestChanges(t *testing.T) {
 			BeginBranch:              before.Active,
 			Config:                   &config,
 			EndBranch:                after.Active,
+			RootDir:                  gitdomain.EmptyRepoRootDir(),
 			UndoablePerennialCommits: []gitdomain.SHA{},
+			Worktrees:                gitdomain.Worktrees{},
 		})
 		wantProgram := program.Program{
 			// No changes should happen here since all changes were syncs on perennial branches.
@@ -8,6 +8,19 @@ type BranchSpan struct {
 	After  gitdomain.BranchInfo // the status of the branch after Git Town ran
 }
 
+// BranchName provides the local name of the branch that this BranchSpan describes.
+func (self BranchSpan) BranchName() gitdomain.LocalBranchName {
+	for _, branchInfo := range []gitdomain.BranchInfo{self.Before, self.After} {
+		if !branchInfo.LocalName.IsEmpty() {
+			return branchInfo.LocalName
+		}
+		if !branchInfo.RemoteName.IsEmpty() {
+			return branchInfo.RemoteName.LocalBranchName()
+		}
+	}
+	return gitdomain.EmptyLocalBranchName()
+}
+
 func (self BranchSpan) IsInconsistentChange() bool {
 	return self.Before.HasTrackingBranch() && self.After.HasTrackingBranch() && self.LocalChanged() && self.RemoteChanged() && !self.IsOmniChange()
 }
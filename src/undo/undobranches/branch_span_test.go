@@ -11,6 +11,38 @@ import (
 func TestBranchSpan(t *testing.T) {
 	t.Parallel()
 
+	t.Run("BranchName", func(t *testing.T) {
+		t.Parallel()
+		t.Run("local branch", func(t *testing.T) {
+			t.Parallel()
+			bs := undobranches.BranchSpan{
+				Before: gitdomain.EmptyBranchInfo(),
+				After: gitdomain.BranchInfo{
+					LocalName:  gitdomain.NewLocalBranchName("branch-1"),
+					LocalSHA:   gitdomain.NewSHA("111111"),
+					SyncStatus: gitdomain.SyncStatusLocalOnly,
+					RemoteName: gitdomain.EmptyRemoteBranchName(),
+					RemoteSHA:  gitdomain.EmptySHA(),
+				},
+			}
+			must.EqOp(t, gitdomain.NewLocalBranchName("branch-1"), bs.BranchName())
+		})
+		t.Run("remote-only branch", func(t *testing.T) {
+			t.Parallel()
+			bs := undobranches.BranchSpan{
+				Before: gitdomain.BranchInfo{
+					LocalName:  gitdomain.EmptyLocalBranchName(),
+					LocalSHA:   gitdomain.EmptySHA(),
+					SyncStatus: gitdomain.SyncStatusRemoteOnly,
+					RemoteName: gitdomain.NewRemoteBranchName("origin/branch-1"),
+					RemoteSHA:  gitdomain.NewSHA("111111"),
+				},
+				After: gitdomain.EmptyBranchInfo(),
+			}
+			must.EqOp(t, gitdomain.NewLocalBranchName("branch-1"), bs.BranchName())
+		})
+	})
+
 	t.Run("IsOmniChange", func(t *testing.T) {
 		t.Parallel()
 		t.Run("is an omni change", func(t *testing.T) {
@@ -28,6 +28,18 @@ func NewBranchSpans(beforeSnapshot, afterSnapshot gitdomain.BranchesSnapshot) Br
 	return result
 }
 
+// BranchNames provides the names of the branches that have changes in this BranchSpans.
+func (self BranchSpans) BranchNames() gitdomain.LocalBranchNames {
+	result := gitdomain.LocalBranchNames{}
+	for _, branchSpan := range self {
+		if branchSpan.NoChanges() {
+			continue
+		}
+		result = result.AppendAllMissing(branchSpan.BranchName())
+	}
+	return result
+}
+
 // Changes describes the specific changes made in this BranchSpans.
 func (self BranchSpans) Changes() BranchChanges {
 	result := EmptyBranchChanges()
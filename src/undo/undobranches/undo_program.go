@@ -3,16 +3,30 @@ package undobranches
 import (
 	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/opcodes"
 	"github.com/git-town/git-town/v14/src/vm/program"
 )
 
-func DetermineUndoBranchesProgram(beginBranchesSnapshot, endBranchesSnapshot gitdomain.BranchesSnapshot, undoablePerennialCommits []gitdomain.SHA, fullConfig *configdomain.FullConfig) program.Program {
+func DetermineUndoBranchesProgram(beginBranchesSnapshot, endBranchesSnapshot gitdomain.BranchesSnapshot, undoablePerennialCommits []gitdomain.SHA, fullConfig *configdomain.FullConfig, rootDir gitdomain.RepoRootDir, worktrees gitdomain.Worktrees) program.Program {
+	result := program.Program{}
+	// remove the worktrees that got added so that their branches can be deleted
+	addedWorktrees := endBranchesSnapshot.Worktrees.NotIn(beginBranchesSnapshot.Worktrees)
+	for _, addedWorktree := range addedWorktrees {
+		result.Add(&opcodes.RemoveWorktree{Path: addedWorktree.Path})
+	}
 	branchSpans := NewBranchSpans(beginBranchesSnapshot, endBranchesSnapshot)
 	branchChanges := branchSpans.Changes()
-	return branchChanges.UndoProgram(BranchChangesUndoProgramArgs{
+	result.AddProgram(branchChanges.UndoProgram(BranchChangesUndoProgramArgs{
 		BeginBranch:              beginBranchesSnapshot.Active,
 		Config:                   fullConfig,
 		EndBranch:                endBranchesSnapshot.Active,
+		RootDir:                  rootDir,
 		UndoablePerennialCommits: undoablePerennialCommits,
-	})
+		Worktrees:                worktrees.NotIn(addedWorktrees),
+	}))
+	// re-add the worktrees that got removed once their branches exist again
+	for _, removedWorktree := range beginBranchesSnapshot.Worktrees.NotIn(endBranchesSnapshot.Worktrees) {
+		result.Add(&opcodes.AddWorktree{Branch: removedWorktree.Branch, Path: removedWorktree.Path})
+	}
+	return result
 }
@@ -25,3 +25,12 @@ func (self StashDiff) Program() program.Program {
 	}
 	return result
 }
+
+// RedoProgram provides the program that re-applies the changes described by this StashDiff after they have been undone.
+func (self StashDiff) RedoProgram() program.Program {
+	result := program.Program{}
+	for ; self.EntriesAdded > 0; self.EntriesAdded-- {
+		result.Add(&opcodes.StashOpenChanges{})
+	}
+	return result
+}
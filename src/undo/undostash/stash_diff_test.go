@@ -5,6 +5,8 @@ import (
 
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
 	"github.com/git-town/git-town/v14/src/undo/undostash"
+	"github.com/git-town/git-town/v14/src/vm/opcodes"
+	"github.com/git-town/git-town/v14/src/vm/program"
 	"github.com/shoenig/test/must"
 )
 
@@ -34,4 +36,23 @@ func TestStashDiff(t *testing.T) {
 			must.EqOp(t, want, have)
 		})
 	})
+
+	t.Run("RedoProgram", func(t *testing.T) {
+		t.Parallel()
+		t.Run("entries added", func(t *testing.T) {
+			t.Parallel()
+			diff := undostash.NewStashDiff(1, 3)
+			have := diff.RedoProgram()
+			want := program.Program{}
+			want.Add(&opcodes.StashOpenChanges{})
+			want.Add(&opcodes.StashOpenChanges{})
+			must.Eq(t, want, have)
+		})
+		t.Run("no entries added", func(t *testing.T) {
+			t.Parallel()
+			diff := undostash.NewStashDiff(1, 1)
+			have := diff.RedoProgram()
+			must.Eq(t, program.Program{}, have)
+		})
+	})
 }
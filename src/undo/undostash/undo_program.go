@@ -8,3 +8,7 @@ import (
 func DetermineUndoStashProgram(beginStashSize, endStashSize gitdomain.StashSize) program.Program {
 	return NewStashDiff(beginStashSize, endStashSize).Program()
 }
+
+func DetermineRedoStashProgram(beginStashSize, endStashSize gitdomain.StashSize) program.Program {
+	return NewStashDiff(beginStashSize, endStashSize).RedoProgram()
+}
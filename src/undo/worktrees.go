@@ -0,0 +1,21 @@
+package undo
+
+import (
+	"github.com/git-town/git-town/v14/src/git"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/runstate"
+)
+
+// DetermineWorktrees provides the other worktrees of the repo at the given root directory
+// if the given runstate involves branches that are checked out in other worktrees.
+func DetermineWorktrees(backend *git.BackendCommands, rootDir gitdomain.RepoRootDir, runState runstate.RunState) (gitdomain.Worktrees, error) {
+	for _, branches := range []gitdomain.BranchInfos{runState.BeginBranchesSnapshot.Branches, runState.EndBranchesSnapshot.Branches} {
+		for _, branch := range branches {
+			if branch.SyncStatus == gitdomain.SyncStatusOtherWorktree {
+				worktrees, err := backend.Worktrees()
+				return worktrees.Other(rootDir), err
+			}
+		}
+	}
+	return gitdomain.Worktrees{}, nil
+}
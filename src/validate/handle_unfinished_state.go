@@ -48,6 +48,7 @@ func HandleUnfinishedState(args UnfinishedStateArgs) (quit bool, err error) {
 		return continueRunstate(runState, args)
 	case dialog.ResponseUndo:
 		return true, undo.Execute(undo.ExecuteArgs{
+			Connector:        args.Connector,
 			FullConfig:       &args.Run.Config.FullConfig,
 			HasOpenChanges:   args.HasOpenChanges,
 			InitialStashSize: args.InitialStashSize,
@@ -112,7 +113,9 @@ func continueRunstate(runState *runstate.RunState, args UnfinishedStateArgs) (bo
 	})
 }
 
+// discardRunstate removes the unfinished run state
+// and makes the previous Git Town command the one to undo next.
 func discardRunstate(rootDir gitdomain.RepoRootDir) (bool, error) {
-	err := statefile.Delete(rootDir)
+	err := statefile.Rewind(rootDir)
 	return false, err
 }
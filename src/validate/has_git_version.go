@@ -18,3 +18,15 @@ func HasAcceptableGitVersion(majorVersion, minorVersion int) error {
 func IsAcceptableGitVersion(major, minor int) bool {
 	return major > 2 || (major == 2 && minor >= 30)
 }
+
+// CanConfigureGitThroughEnv indicates whether the given Git version reads configuration entries
+// from the GIT_CONFIG_COUNT, GIT_CONFIG_KEY_<n>, and GIT_CONFIG_VALUE_<n> environment variables.
+func CanConfigureGitThroughEnv(major, minor int) bool {
+	return major > 2 || (major == 2 && minor >= 31)
+}
+
+// CanMergeInMemory indicates whether the given Git version provides "git merge-tree --write-tree",
+// which merges branches without checking them out.
+func CanMergeInMemory(major, minor int) bool {
+	return major > 2 || (major == 2 && minor >= 38)
+}
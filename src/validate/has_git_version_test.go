@@ -7,6 +7,41 @@ import (
 	"github.com/shoenig/test/must"
 )
 
+func TestCanConfigureGitThroughEnv(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		major int
+		minor int
+		want  bool
+	}{
+		{2, 31, true},
+		{3, 0, true},
+		{2, 30, false},
+	}
+	for _, tt := range tests {
+		have := validate.CanConfigureGitThroughEnv(tt.major, tt.minor)
+		must.EqOp(t, tt.want, have)
+	}
+}
+
+func TestCanMergeInMemory(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		major int
+		minor int
+		want  bool
+	}{
+		{2, 38, true},
+		{3, 0, true},
+		{2, 37, false},
+		{2, 30, false},
+	}
+	for _, tt := range tests {
+		have := validate.CanMergeInMemory(tt.major, tt.minor)
+		must.EqOp(t, tt.want, have)
+	}
+}
+
 func TestIsAcceptableGitVersion(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
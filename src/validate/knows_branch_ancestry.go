@@ -25,6 +25,7 @@ func KnowsBranchAncestors(branch gitdomain.LocalBranchName, args KnowsBranchAnce
 			var err error
 			parent, aborted, err = dialog.Parent(dialog.ParentArgs{
 				Branch:          currentBranch,
+				DefaultChoice:   args.MainBranch,
 				DialogTestInput: args.DialogTestInputs.Next(),
 				Lineage:         args.Config.Lineage,
 				LocalBranches:   args.LocalBranches,
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/git-town/git-town/v14/src/cli/print"
 	"github.com/git-town/git-town/v14/src/config/gitconfig"
 	"github.com/git-town/git-town/v14/src/git"
@@ -38,6 +40,7 @@ func Finished(args FinishedArgs) error {
 		EndBranchesSnapshot:      gitdomain.EmptyBranchesSnapshot(),
 		EndConfigSnapshot:        configSnapshot,
 		EndStashSize:             0,
+		EndTime:                  time.Now(),
 		FinalUndoProgram:         program.Program{},
 		IsUndo:                   false,
 		RunProgram:               program.Program{},
@@ -16,16 +16,27 @@ import (
 // should they fail.
 func autoUndo(opcode shared.Opcode, runErr error, args ExecuteArgs) error {
 	print.Error(fmt.Errorf(messages.RunAutoUndo, runErr.Error()))
+	worktrees, err := undo.DetermineWorktrees(&args.Run.Backend, args.RootDir, *args.RunState)
+	if err != nil {
+		return err
+	}
 	undoProgram, err := undo.CreateUndoForRunningProgram(undo.CreateUndoProgramArgs{
 		DryRun:         args.Run.Config.DryRun,
 		HasOpenChanges: false,
 		NoPushHook:     args.FullConfig.NoPushHook(),
+		RootDir:        args.RootDir,
 		Run:            args.Run,
 		RunState:       *args.RunState,
+		Worktrees:      worktrees,
 	})
 	if err != nil {
 		return err
 	}
-	lightInterpreter.Execute(undoProgram, args.Run, args.Lineage)
+	lightInterpreter.Execute(lightInterpreter.ExecuteArgs{
+		Connector: args.Connector,
+		Lineage:   args.Lineage,
+		Prog:      undoProgram,
+		Runner:    args.Run,
+	})
 	return opcode.CreateAutomaticUndoError()
 }
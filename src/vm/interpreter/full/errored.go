@@ -6,8 +6,10 @@ import (
 
 	"github.com/git-town/git-town/v14/src/cli/print"
 	"github.com/git-town/git-town/v14/src/config/gitconfig"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
 	"github.com/git-town/git-town/v14/src/messages"
 	"github.com/git-town/git-town/v14/src/undo/undoconfig"
+	"github.com/git-town/git-town/v14/src/vm/opcodes"
 	"github.com/git-town/git-town/v14/src/vm/shared"
 	"github.com/git-town/git-town/v14/src/vm/statefile"
 )
@@ -36,11 +38,27 @@ func errored(failedOpcode shared.Opcode, runErr error, args ExecuteArgs) error {
 	if err != nil {
 		return err
 	}
-	args.RunState.AbortProgram.Add(failedOpcode.CreateAbortProgram()...)
+	// the problem might have happened while syncing a branch inside another worktree
+	worktree := args.Run.Backend.RootDirectory()
+	inOtherWorktree := !worktree.IsEmpty() && worktree != args.RootDir
+	if inOtherWorktree {
+		// the snapshot lists the worktrees other than the one it was taken in, describe them from the perspective of the initial worktree
+		args.RunState.EndBranchesSnapshot.Worktrees = append(args.RunState.EndBranchesSnapshot.Worktrees.Other(args.RootDir), gitdomain.Worktree{
+			Branch:      args.RunState.EndBranchesSnapshot.Active,
+			OpenChanges: false,
+			Path:        worktree,
+		})
+		args.RunState.AbortProgram.Add(worktreeAbortProgram(failedOpcode, worktree, args)...)
+	} else {
+		args.RunState.AbortProgram.Add(failedOpcode.CreateAbortProgram()...)
+	}
 	if failedOpcode.ShouldAutomaticallyUndoOnError() {
 		return autoUndo(failedOpcode, runErr, args)
 	}
 	args.RunState.RunProgram.Prepend(failedOpcode.CreateContinueProgram()...)
+	if inOtherWorktree {
+		args.RunState.RunProgram.Prepend(&opcodes.ChangeWorktree{Path: worktree})
+	}
 	err = args.RunState.MarkAsUnfinished(&args.Run.Backend)
 	if err != nil {
 		return err
@@ -62,6 +80,9 @@ func errored(failedOpcode shared.Opcode, runErr error, args ExecuteArgs) error {
 	}
 	print.Footer(args.Verbose, args.Run.CommandsCounter.Count(), args.Run.FinalMessages.Result())
 	message := runErr.Error()
+	if inOtherWorktree {
+		message += fmt.Sprintf(messages.WorktreeProblemLocation, worktree, args.RootDir)
+	}
 	if !args.RunState.IsUndo {
 		message += messages.UndoContinueGuidance
 	}
@@ -71,3 +92,19 @@ func errored(failedOpcode shared.Opcode, runErr error, args ExecuteArgs) error {
 	message += "\n"
 	return errors.New(message)
 }
+
+// worktreeAbortProgram provides the opcodes that abort the given opcode, which failed inside the given other worktree.
+// This includes restoring the open changes that Git Town has stashed away in that worktree.
+func worktreeAbortProgram(failedOpcode shared.Opcode, worktree gitdomain.RepoRootDir, args ExecuteArgs) []shared.Opcode {
+	result := []shared.Opcode{&opcodes.ChangeWorktree{Path: worktree}}
+	result = append(result, failedOpcode.CreateAbortProgram()...)
+	for _, opcode := range args.RunState.RunProgram {
+		if _, isChangeWorktree := opcode.(*opcodes.ChangeWorktree); isChangeWorktree {
+			break
+		}
+		if _, isRestore := opcode.(*opcodes.RestoreOpenChanges); isRestore {
+			result = append(result, opcode)
+		}
+	}
+	return append(result, &opcodes.ChangeWorktree{Path: args.RootDir})
+}
@@ -29,6 +29,7 @@ func Execute(args ExecuteArgs) error {
 			DialogTestInputs:                args.DialogTestInputs,
 			Lineage:                         args.Lineage,
 			PrependOpcodes:                  args.RunState.RunProgram.Prepend,
+			RegisterFinalUndoOpcode:         args.RunState.RegisterFinalUndoOpcode,
 			RegisterUndoablePerennialCommit: args.RunState.RegisterUndoablePerennialCommit,
 			Runner:                          args.Run,
 			UpdateInitialBranchLocalSHA:     args.InitialBranchesSnapshot.Branches.UpdateLocalSHA,
@@ -6,19 +6,21 @@ import (
 	"github.com/git-town/git-town/v14/src/cli/dialog/components"
 	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/git"
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
 	"github.com/git-town/git-town/v14/src/vm/program"
 	"github.com/git-town/git-town/v14/src/vm/shared"
 )
 
-func Execute(prog program.Program, runner *git.ProdRunner, lineage configdomain.Lineage) {
-	for _, opcode := range prog {
+func Execute(args ExecuteArgs) {
+	for _, opcode := range args.Prog {
 		err := opcode.Run(shared.RunArgs{
-			Connector:                       nil,
+			Connector:                       args.Connector,
 			DialogTestInputs:                nil,
-			Lineage:                         lineage,
+			Lineage:                         args.Lineage,
 			PrependOpcodes:                  nil,
+			RegisterFinalUndoOpcode:         nil,
 			RegisterUndoablePerennialCommit: nil,
-			Runner:                          runner,
+			Runner:                          args.Runner,
 			UpdateInitialBranchLocalSHA:     nil,
 		})
 		if err != nil {
@@ -26,3 +28,10 @@ func Execute(prog program.Program, runner *git.ProdRunner, lineage configdomain.
 		}
 	}
 }
+
+type ExecuteArgs struct {
+	Connector hostingdomain.Connector
+	Lineage   configdomain.Lineage
+	Prog      program.Program
+	Runner    *git.ProdRunner
+}
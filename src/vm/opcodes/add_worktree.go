@@ -0,0 +1,17 @@
+package opcodes
+
+import (
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// AddWorktree creates a new worktree at the given path that has the given branch checked out.
+type AddWorktree struct {
+	Branch gitdomain.LocalBranchName
+	Path   gitdomain.RepoRootDir
+	undeclaredOpcodeMethods
+}
+
+func (self *AddWorktree) Run(args shared.RunArgs) error {
+	return args.Runner.Frontend.AddWorktree(self.Path, self.Branch)
+}
@@ -0,0 +1,21 @@
+package opcodes
+
+import (
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// ChangeWorktree makes all subsequent opcodes run in the worktree at the given path.
+type ChangeWorktree struct {
+	Path gitdomain.RepoRootDir
+	undeclaredOpcodeMethods
+}
+
+func (self *ChangeWorktree) Run(args shared.RunArgs) error {
+	err := args.Runner.Frontend.NavigateToDir(self.Path)
+	if err != nil {
+		return err
+	}
+	args.Runner.Backend.CurrentBranchCache.Invalidate()
+	return nil
+}
@@ -0,0 +1,19 @@
+package opcodes
+
+import (
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// CloseProposal closes the proposal with the given number at the code hosting platform without merging it.
+type CloseProposal struct {
+	ProposalNumber int
+	undeclaredOpcodeMethods
+}
+
+func (self *CloseProposal) Run(args shared.RunArgs) error {
+	if args.Connector == nil {
+		return hostingdomain.UnsupportedServiceError()
+	}
+	return args.Connector.CloseProposal(self.ProposalNumber)
+}
@@ -0,0 +1,48 @@
+package opcodes
+
+import (
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// CompressBranch squashes the commits that the given branch has on top of the branch that is its parent at runtime
+// into a single commit with the message of the first of these commits.
+type CompressBranch struct {
+	CurrentBranch               gitdomain.LocalBranchName
+	ParentActiveInOtherWorktree bool
+	undeclaredOpcodeMethods
+}
+
+func (self *CompressBranch) Run(args shared.RunArgs) error {
+	parent := args.Lineage.Parent(self.CurrentBranch)
+	if parent.IsEmpty() {
+		return nil
+	}
+	var parentBranch gitdomain.BranchName
+	if self.ParentActiveInOtherWorktree {
+		parentBranch = parent.TrackingBranch().BranchName()
+	} else {
+		parentBranch = parent.BranchName()
+	}
+	mergeBase, err := args.Runner.Backend.MergeBase(self.CurrentBranch.BranchName(), parentBranch)
+	if err != nil {
+		return err
+	}
+	commits, err := args.Runner.Backend.CommitsSince(mergeBase)
+	if err != nil {
+		return err
+	}
+	if len(commits) < 2 {
+		// the branch is already compressed
+		return nil
+	}
+	message, err := args.Runner.Backend.CommitMessage(commits[0].SHA)
+	if err != nil {
+		return err
+	}
+	args.PrependOpcodes(
+		&ResetCommitsInCurrentBranch{Parent: mergeBase.Location()},
+		&CommitSquashedChanges{Message: message},
+	)
+	return nil
+}
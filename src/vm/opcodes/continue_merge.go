@@ -15,6 +15,9 @@ func (self *ContinueMerge) CreateContinueProgram() []shared.Opcode {
 }
 
 func (self *ContinueMerge) Run(args shared.RunArgs) error {
+	if err := args.Runner.Backend.RecordConflictResolutions(); err != nil {
+		return err
+	}
 	if args.Runner.Backend.HasMergeInProgress() {
 		return args.Runner.Frontend.CommitNoEdit()
 	}
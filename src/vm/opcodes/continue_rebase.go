@@ -21,6 +21,10 @@ func (self *ContinueRebase) CreateContinueProgram() []shared.Opcode {
 }
 
 func (self *ContinueRebase) Run(args shared.RunArgs) error {
+	err := args.Runner.Backend.RecordConflictResolutions()
+	if err != nil {
+		return err
+	}
 	repoStatus, err := args.Runner.Backend.RepoStatus()
 	if err != nil {
 		return err
@@ -48,17 +48,23 @@ func Types() []shared.Opcode {
 		&AbortMerge{},
 		&AbortRebase{},
 		&AddToPerennialBranches{},
+		&AddWorktree{},
 		&ChangeParent{},
+		&ChangeWorktree{},
 		&Checkout{},
 		&CheckoutIfExists{},
 		&CheckoutParent{},
 		&ChangeParent{},
+		&CloseProposal{},
 		&CommitOpenChanges{},
+		&CommitSquashedChanges{},
+		&CompressBranch{},
 		&ConnectorMergeProposal{},
 		&ContinueMerge{},
 		&ContinueRebase{},
 		&CreateAndCheckoutBranchExistingParent{},
 		&CreateBranch{},
+		&CreateBranchExistingParent{},
 		&CreateProposal{},
 		&CreateRemoteBranch{},
 		&CreateTrackingBranch{},
@@ -68,26 +74,34 @@ func Types() []shared.Opcode {
 		&DiscardOpenChanges{},
 		&EndOfBranchProgram{},
 		&EnsureHasShippableChanges{},
+		&EnsureProposal{},
 		&FetchUpstream{},
 		&ForcePushCurrentBranch{},
 		&DeleteBranchIfEmptyAtRuntime{},
 		&Merge{},
 		&MergeParent{},
 		&PreserveCheckoutHistory{},
+		&PrintProposals{},
 		&PullCurrentBranch{},
 		&PushCurrentBranch{},
+		&PushSharedLineage{},
 		&PushTags{},
+		&QueueMessage{},
 		&RebaseBranch{},
 		&RebaseFeatureTrackingBranch{},
+		&RebaseOnto{},
 		&RebaseParent{},
 		&RemoveBranchFromLineage{},
+		&RemoveBranchSyncStrategy{},
 		&RemoveFromPerennialBranches{},
 		&RemoveGlobalConfig{},
 		&RemoveLocalConfig{},
+		&RemoveWorktree{},
 		&ResetCurrentBranchToSHA{},
 		&ResetRemoteBranchToSHA{},
 		&RestoreOpenChanges{},
 		&RevertCommit{},
+		&SetBranchSyncStrategy{},
 		&SetExistingParent{},
 		&SetGlobalConfig{},
 		&SetLocalConfig{},
@@ -96,7 +110,9 @@ func Types() []shared.Opcode {
 		&SkipCurrentBranch{},
 		&StashOpenChanges{},
 		&SquashMerge{},
+		&SyncFeatureBranchesInParallel{},
 		&UndoLastCommit{},
+		&UpdateProposalStacks{},
 		&UpdateProposalTarget{},
 	}
 }
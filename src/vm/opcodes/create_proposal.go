@@ -1,14 +1,24 @@
 package opcodes
 
 import (
+	"fmt"
+
 	"github.com/git-town/git-town/v14/src/browser"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+	"github.com/git-town/git-town/v14/src/messages"
 	"github.com/git-town/git-town/v14/src/vm/shared"
 )
 
 // CreateProposal creates a new proposal for the current branch.
+// Without a title, it opens the page to create the proposal in the browser.
+// With a title, it creates the proposal via the API of the code hosting platform.
 type CreateProposal struct {
-	Branch gitdomain.LocalBranchName
+	Body      string
+	Branch    gitdomain.LocalBranchName
+	Draft     bool
+	NoBrowser bool
+	Title     string
 	undeclaredOpcodeMethods
 }
 
@@ -20,10 +30,32 @@ func (self *CreateProposal) CreateContinueProgram() []shared.Opcode {
 
 func (self *CreateProposal) Run(args shared.RunArgs) error {
 	parentBranch := args.Runner.Config.FullConfig.Lineage[self.Branch]
-	prURL, err := args.Connector.NewProposalURL(self.Branch, parentBranch)
+	if self.Title == "" {
+		prURL, err := args.Connector.NewProposalURL(self.Branch, parentBranch)
+		if err != nil {
+			return err
+		}
+		browser.Open(prURL, args.Runner.Frontend.Runner, args.Runner.Backend.Runner)
+		return nil
+	}
+	if args.Runner.Config.DryRun {
+		return nil
+	}
+	proposal, err := args.Connector.CreateProposal(hostingdomain.NewProposalData{
+		Body:   self.Body,
+		Branch: self.Branch,
+		Draft:  self.Draft,
+		Target: parentBranch,
+		Title:  self.Title,
+	})
 	if err != nil {
 		return err
 	}
-	browser.Open(prURL, args.Runner.Frontend.Runner, args.Runner.Backend.Runner)
+	args.RegisterFinalUndoOpcode(&CloseProposal{ProposalNumber: proposal.Number})
+	proposalURL := args.Connector.ProposalURL(proposal.Number)
+	fmt.Printf(messages.ProposalCreated, args.Connector.DefaultProposalMessage(proposal), proposalURL)
+	if !self.NoBrowser {
+		browser.Open(proposalURL, args.Runner.Frontend.Runner, args.Runner.Backend.Runner)
+	}
 	return nil
 }
@@ -0,0 +1,64 @@
+package opcodes
+
+import (
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// EnsureProposal makes sure that a proposal from the given branch into its parent branch exists.
+// If there is none, it creates one via the API of the code hosting platform,
+// titled with the first commit message of the branch.
+type EnsureProposal struct {
+	Branch gitdomain.LocalBranchName
+	Draft  bool
+	undeclaredOpcodeMethods
+}
+
+func (self *EnsureProposal) CreateContinueProgram() []shared.Opcode {
+	return []shared.Opcode{
+		self,
+	}
+}
+
+func (self *EnsureProposal) Run(args shared.RunArgs) error {
+	if args.Runner.Config.DryRun {
+		return nil
+	}
+	parentBranch := args.Lineage.Parent(self.Branch)
+	existingProposal, err := args.Connector.FindProposal(self.Branch, parentBranch)
+	if err != nil {
+		return err
+	}
+	if existingProposal != nil {
+		return nil
+	}
+	title, err := self.title(parentBranch, args)
+	if err != nil {
+		return err
+	}
+	proposal, err := args.Connector.CreateProposal(hostingdomain.NewProposalData{
+		Body:   "",
+		Branch: self.Branch,
+		Draft:  self.Draft,
+		Target: parentBranch,
+		Title:  title,
+	})
+	if err != nil {
+		return err
+	}
+	args.RegisterFinalUndoOpcode(&CloseProposal{ProposalNumber: proposal.Number})
+	return nil
+}
+
+// provides the title for the proposal to create
+func (self *EnsureProposal) title(parentBranch gitdomain.LocalBranchName, args shared.RunArgs) (string, error) {
+	commits, err := args.Runner.Backend.CommitsInFeatureBranch(self.Branch, parentBranch)
+	if err != nil {
+		return "", err
+	}
+	if len(commits) == 0 {
+		return self.Branch.String(), nil
+	}
+	return commits[0].Message.Parts().Subject, nil
+}
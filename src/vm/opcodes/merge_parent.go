@@ -35,5 +35,6 @@ func (self *MergeParent) Run(args shared.RunArgs) error {
 	} else {
 		branchToMerge = parent.BranchName()
 	}
-	return args.Runner.Frontend.MergeBranchNoEdit(branchToMerge)
+	err := args.Runner.Frontend.MergeBranchNoEdit(branchToMerge)
+	return continueWithRecordedResolutions(self.CurrentBranch, err, args)
 }
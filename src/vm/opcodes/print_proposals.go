@@ -0,0 +1,39 @@
+package opcodes
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/git-town/git-town/v14/src/cli/print"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// PrintProposals prints a table of the proposals for the given branches.
+type PrintProposals struct {
+	Branches gitdomain.LocalBranchNames
+	undeclaredOpcodeMethods
+}
+
+func (self *PrintProposals) Run(args shared.RunArgs) error {
+	if args.Runner.Config.DryRun {
+		return nil
+	}
+	fmt.Println()
+	print.Header(messages.ProposalsHeader)
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, branch := range self.Branches {
+		proposal, err := args.Connector.FindProposal(branch, args.Lineage.Parent(branch))
+		if err != nil {
+			return err
+		}
+		if proposal == nil {
+			fmt.Fprintf(writer, "  %s\t%s\t\n", branch, messages.ProposalNone)
+			continue
+		}
+		fmt.Fprintf(writer, "  %s\t%s\t%s\n", branch, args.Connector.DefaultProposalMessage(*proposal), args.Connector.ProposalURL(proposal.Number))
+	}
+	return writer.Flush()
+}
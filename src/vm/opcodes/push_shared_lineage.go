@@ -0,0 +1,57 @@
+package opcodes
+
+import (
+	"maps"
+
+	"github.com/git-town/git-town/v14/src/config/lineageshare"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// PushSharedLineage merges the local lineage into the lineage shared through the origin remote
+// and pushes the result to origin.
+type PushSharedLineage struct {
+	Base gitdomain.SHA // the shared lineage at the time of the last exchange
+	undeclaredOpcodeMethods
+}
+
+func (self *PushSharedLineage) Run(args shared.RunArgs) error {
+	if args.Runner.Config.DryRun {
+		return nil
+	}
+	base, err := lineageshare.Read(&args.Runner.Backend, self.Base)
+	if err != nil {
+		// the blob of an outdated shared lineage might have been garbage collected
+		base = nil
+	}
+	previousSHA := args.Runner.Backend.SharedLineageSHA()
+	sharedLineage, err := lineageshare.Read(&args.Runner.Backend, previousSHA)
+	if err != nil {
+		return err
+	}
+	branchesSnapshot, err := args.Runner.Backend.BranchesSnapshot()
+	if err != nil {
+		return err
+	}
+	published := lineageshare.Publish(lineageshare.PublishArgs{
+		Base:     base,
+		Branches: branchesSnapshot.Branches,
+		Local:    args.Runner.Config.FullConfig.Lineage,
+		Shared:   sharedLineage,
+	})
+	if maps.Equal(published, sharedLineage) {
+		return nil
+	}
+	content, err := lineageshare.Render(published)
+	if err != nil {
+		return err
+	}
+	sha, err := args.Runner.Backend.WriteBlob(content)
+	if err != nil {
+		return err
+	}
+	if err = args.Runner.Frontend.PushSharedLineage(sha, previousSHA, args.Runner.Config.FullConfig.NoPushHook()); err != nil {
+		return err
+	}
+	return args.Runner.Backend.SetSharedLineageTrackingRef(sha)
+}
@@ -0,0 +1,28 @@
+package opcodes
+
+import (
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// RebaseOnto moves the commits that the current branch has in addition to the given upstream
+// onto the given branch.
+type RebaseOnto struct {
+	BranchToRebaseOnto gitdomain.BranchName
+	Upstream           gitdomain.Location
+	undeclaredOpcodeMethods
+}
+
+func (self *RebaseOnto) CreateAbortProgram() []shared.Opcode {
+	return []shared.Opcode{&AbortRebase{}}
+}
+
+func (self *RebaseOnto) CreateContinueProgram() []shared.Opcode {
+	return []shared.Opcode{
+		&ContinueRebase{},
+	}
+}
+
+func (self *RebaseOnto) Run(args shared.RunArgs) error {
+	return args.Runner.Frontend.RebaseOnto(self.BranchToRebaseOnto, self.Upstream)
+}
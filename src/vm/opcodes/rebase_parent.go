@@ -35,5 +35,6 @@ func (self *RebaseParent) Run(args shared.RunArgs) error {
 	} else {
 		branchToRebase = parent.BranchName()
 	}
-	return args.Runner.Frontend.Rebase(branchToRebase)
+	err := args.Runner.Frontend.Rebase(branchToRebase)
+	return continueWithRecordedResolutions(self.CurrentBranch, err, args)
 }
@@ -23,6 +23,7 @@ func (self *RemoveBranchFromLineage) Run(args shared.RunArgs) error {
 		}
 	}
 	args.Runner.Backend.Config.RemoveParent(self.Branch)
+	args.Runner.Backend.Config.RemoveBranchSyncStrategy(self.Branch)
 	args.Lineage.RemoveBranch(self.Branch)
 	return nil
 }
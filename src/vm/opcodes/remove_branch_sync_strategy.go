@@ -0,0 +1,17 @@
+package opcodes
+
+import (
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// RemoveBranchSyncStrategy removes the sync strategy override of the branch with the given name.
+type RemoveBranchSyncStrategy struct {
+	Branch gitdomain.LocalBranchName
+	undeclaredOpcodeMethods
+}
+
+func (self *RemoveBranchSyncStrategy) Run(args shared.RunArgs) error {
+	args.Runner.Config.RemoveBranchSyncStrategy(self.Branch)
+	return nil
+}
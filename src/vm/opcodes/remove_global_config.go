@@ -11,5 +11,8 @@ type RemoveGlobalConfig struct {
 }
 
 func (self *RemoveGlobalConfig) Run(args shared.RunArgs) error {
+	if args.Runner.Config.DryRun {
+		return nil
+	}
 	return args.Runner.Config.GitConfig.RemoveGlobalConfigValue(self.Key)
 }
@@ -11,5 +11,8 @@ type RemoveLocalConfig struct {
 }
 
 func (self *RemoveLocalConfig) Run(args shared.RunArgs) error {
+	if args.Runner.Config.DryRun {
+		return nil
+	}
 	return args.Runner.Config.GitConfig.RemoveLocalConfigValue(self.Key)
 }
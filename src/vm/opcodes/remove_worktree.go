@@ -0,0 +1,16 @@
+package opcodes
+
+import (
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// RemoveWorktree removes the worktree at the given path.
+type RemoveWorktree struct {
+	Path gitdomain.RepoRootDir
+	undeclaredOpcodeMethods
+}
+
+func (self *RemoveWorktree) Run(args shared.RunArgs) error {
+	return args.Runner.Frontend.RemoveWorktree(self.Path)
+}
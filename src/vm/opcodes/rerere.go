@@ -0,0 +1,39 @@
+package opcodes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// continueWithRecordedResolutions finishes the merge or rebase of the given branch that failed with the given error
+// if Git's rerere feature has resolved all conflicts with resolutions recorded earlier.
+// Provides the given error if the failure was not caused by conflicts or if conflicts remain.
+func continueWithRecordedResolutions(branch gitdomain.LocalBranchName, failure error, args shared.RunArgs) error {
+	for failure != nil {
+		files, err := args.Runner.Backend.RerereResolvedFiles()
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			return failure
+		}
+		if err = args.Runner.Frontend.StageFiles(files...); err != nil {
+			return err
+		}
+		args.Runner.FinalMessages.Add(fmt.Sprintf(messages.RerereReplayed, strings.Join(files, ", "), branch))
+		repoStatus, err := args.Runner.Backend.RepoStatus()
+		if err != nil {
+			return err
+		}
+		if repoStatus.RebaseInProgress {
+			failure = args.Runner.Frontend.ContinueRebaseNoEdit()
+		} else {
+			failure = args.Runner.Frontend.CommitNoEdit()
+		}
+	}
+	return nil
+}
@@ -7,7 +7,7 @@ import (
 
 // ResetCommitsInCurrentBranch resets all commits in the current branch.
 type ResetCommitsInCurrentBranch struct {
-	Parent gitdomain.LocalBranchName
+	Parent gitdomain.Location
 	undeclaredOpcodeMethods
 }
 
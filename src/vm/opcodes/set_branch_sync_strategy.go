@@ -0,0 +1,18 @@
+package opcodes
+
+import (
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// SetBranchSyncStrategy configures the branch with the given name to sync with the given strategy.
+type SetBranchSyncStrategy struct {
+	Branch   gitdomain.LocalBranchName
+	Strategy configdomain.SyncFeatureStrategy
+	undeclaredOpcodeMethods
+}
+
+func (self *SetBranchSyncStrategy) Run(args shared.RunArgs) error {
+	return args.Runner.Config.SetBranchSyncStrategy(self.Branch, self.Strategy)
+}
@@ -12,5 +12,8 @@ type SetGlobalConfig struct {
 }
 
 func (self *SetGlobalConfig) Run(args shared.RunArgs) error {
+	if args.Runner.Config.DryRun {
+		return nil
+	}
 	return args.Runner.Config.GitConfig.SetGlobalConfigValue(self.Key, self.Value)
 }
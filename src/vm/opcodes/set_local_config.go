@@ -12,5 +12,8 @@ type SetLocalConfig struct {
 }
 
 func (self *SetLocalConfig) Run(args shared.RunArgs) error {
+	if args.Runner.Config.DryRun {
+		return nil
+	}
 	return args.Runner.Config.GitConfig.SetLocalConfigValue(self.Key, self.Value)
 }
@@ -0,0 +1,152 @@
+package opcodes
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// SyncFeatureBranchesInParallel syncs the given stacks of feature branches that use the "merge" sync strategy
+// without checking them out. It computes the merges in memory and syncs independent stacks concurrently.
+// Branches that cannot be merged without conflicts, and their descendants,
+// get synced afterwards through the regular opcodes that check them out.
+type SyncFeatureBranchesInParallel struct {
+	Stacks [][]ParallelSyncBranch // each stack contains a branch that has no parent in this opcode, followed by its descendants in hierarchical order
+	undeclaredOpcodeMethods
+}
+
+// ParallelSyncBranch describes a feature branch that SyncFeatureBranchesInParallel syncs.
+type ParallelSyncBranch struct {
+	Branch         gitdomain.LocalBranchName
+	Push           bool                       // whether to push the branch after syncing it
+	TrackingBranch gitdomain.RemoteBranchName // empty if the branch has no tracking branch
+}
+
+func (self *SyncFeatureBranchesInParallel) Run(args shared.RunArgs) error {
+	currentBranch, err := args.Runner.Backend.CurrentBranch()
+	if err != nil {
+		return err
+	}
+	results := make([][]parallelSyncResult, len(self.Stacks))
+	errs := make([]error, len(self.Stacks))
+	slots := make(chan struct{}, runtime.NumCPU())
+	var waitGroup sync.WaitGroup
+	for s, stack := range self.Stacks {
+		s, stack := s, stack
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			results[s], errs[s] = syncStackInMemory(stack, currentBranch, args)
+		}()
+	}
+	waitGroup.Wait()
+	if err = errors.Join(errs...); err != nil {
+		return err
+	}
+	branchesToPush := gitdomain.LocalBranchNames{}
+	fallback := []shared.Opcode{}
+	for _, stackResults := range results {
+		for _, result := range stackResults {
+			if !result.synced {
+				fallback = append(fallback, sequentialSyncOpcodes(result.branch)...)
+				continue
+			}
+			if result.after != result.before {
+				err = args.Runner.Backend.UpdateBranchRef(result.branch.Branch, result.after, result.before)
+				if err != nil {
+					return err
+				}
+			}
+			if !result.branch.Push {
+				continue
+			}
+			if result.branch.TrackingBranch.IsEmpty() {
+				err = args.Runner.Frontend.CreateTrackingBranch(result.branch.Branch, gitdomain.RemoteOrigin, args.Runner.Config.FullConfig.NoPushHook())
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			shouldPush, err := args.Runner.Backend.ShouldPushBranch(result.branch.Branch, result.branch.TrackingBranch)
+			if err != nil {
+				return err
+			}
+			if shouldPush {
+				branchesToPush = append(branchesToPush, result.branch.Branch)
+			}
+		}
+	}
+	if len(branchesToPush) > 0 {
+		err = args.Runner.Frontend.PushBranches(branchesToPush, args.Runner.Config.FullConfig.NoPushHook())
+		if err != nil {
+			return err
+		}
+	}
+	args.PrependOpcodes(fallback...)
+	return nil
+}
+
+// parallelSyncResult describes the outcome of syncing a branch in memory.
+type parallelSyncResult struct {
+	after  gitdomain.SHA // the commit the branch points to after syncing it
+	before gitdomain.SHA // the commit the branch points to before syncing it
+	branch ParallelSyncBranch
+	synced bool // whether the branch could be synced in memory, if not it needs to be synced sequentially
+}
+
+// sequentialSyncOpcodes provides the opcodes that sync the given branch the regular way, by checking it out.
+func sequentialSyncOpcodes(branch ParallelSyncBranch) []shared.Opcode {
+	result := []shared.Opcode{&Checkout{Branch: branch.Branch}}
+	if !branch.TrackingBranch.IsEmpty() {
+		result = append(result, &Merge{Branch: branch.TrackingBranch.BranchName()})
+	}
+	result = append(result, &MergeParent{CurrentBranch: branch.Branch, ParentActiveInOtherWorktree: false})
+	if branch.Push {
+		if branch.TrackingBranch.IsEmpty() {
+			result = append(result, &CreateTrackingBranch{Branch: branch.Branch})
+		} else {
+			result = append(result, &PushCurrentBranch{CurrentBranch: branch.Branch})
+		}
+	}
+	return append(result, &EndOfBranchProgram{})
+}
+
+// syncStackInMemory determines the new commits of the branches in the given stack.
+// The currently checked out branch and branches whose parent cannot be synced in memory need to be synced sequentially.
+func syncStackInMemory(stack []ParallelSyncBranch, currentBranch gitdomain.LocalBranchName, args shared.RunArgs) ([]parallelSyncResult, error) {
+	result := make([]parallelSyncResult, 0, len(stack))
+	synced := map[gitdomain.LocalBranchName]gitdomain.SHA{}
+	unsynced := map[gitdomain.LocalBranchName]bool{}
+	for _, branch := range stack {
+		parent := args.Lineage.Parent(branch.Branch)
+		if branch.Branch == currentBranch || unsynced[parent] {
+			unsynced[branch.Branch] = true
+			result = append(result, parallelSyncResult{after: gitdomain.EmptySHA(), before: gitdomain.EmptySHA(), branch: branch, synced: false})
+			continue
+		}
+		syncResult, err := SyncInMemory(SyncInMemoryArgs{
+			Backend: &args.Runner.Backend,
+			Branch:  branch.Branch,
+			Lineage: args.Lineage,
+			Opcodes: sequentialSyncOpcodes(branch),
+			Synced:  synced,
+		})
+		if err != nil {
+			return result, err
+		}
+		before, after := syncResult.Before, syncResult.After
+		if len(syncResult.Conflicts) > 0 {
+			unsynced[branch.Branch] = true
+			result = append(result, parallelSyncResult{after: before, before: before, branch: branch, synced: false})
+			continue
+		}
+		synced[branch.Branch] = after
+		result = append(result, parallelSyncResult{after: after, before: before, branch: branch, synced: true})
+	}
+	return result, nil
+}
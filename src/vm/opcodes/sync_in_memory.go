@@ -0,0 +1,144 @@
+package opcodes
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// InMemoryBackend describes the Git operations needed to sync branches in memory.
+type InMemoryBackend interface {
+	IsAncestorCommit(ancestor, commit gitdomain.SHA) (bool, error)
+	MergeInMemory(commit, other gitdomain.SHA, message string) (gitdomain.SHA, []string, error)
+	RebaseInMemory(commit, onto gitdomain.SHA) (gitdomain.SHA, []string, error)
+	SHAForBranch(name gitdomain.BranchName) (gitdomain.SHA, error)
+}
+
+type SyncInMemoryArgs struct {
+	Backend InMemoryBackend
+	Branch  gitdomain.LocalBranchName // the branch to sync
+	Lineage configdomain.Lineage
+	Opcodes []shared.Opcode                             // the opcodes that sync the branch when it is checked out
+	Synced  map[gitdomain.LocalBranchName]gitdomain.SHA // the commits that the branches synced in memory so far point to
+}
+
+// InMemorySyncResult describes the outcome of syncing a branch in memory.
+type InMemorySyncResult struct {
+	After       gitdomain.SHA // the commit the branch points to after syncing it
+	Approximate bool          // whether the outcome involves rebases that got simulated as a whole rather than commit by commit
+	Before      gitdomain.SHA // the commit the branch points to before syncing it
+	Conflicts   []string      // the files that have conflicts, syncing stops at the first opcode that has conflicts
+}
+
+// SyncInMemory determines the commit that the given branch points to after running the given opcodes on it,
+// without checking out the branch or changing any branch or the workspace.
+// Only the opcodes that merge or rebase the branch affect the outcome, all other opcodes get ignored.
+func SyncInMemory(args SyncInMemoryArgs) (InMemorySyncResult, error) {
+	before, err := args.Backend.SHAForBranch(args.Branch.BranchName())
+	result := InMemorySyncResult{
+		After:       before,
+		Approximate: false,
+		Before:      before,
+		Conflicts:   []string{},
+	}
+	if err != nil {
+		return result, err
+	}
+	for _, opcode := range args.Opcodes {
+		switch opcode := opcode.(type) {
+		case *Merge:
+			err = mergeInMemory(&result, opcode.Branch, args)
+		case *MergeParent:
+			if parent, hasParent := inMemoryParent(opcode.CurrentBranch, opcode.ParentActiveInOtherWorktree, args.Lineage); hasParent {
+				err = mergeInMemory(&result, parent, args)
+			}
+		case *RebaseBranch:
+			err = rebaseInMemory(&result, opcode.Branch, args)
+		case *RebaseFeatureTrackingBranch:
+			err = rebaseTrackingBranchInMemory(&result, opcode.RemoteBranch, args)
+		case *RebaseParent:
+			if parent, hasParent := inMemoryParent(opcode.CurrentBranch, opcode.ParentActiveInOtherWorktree, args.Lineage); hasParent {
+				err = rebaseInMemory(&result, parent, args)
+			}
+		}
+		if err != nil || len(result.Conflicts) > 0 {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// inMemoryParent provides the branch that the MergeParent and RebaseParent opcodes integrate into the given branch.
+func inMemoryParent(branch gitdomain.LocalBranchName, parentActiveInOtherWorktree bool, lineage configdomain.Lineage) (gitdomain.BranchName, bool) {
+	parent := lineage.Parent(branch)
+	if parent.IsEmpty() {
+		return "", false
+	}
+	if parentActiveInOtherWorktree {
+		return parent.TrackingBranch().BranchName(), true
+	}
+	return parent.BranchName(), true
+}
+
+// inMemorySHA provides the commit that the given branch points to, taking the branches synced in memory into account.
+func inMemorySHA(branch gitdomain.BranchName, args SyncInMemoryArgs) (gitdomain.SHA, error) {
+	if branch.IsLocal() {
+		if sha, has := args.Synced[branch.LocalName()]; has {
+			return sha, nil
+		}
+	}
+	return args.Backend.SHAForBranch(branch)
+}
+
+// mergeInMemory merges the given branch into the branch described by the given result,
+// using the commit message that "git merge --no-edit" would use.
+func mergeInMemory(result *InMemorySyncResult, branch gitdomain.BranchName, args SyncInMemoryArgs) error {
+	other, err := inMemorySHA(branch, args)
+	if err != nil {
+		return err
+	}
+	var message string
+	if branch.IsLocal() {
+		message = fmt.Sprintf("Merge branch '%s' into %s", branch, args.Branch)
+	} else {
+		message = fmt.Sprintf("Merge remote-tracking branch '%s' into %s", branch, args.Branch)
+	}
+	result.After, result.Conflicts, err = args.Backend.MergeInMemory(result.After, other, message)
+	return err
+}
+
+// rebaseInMemory rebases the branch described by the given result onto the given branch.
+// Rebases that replay commits get simulated as a whole rather than commit by commit,
+// so they mark the given result as approximate.
+func rebaseInMemory(result *InMemorySyncResult, branch gitdomain.BranchName, args SyncInMemoryArgs) error {
+	onto, err := inMemorySHA(branch, args)
+	if err != nil {
+		return err
+	}
+	commit := result.After
+	result.After, result.Conflicts, err = args.Backend.RebaseInMemory(commit, onto)
+	if len(result.Conflicts) > 0 || (result.After != commit && result.After != onto) {
+		result.Approximate = true
+	}
+	return err
+}
+
+// rebaseTrackingBranchInMemory rebases the branch described by the given result onto the given tracking branch
+// if the tracking branch contains commits that the branch didn't contain before syncing it,
+// like RebaseFeatureTrackingBranch does.
+func rebaseTrackingBranchInMemory(result *InMemorySyncResult, trackingBranch gitdomain.RemoteBranchName, args SyncInMemoryArgs) error {
+	trackingSHA, err := args.Backend.SHAForBranch(trackingBranch.BranchName())
+	if err != nil {
+		return err
+	}
+	if trackingSHA == result.Before {
+		return nil
+	}
+	integrated, err := args.Backend.IsAncestorCommit(trackingSHA, result.Before)
+	if err != nil || integrated {
+		return err
+	}
+	return rebaseInMemory(result, trackingBranch.BranchName(), args)
+}
@@ -0,0 +1,128 @@
+package opcodes
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v14/src/config/configdomain"
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/hosting/hostingdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+	"github.com/git-town/git-town/v14/src/vm/shared"
+)
+
+// UpdateProposalStacks updates the stack overview in the bodies of the proposals
+// for all branches in the lineage of the given branch.
+// The stack overview is informational only, so problems talking to the code hosting platform
+// result in a warning instead of stopping the Git Town command.
+type UpdateProposalStacks struct {
+	Branch gitdomain.LocalBranchName
+	undeclaredOpcodeMethods
+}
+
+func (self *UpdateProposalStacks) Run(args shared.RunArgs) error {
+	if args.Runner.Config.DryRun || args.Connector == nil {
+		return nil
+	}
+	if err := self.update(args); err != nil {
+		args.Runner.FinalMessages.Add(fmt.Sprintf(messages.ProposalStackUpdateProblem, self.Branch, err))
+	}
+	return nil
+}
+
+// provides only the given branches that exist in the local repository
+func (self *UpdateProposalStacks) existingBranches(branches gitdomain.LocalBranchNames, args shared.RunArgs) gitdomain.LocalBranchNames {
+	result := make(gitdomain.LocalBranchNames, 0, len(branches))
+	for _, branch := range branches {
+		if args.Runner.Backend.HasLocalBranch(branch) {
+			result = append(result, branch)
+		}
+	}
+	return result
+}
+
+// provides the stack section for the proposal of the given branch,
+// or an empty string if the branch isn't part of a stack
+func (self *UpdateProposalStacks) renderStack(branch gitdomain.LocalBranchName, finder *proposalFinder, args shared.RunArgs) (string, error) {
+	stackBranches := self.existingBranches(args.Lineage.BranchLineageWithoutRoot(branch), args)
+	if len(stackBranches) < 2 {
+		return "", nil
+	}
+	ancestors := args.Lineage.Ancestors(branch)
+	entries := make([]hostingdomain.ProposalStackEntry, 0, len(stackBranches)+1)
+	if len(ancestors) > 0 {
+		entries = append(entries, hostingdomain.ProposalStackEntry{
+			Depth:     0,
+			IsCurrent: false,
+			Text:      ancestors[0].String(),
+			URL:       "",
+		})
+	}
+	for _, stackBranch := range stackBranches {
+		entry := hostingdomain.ProposalStackEntry{
+			Depth:     len(args.Lineage.Ancestors(stackBranch)),
+			IsCurrent: stackBranch == branch,
+			Text:      stackBranch.String(),
+			URL:       "",
+		}
+		proposal, err := finder.find(stackBranch)
+		if err != nil {
+			return "", err
+		}
+		if proposal != nil {
+			entry.Text = args.Connector.DefaultProposalMessage(*proposal)
+			entry.URL = args.Connector.ProposalURL(proposal.Number)
+		}
+		entries = append(entries, entry)
+	}
+	return hostingdomain.RenderProposalStack(entries), nil
+}
+
+// updates the stack overview in the proposals of all branches in the lineage of the given branch
+func (self *UpdateProposalStacks) update(args shared.RunArgs) error {
+	finder := proposalFinder{
+		connector: args.Connector,
+		lineage:   args.Lineage,
+		proposals: map[gitdomain.LocalBranchName]*hostingdomain.Proposal{},
+	}
+	for _, branch := range self.existingBranches(args.Lineage.BranchLineageWithoutRoot(self.Branch), args) {
+		proposal, err := finder.find(branch)
+		if err != nil {
+			return err
+		}
+		if proposal == nil {
+			continue
+		}
+		stack, err := self.renderStack(branch, &finder, args)
+		if err != nil {
+			return err
+		}
+		newBody := hostingdomain.UpdateProposalStack(proposal.Body, stack)
+		if newBody == proposal.Body {
+			continue
+		}
+		if err = args.Connector.UpdateProposalBody(proposal.Number, newBody); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// proposalFinder looks up the proposals of branches into their parent branch,
+// looking up each proposal only once.
+type proposalFinder struct {
+	connector hostingdomain.Connector
+	lineage   configdomain.Lineage
+	proposals map[gitdomain.LocalBranchName]*hostingdomain.Proposal
+}
+
+func (self *proposalFinder) find(branch gitdomain.LocalBranchName) (*hostingdomain.Proposal, error) {
+	if proposal, found := self.proposals[branch]; found {
+		return proposal, nil
+	}
+	proposal, err := self.connector.FindProposal(branch, self.lineage.Parent(branch))
+	if err != nil {
+		return nil, err
+	}
+	self.proposals[branch] = proposal
+	return proposal, nil
+}
@@ -0,0 +1,29 @@
+package runstate
+
+// HistoryCapacity defines how many finished runstates the undo history of a repository keeps.
+const HistoryCapacity = 20
+
+// History is the journal of finished runstates of a repository,
+// ordered from the oldest to the most recent runstate.
+// It allows undoing several Git Town commands one after the other.
+type History []RunState
+
+// Pop removes the most recent runstate from this History and provides it.
+// Returns nil if this History is empty.
+func (self *History) Pop() *RunState {
+	if len(*self) == 0 {
+		return nil
+	}
+	result := (*self)[len(*self)-1]
+	*self = (*self)[:len(*self)-1]
+	return &result
+}
+
+// Push appends the given runstate to this History.
+// If this History exceeds its capacity, it drops the oldest runstates.
+func (self *History) Push(runState RunState) {
+	*self = append(*self, runState)
+	if len(*self) > HistoryCapacity {
+		*self = (*self)[len(*self)-HistoryCapacity:]
+	}
+}
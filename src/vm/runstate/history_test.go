@@ -0,0 +1,47 @@
+package runstate_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/vm/runstate"
+	"github.com/shoenig/test/must"
+)
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Pop", func(t *testing.T) {
+		t.Parallel()
+		t.Run("populated history", func(t *testing.T) {
+			t.Parallel()
+			history := runstate.History{}
+			history.Push(runstate.RunState{Command: "hack"}) //nolint:exhaustruct
+			history.Push(runstate.RunState{Command: "sync"}) //nolint:exhaustruct
+			have := history.Pop()
+			must.NotNil(t, have)
+			must.EqOp(t, "sync", have.Command)
+			must.Len(t, 1, history)
+			must.EqOp(t, "hack", history[0].Command)
+		})
+		t.Run("empty history", func(t *testing.T) {
+			t.Parallel()
+			history := runstate.History{}
+			must.Nil(t, history.Pop())
+		})
+	})
+
+	t.Run("Push", func(t *testing.T) {
+		t.Parallel()
+		t.Run("drops the oldest entries beyond the capacity", func(t *testing.T) {
+			t.Parallel()
+			history := runstate.History{}
+			for i := 0; i < runstate.HistoryCapacity+2; i++ {
+				history.Push(runstate.RunState{Command: strconv.Itoa(i)}) //nolint:exhaustruct
+			}
+			must.Len(t, runstate.HistoryCapacity, history)
+			must.EqOp(t, "2", history[0].Command)
+			must.EqOp(t, strconv.Itoa(runstate.HistoryCapacity+1), history[runstate.HistoryCapacity-1].Command)
+		})
+	})
+}
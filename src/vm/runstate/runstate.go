@@ -7,6 +7,7 @@ import (
 
 	"github.com/git-town/git-town/v14/src/git"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/undo/undobranches"
 	"github.com/git-town/git-town/v14/src/undo/undoconfig"
 	"github.com/git-town/git-town/v14/src/vm/opcodes"
 	"github.com/git-town/git-town/v14/src/vm/program"
@@ -26,6 +27,7 @@ type RunState struct {
 	EndBranchesSnapshot      gitdomain.BranchesSnapshot
 	EndConfigSnapshot        undoconfig.ConfigSnapshot
 	EndStashSize             gitdomain.StashSize
+	EndTime                  time.Time       `exhaustruct:"optional"`
 	FinalUndoProgram         program.Program `exhaustruct:"optional"`
 	IsUndo                   bool            `exhaustruct:"optional"` // TODO: remove?
 	RunProgram               program.Program
@@ -37,6 +39,11 @@ func EmptyRunState() RunState {
 	return RunState{} //nolint:exhaustruct
 }
 
+// AffectedBranches provides the names of the branches that the Git Town command described by this runstate has changed.
+func (self *RunState) AffectedBranches() gitdomain.LocalBranchNames {
+	return undobranches.NewBranchSpans(self.BeginBranchesSnapshot, self.EndBranchesSnapshot).BranchNames()
+}
+
 // AddPushBranchAfterCurrentBranchProgram inserts a PushBranch opcode
 // after all the opcodes for the current branch.
 func (self *RunState) AddPushBranchAfterCurrentBranchProgram(backend *git.BackendCommands) error {
@@ -73,6 +80,7 @@ func (self *RunState) IsFinished() bool {
 
 // MarkAsFinished updates the run state to be marked as finished.
 func (self *RunState) MarkAsFinished() {
+	self.EndTime = time.Now()
 	self.UnfinishedDetails = nil
 }
 
@@ -90,6 +98,13 @@ func (self *RunState) MarkAsUnfinished(backend *git.BackendCommands) error {
 	return nil
 }
 
+// RegisterFinalUndoOpcode adds the given opcode to the program
+// that undoes this command after all other undo operations.
+// This method is used as a callback.
+func (self *RunState) RegisterFinalUndoOpcode(opcode shared.Opcode) {
+	self.FinalUndoProgram.Add(opcode)
+}
+
 // RegisterUndoablePerennialCommit stores the given commit on a perennial branch as undoable.
 // This method is used as a callback.
 func (self *RunState) RegisterUndoablePerennialCommit(commit gitdomain.SHA) {
@@ -52,6 +52,7 @@ func TestRunState(t *testing.T) {
 						SyncStatus: gitdomain.SyncStatusLocalOnly,
 					},
 				},
+				Worktrees: gitdomain.Worktrees{},
 			},
 			EndConfigSnapshot:        undoconfig.EmptyConfigSnapshot(),
 			EndStashSize:             1,
@@ -76,7 +77,8 @@ func TestRunState(t *testing.T) {
   ],
   "BeginBranchesSnapshot": {
     "Active": "",
-    "Branches": []
+    "Branches": [],
+    "Worktrees": []
   },
   "BeginConfigSnapshot": {
     "Global": {},
@@ -102,13 +104,15 @@ func TestRunState(t *testing.T) {
         "RemoteSHA": "",
         "SyncStatus": "local only"
       }
-    ]
+    ],
+    "Worktrees": []
   },
   "EndConfigSnapshot": {
     "Global": {},
     "Local": {}
   },
   "EndStashSize": 1,
+  "EndTime": "0001-01-01T00:00:00Z",
   "FinalUndoProgram": [],
   "IsUndo": false,
   "RunProgram": [
@@ -13,6 +13,7 @@ type RunArgs struct {
 	DialogTestInputs                *components.TestInputs
 	Lineage                         configdomain.Lineage
 	PrependOpcodes                  func(...Opcode)
+	RegisterFinalUndoOpcode         func(Opcode)
 	RegisterUndoablePerennialCommit func(gitdomain.SHA)
 	Runner                          *git.ProdRunner
 	UpdateInitialBranchLocalSHA     func(gitdomain.LocalBranchName, gitdomain.SHA) error
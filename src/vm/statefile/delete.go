@@ -14,7 +14,25 @@ func Delete(repoDir gitdomain.RepoRootDir) error {
 	if err != nil {
 		return err
 	}
-	_, err = os.Stat(filename)
+	return deleteFile(filename)
+}
+
+// DeleteAll removes the stored run state, the undo history, and the undone commands from disk.
+func DeleteAll(repoDir gitdomain.RepoRootDir) error {
+	for _, filePath := range []func(gitdomain.RepoRootDir) (string, error){FilePath, HistoryFilePath, RedoFilePath} {
+		filename, err := filePath(repoDir)
+		if err != nil {
+			return err
+		}
+		if err = deleteFile(filename); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteFile(filename string) error {
+	_, err := os.Stat(filename)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil
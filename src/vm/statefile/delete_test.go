@@ -0,0 +1,33 @@
+package statefile_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/runstate"
+	"github.com/git-town/git-town/v14/src/vm/statefile"
+	"github.com/shoenig/test/must"
+)
+
+func TestDeleteAll(t *testing.T) {
+	t.Parallel()
+	repoRoot := gitdomain.NewRepoRootDir("/path/to/git-town-unit-tests-delete-all")
+	must.NoError(t, statefile.Delete(repoRoot))
+	must.NoError(t, statefile.SaveHistory(runstate.History{}, repoRoot))
+	must.NoError(t, statefile.SaveRedoable(runstate.History{}, repoRoot))
+	for _, command := range []string{"hack", "sync", "ship"} {
+		must.NoError(t, statefile.Save(&runstate.RunState{Command: command}, repoRoot)) //nolint:exhaustruct
+	}
+	must.NoError(t, statefile.Rewind(repoRoot))
+	must.NoError(t, statefile.DeleteAll(repoRoot))
+	have, err := statefile.Load(repoRoot)
+	must.NoError(t, err)
+	must.Nil(t, have)
+	history, err := statefile.LoadHistory(repoRoot)
+	must.NoError(t, err)
+	must.Len(t, 0, history)
+	redoable, err := statefile.LoadRedoable(repoRoot)
+	must.NoError(t, err)
+	must.Len(t, 0, redoable)
+	must.NoError(t, statefile.DeleteAll(repoRoot))
+}
@@ -10,11 +10,26 @@ import (
 )
 
 func FilePath(repoDir gitdomain.RepoRootDir) (string, error) {
+	return persistencePath("runstate", repoDir)
+}
+
+// HistoryFilePath provides the path of the file that stores the undo history for the given Git repo.
+func HistoryFilePath(repoDir gitdomain.RepoRootDir) (string, error) {
+	return persistencePath("history", repoDir)
+}
+
+// RedoFilePath provides the path of the file that stores the runstates of undone commands for the given Git repo.
+func RedoFilePath(repoDir gitdomain.RepoRootDir) (string, error) {
+	return persistencePath("redo", repoDir)
+}
+
+// persistencePath provides the path of the file in the given folder that stores data for the given Git repo.
+func persistencePath(folder string, repoDir gitdomain.RepoRootDir) (string, error) {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
 		return "", fmt.Errorf(messages.RunstatePathProblem, err)
 	}
-	persistenceDir := filepath.Join(configDir, "git-town", "runstate")
+	persistenceDir := filepath.Join(configDir, "git-town", folder)
 	filename := SanitizePath(repoDir)
 	return filepath.Join(persistenceDir, filename+".json"), err
 }
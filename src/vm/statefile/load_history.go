@@ -0,0 +1,51 @@
+package statefile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+	"github.com/git-town/git-town/v14/src/vm/runstate"
+)
+
+// LoadHistory loads the undo history for the given Git repo from disk.
+// Returns an empty history if none has been saved yet.
+func LoadHistory(repoDir gitdomain.RepoRootDir) (runstate.History, error) {
+	filename, err := HistoryFilePath(repoDir)
+	if err != nil {
+		return runstate.History{}, err
+	}
+	return loadJournal(filename)
+}
+
+// LoadRedoable loads the runstates of the undone commands for the given Git repo from disk.
+// Returns an empty history if no command has been undone.
+func LoadRedoable(repoDir gitdomain.RepoRootDir) (runstate.History, error) {
+	filename, err := RedoFilePath(repoDir)
+	if err != nil {
+		return runstate.History{}, err
+	}
+	return loadJournal(filename)
+}
+
+func loadJournal(filename string) (runstate.History, error) {
+	_, err := os.Stat(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return runstate.History{}, nil
+		}
+		return runstate.History{}, fmt.Errorf(messages.FileStatProblem, filename, err)
+	}
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return runstate.History{}, fmt.Errorf(messages.FileReadProblem, filename, err)
+	}
+	var history runstate.History
+	err = json.Unmarshal(content, &history)
+	if err != nil {
+		return runstate.History{}, fmt.Errorf(messages.FileContentInvalidJSON, filename, err)
+	}
+	return history, nil
+}
@@ -0,0 +1,40 @@
+package statefile
+
+import (
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+)
+
+// Rewind removes the current run state for the given Git repo
+// and makes the most recent run state in its undo history the current one.
+// This allows undoing the Git Town commands in the undo history one after the other.
+// The removed run state becomes available for redoing.
+func Rewind(repoDir gitdomain.RepoRootDir) error {
+	current, err := Load(repoDir)
+	if err != nil {
+		return err
+	}
+	if current != nil && current.IsFinished() && !current.DryRun {
+		redoable, err := LoadRedoable(repoDir)
+		if err != nil {
+			return err
+		}
+		redoable.Push(*current)
+		err = SaveRedoable(redoable, repoDir)
+		if err != nil {
+			return err
+		}
+	}
+	history, err := LoadHistory(repoDir)
+	if err != nil {
+		return err
+	}
+	previous := history.Pop()
+	if previous == nil {
+		return Delete(repoDir)
+	}
+	err = write(previous, repoDir)
+	if err != nil {
+		return err
+	}
+	return SaveHistory(history, repoDir)
+}
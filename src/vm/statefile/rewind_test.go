@@ -0,0 +1,99 @@
+package statefile_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/vm/runstate"
+	"github.com/git-town/git-town/v14/src/vm/statefile"
+	"github.com/shoenig/test/must"
+)
+
+func TestRewind(t *testing.T) {
+	t.Parallel()
+
+	t.Run("steps back through the runstates of finished commands", func(t *testing.T) {
+		t.Parallel()
+		repoRoot := gitdomain.NewRepoRootDir("/path/to/git-town-unit-tests-rewind")
+		must.NoError(t, statefile.Delete(repoRoot))
+		must.NoError(t, statefile.SaveHistory(runstate.History{}, repoRoot))
+		for _, command := range []string{"hack", "sync", "ship"} {
+			must.NoError(t, statefile.Save(&runstate.RunState{Command: command}, repoRoot)) //nolint:exhaustruct
+		}
+		history, err := statefile.LoadHistory(repoRoot)
+		must.NoError(t, err)
+		must.Len(t, 2, history)
+		for _, want := range []string{"ship", "sync", "hack"} {
+			have, err := statefile.Load(repoRoot)
+			must.NoError(t, err)
+			must.NotNil(t, have)
+			must.EqOp(t, want, have.Command)
+			must.NoError(t, statefile.Rewind(repoRoot))
+		}
+		have, err := statefile.Load(repoRoot)
+		must.NoError(t, err)
+		must.Nil(t, have)
+	})
+
+	t.Run("does not archive unfinished runstates", func(t *testing.T) {
+		t.Parallel()
+		repoRoot := gitdomain.NewRepoRootDir("/path/to/git-town-unit-tests-rewind-unfinished")
+		must.NoError(t, statefile.Delete(repoRoot))
+		must.NoError(t, statefile.SaveHistory(runstate.History{}, repoRoot))
+		unfinished := runstate.RunState{ //nolint:exhaustruct
+			Command:           "sync",
+			UnfinishedDetails: &runstate.UnfinishedRunStateDetails{}, //nolint:exhaustruct
+		}
+		must.NoError(t, statefile.Save(&unfinished, repoRoot))
+		must.NoError(t, statefile.Save(&runstate.RunState{Command: "sync"}, repoRoot)) //nolint:exhaustruct
+		history, err := statefile.LoadHistory(repoRoot)
+		must.NoError(t, err)
+		must.Len(t, 0, history)
+	})
+
+	t.Run("makes rewound runstates redoable until the next command runs", func(t *testing.T) {
+		t.Parallel()
+		repoRoot := gitdomain.NewRepoRootDir("/path/to/git-town-unit-tests-rewind-redo")
+		must.NoError(t, statefile.Delete(repoRoot))
+		must.NoError(t, statefile.SaveHistory(runstate.History{}, repoRoot))
+		must.NoError(t, statefile.SaveRedoable(runstate.History{}, repoRoot))
+		for _, command := range []string{"hack", "sync"} {
+			must.NoError(t, statefile.Save(&runstate.RunState{Command: command}, repoRoot)) //nolint:exhaustruct
+		}
+		must.NoError(t, statefile.Rewind(repoRoot))
+		redoable, err := statefile.LoadRedoable(repoRoot)
+		must.NoError(t, err)
+		must.Len(t, 1, redoable)
+		must.EqOp(t, "sync", redoable[0].Command)
+		must.NoError(t, statefile.Save(&runstate.RunState{Command: "ship"}, repoRoot)) //nolint:exhaustruct
+		redoable, err = statefile.LoadRedoable(repoRoot)
+		must.NoError(t, err)
+		must.Len(t, 0, redoable)
+	})
+
+	t.Run("keeps the undone commands until the redo command finishes", func(t *testing.T) {
+		t.Parallel()
+		repoRoot := gitdomain.NewRepoRootDir("/path/to/git-town-unit-tests-rewind-redo-unfinished")
+		must.NoError(t, statefile.Delete(repoRoot))
+		must.NoError(t, statefile.SaveHistory(runstate.History{}, repoRoot))
+		must.NoError(t, statefile.SaveRedoable(runstate.History{}, repoRoot))
+		for _, command := range []string{"hack", "sync", "ship"} {
+			must.NoError(t, statefile.Save(&runstate.RunState{Command: command}, repoRoot)) //nolint:exhaustruct
+		}
+		must.NoError(t, statefile.Rewind(repoRoot))
+		must.NoError(t, statefile.Rewind(repoRoot))
+		unfinished := runstate.RunState{ //nolint:exhaustruct
+			Command:           "redo",
+			UnfinishedDetails: &runstate.UnfinishedRunStateDetails{}, //nolint:exhaustruct
+		}
+		must.NoError(t, statefile.Save(&unfinished, repoRoot))
+		redoable, err := statefile.LoadRedoable(repoRoot)
+		must.NoError(t, err)
+		must.Len(t, 2, redoable)
+		must.NoError(t, statefile.Save(&runstate.RunState{Command: "redo"}, repoRoot)) //nolint:exhaustruct
+		redoable, err = statefile.LoadRedoable(repoRoot)
+		must.NoError(t, err)
+		must.Len(t, 1, redoable)
+		must.EqOp(t, "ship", redoable[0].Command)
+	})
+}
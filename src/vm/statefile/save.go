@@ -12,7 +12,66 @@ import (
 )
 
 // Save stores the given run state for the given Git repo to disk.
+// If this replaces the run state of a previously finished Git Town command,
+// that run state moves into the undo history.
+// Once a Git Town command has successfully changed the repo, the previously undone commands can no longer be redone.
+// A successful redo command only consumes the undone command it re-applied.
 func Save(runState *runstate.RunState, repoDir gitdomain.RepoRootDir) error {
+	err := archive(repoDir)
+	if err != nil {
+		return err
+	}
+	if runState.IsFinished() && !runState.DryRun {
+		err = updateRedoable(runState.Command, repoDir)
+		if err != nil {
+			return err
+		}
+	}
+	return write(runState, repoDir)
+}
+
+// archive moves the run state of the previously finished Git Town command into the undo history.
+func archive(repoDir gitdomain.RepoRootDir) error {
+	previous, err := Load(repoDir)
+	if err != nil {
+		return err
+	}
+	if previous == nil || !previous.IsFinished() || previous.DryRun {
+		return nil
+	}
+	history, err := LoadHistory(repoDir)
+	if err != nil {
+		return err
+	}
+	history.Push(*previous)
+	return SaveHistory(history, repoDir)
+}
+
+// clearRedoable removes the runstates of the undone commands for the given Git repo.
+func clearRedoable(repoDir gitdomain.RepoRootDir) error {
+	filename, err := RedoFilePath(repoDir)
+	if err != nil {
+		return err
+	}
+	return deleteFile(filename)
+}
+
+// updateRedoable updates the runstates of the undone commands for the given Git repo
+// after the given Git Town command has finished.
+func updateRedoable(command string, repoDir gitdomain.RepoRootDir) error {
+	if command != "redo" {
+		return clearRedoable(repoDir)
+	}
+	redoable, err := LoadRedoable(repoDir)
+	if err != nil {
+		return err
+	}
+	redoable.Pop()
+	return SaveRedoable(redoable, repoDir)
+}
+
+// write stores the given run state as the current run state for the given Git repo.
+func write(runState *runstate.RunState, repoDir gitdomain.RepoRootDir) error {
 	content, err := json.MarshalIndent(runState, "", "  ")
 	if err != nil {
 		return fmt.Errorf(messages.RunstateSerializeProblem, err)
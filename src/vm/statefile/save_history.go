@@ -0,0 +1,46 @@
+package statefile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/git-town/git-town/v14/src/git/gitdomain"
+	"github.com/git-town/git-town/v14/src/messages"
+	"github.com/git-town/git-town/v14/src/vm/runstate"
+)
+
+// SaveHistory stores the given undo history for the given Git repo to disk.
+func SaveHistory(history runstate.History, repoDir gitdomain.RepoRootDir) error {
+	persistencePath, err := HistoryFilePath(repoDir)
+	if err != nil {
+		return err
+	}
+	return saveJournal(history, persistencePath)
+}
+
+// SaveRedoable stores the runstates of the given undone commands for the given Git repo to disk.
+func SaveRedoable(redoable runstate.History, repoDir gitdomain.RepoRootDir) error {
+	persistencePath, err := RedoFilePath(repoDir)
+	if err != nil {
+		return err
+	}
+	return saveJournal(redoable, persistencePath)
+}
+
+func saveJournal(history runstate.History, persistencePath string) error {
+	content, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf(messages.RunstateSerializeProblem, err)
+	}
+	err = os.MkdirAll(filepath.Dir(persistencePath), 0o700)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(persistencePath, content, 0o600)
+	if err != nil {
+		return fmt.Errorf(messages.FileWriteProblem, persistencePath, err)
+	}
+	return nil
+}
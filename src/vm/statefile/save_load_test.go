@@ -55,6 +55,7 @@ func TestLoadSave(t *testing.T) {
 					Parent: gitdomain.NewLocalBranchName("parent"),
 				},
 				&opcodes.Checkout{Branch: gitdomain.NewLocalBranchName("branch")},
+				&opcodes.CloseProposal{ProposalNumber: 123},
 				&opcodes.CommitOpenChanges{},
 				&opcodes.ConnectorMergeProposal{
 					Branch:          gitdomain.NewLocalBranchName("branch"),
@@ -68,7 +69,13 @@ func TestLoadSave(t *testing.T) {
 					Branch:        gitdomain.NewLocalBranchName("branch"),
 					StartingPoint: gitdomain.NewSHA("123456").Location(),
 				},
-				&opcodes.CreateProposal{Branch: gitdomain.NewLocalBranchName("branch")},
+				&opcodes.CreateProposal{
+					Body:      "body",
+					Branch:    gitdomain.NewLocalBranchName("branch"),
+					Draft:     true,
+					NoBrowser: true,
+					Title:     "title",
+				},
 				&opcodes.CreateRemoteBranch{
 					Branch: gitdomain.NewLocalBranchName("branch"),
 					SHA:    gitdomain.NewSHA("123456"),
@@ -173,7 +180,8 @@ func TestLoadSave(t *testing.T) {
   "AbortProgram": [],
   "BeginBranchesSnapshot": {
     "Active": "",
-    "Branches": []
+    "Branches": [],
+    "Worktrees": []
   },
   "BeginConfigSnapshot": {
     "Global": {},
@@ -184,13 +192,15 @@ func TestLoadSave(t *testing.T) {
   "DryRun": true,
   "EndBranchesSnapshot": {
     "Active": "",
-    "Branches": []
+    "Branches": [],
+    "Worktrees": []
   },
   "EndConfigSnapshot": {
     "Global": {},
     "Local": {}
   },
   "EndStashSize": 1,
+  "EndTime": "0001-01-01T00:00:00Z",
   "FinalUndoProgram": [],
   "IsUndo": true,
   "RunProgram": [
@@ -221,6 +231,12 @@ func TestLoadSave(t *testing.T) {
       },
       "type": "Checkout"
     },
+    {
+      "data": {
+        "ProposalNumber": 123
+      },
+      "type": "CloseProposal"
+    },
     {
       "data": {},
       "type": "CommitOpenChanges"
@@ -251,7 +267,11 @@ func TestLoadSave(t *testing.T) {
     },
     {
       "data": {
-        "Branch": "branch"
+        "Body": "body",
+        "Branch": "branch",
+        "Draft": true,
+        "NoBrowser": true,
+        "Title": "title"
       },
       "type": "CreateProposal"
     },
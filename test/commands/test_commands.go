@@ -8,6 +8,7 @@ import (
 
 	"github.com/git-town/git-town/v14/src/config/configdomain"
 	"github.com/git-town/git-town/v14/src/config/gitconfig"
+	"github.com/git-town/git-town/v14/src/config/lineageshare"
 	prodgit "github.com/git-town/git-town/v14/src/git"
 	"github.com/git-town/git-town/v14/src/git/gitdomain"
 	"github.com/git-town/git-town/v14/src/gohacks/slice"
@@ -186,6 +187,14 @@ func (self *TestCommands) CreateParkedBranches(names ...gitdomain.LocalBranchNam
 	asserts.NoError(self.Config.AddToParkedBranches(names...))
 }
 
+// CreatePrototypeBranches creates prototype branches with the given names in this repository.
+func (self *TestCommands) CreatePrototypeBranches(names ...gitdomain.LocalBranchName) {
+	for _, name := range names {
+		self.CreateFeatureBranch(name)
+	}
+	asserts.NoError(self.Config.AddToPrototypeBranches(names...))
+}
+
 // CreatePerennialBranches creates perennial branches with the given names in this repository.
 func (self *TestCommands) CreatePerennialBranches(names ...gitdomain.LocalBranchName) {
 	main := gitdomain.NewLocalBranchName("main")
@@ -280,6 +289,11 @@ func (self *TestCommands) FilesInCommit(sha gitdomain.SHA) []string {
 	return strings.Split(output, "\n")
 }
 
+// FullSHAsForCommit provides the full SHAs of the commits with the given name.
+func (self *TestCommands) FullSHAsForCommit(name string) gitdomain.SHAs {
+	return self.shasForCommit(name, "%H")
+}
+
 func (self *TestCommands) GlobalGitConfig(name gitconfig.Key) *string {
 	output, err := self.Query("git", "config", "--global", "--get", name.String())
 	if err != nil {
@@ -405,7 +419,12 @@ func (self *TestCommands) RemoveUnnecessaryFiles() {
 
 // SHAForCommit provides the SHA for the commit with the given name.
 func (self *TestCommands) SHAsForCommit(name string) gitdomain.SHAs {
-	output := self.MustQuery("git", "reflog", "--format=%h %s")
+	return self.shasForCommit(name, "%h")
+}
+
+// shasForCommit provides the SHAs in the given format for the commits with the given name.
+func (self *TestCommands) shasForCommit(name, shaFormat string) gitdomain.SHAs {
+	output := self.MustQuery("git", "reflog", "--format="+shaFormat+" %s")
 	if output == "" {
 		panic(fmt.Sprintf("cannot find the SHA of commit %q", name))
 	}
@@ -420,6 +439,32 @@ func (self *TestCommands) SHAsForCommit(name string) gitdomain.SHAs {
 	return shasWithMessage
 }
 
+// SetSharedLineage stores the given lineage as the lineage shared through this repo.
+func (self *TestCommands) SetSharedLineage(lineage configdomain.Lineage) {
+	content, err := lineageshare.Render(lineage)
+	asserts.NoError(err)
+	sha, err := self.WriteBlob(content)
+	asserts.NoError(err)
+	self.MustRun("git", "update-ref", gitdomain.SharedLineageRef, sha.String())
+}
+
+// SharedLineageTable provides the lineage shared through this repo as a DataTable.
+func (self *TestCommands) SharedLineageTable() datatable.DataTable {
+	result := datatable.DataTable{}
+	result.AddRow("BRANCH", "PARENT")
+	output, err := self.QueryTrim("git", "rev-parse", "-q", "--verify", gitdomain.SharedLineageRef)
+	if err != nil {
+		return result
+	}
+	lineage, err := lineageshare.Read(self, gitdomain.NewSHA(output))
+	asserts.NoError(err)
+	for _, branchName := range lineage.BranchNames() {
+		result.AddRow(branchName.String(), lineage[branchName].String())
+	}
+	result.Sort()
+	return result
+}
+
 // SetColorUI configures whether Git output contains color codes.
 func (self *TestCommands) SetColorUI(value string) error {
 	return self.Run("git", "config", "color.ui", value)
@@ -233,6 +233,21 @@ func TestTestCommands(t *testing.T) {
 		must.Eq(t, []string{"f1.txt", "f2.txt"}, fileNames)
 	})
 
+	t.Run("FullSHAsForCommit", func(t *testing.T) {
+		t.Parallel()
+		repo := testruntime.Create(t)
+		repo.CreateCommit(git.Commit{
+			Branch:      gitdomain.NewLocalBranchName("initial"),
+			FileContent: "bar",
+			FileName:    "foo",
+			Message:     "commit",
+		})
+		shas := repo.FullSHAsForCommit("commit")
+		must.EqOp(t, 1, len(shas))
+		sha := shas.First()
+		must.EqOp(t, 40, len(sha))
+	})
+
 	t.Run("HasBranchesOutOfSync", func(t *testing.T) {
 		t.Run("branches are in sync", func(t *testing.T) {
 			t.Parallel()
@@ -139,6 +139,21 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^a (local )?prototype branch "([^"]+)"$`, func(localStr, branchText string) error {
+		branch := gitdomain.NewLocalBranchName(branchText)
+		state.fixture.DevRepo.CreatePrototypeBranches(branch)
+		if localStr == "" {
+			state.fixture.DevRepo.PushBranchToRemote(branch, gitdomain.RemoteOrigin)
+		}
+		return nil
+	})
+
+	suite.Step(`^a local prototype branch "([^"]+)" as a child of "([^"]+)"$`, func(branchText, parentBranch string) error {
+		branch := gitdomain.NewLocalBranchName(branchText)
+		state.fixture.DevRepo.CreateChildFeatureBranch(branch, gitdomain.NewLocalBranchName(parentBranch))
+		return state.fixture.DevRepo.Config.AddToPrototypeBranches(branch)
+	})
+
 	suite.Step(`^a perennial branch "([^"]+)"$`, func(branchText string) error {
 		branch := gitdomain.NewLocalBranchName(branchText)
 		state.fixture.DevRepo.CreatePerennialBranches(branch)
@@ -175,6 +190,11 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^a file "([^"]+)" with content:$`, func(name string, content *messages.PickleStepArgument_PickleDocString) error {
+		state.fixture.DevRepo.CreateFile(name, content.Content)
+		return nil
+	})
+
 	suite.Step(`^an uncommitted file$`, func() error {
 		state.uncommittedFileName = "uncommitted file"
 		state.uncommittedContent = "uncommitted content"
@@ -194,6 +214,16 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^an uncommitted file in the other worktree$`, func() error {
+		state.uncommittedFileName = "uncommitted file"
+		state.uncommittedContent = "uncommitted content"
+		state.fixture.SecondWorktree.CreateFile(
+			state.uncommittedFileName,
+			state.uncommittedContent,
+		)
+		return nil
+	})
+
 	suite.Step(`^an uncommitted file with name "([^"]+)" and content "([^"]+)"$`, func(name, content string) error {
 		state.uncommittedFileName = name
 		state.uncommittedContent = content
@@ -206,11 +236,53 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^branch "([^"]+)" has the sync strategy "([^"]+)"$`, func(name, strategy string) error {
+		key := gitconfig.NewSyncStrategyKey(gitdomain.NewLocalBranchName(name))
+		return state.fixture.DevRepo.Config.GitConfig.SetLocalConfigValue(key, strategy)
+	})
+
+	suite.Step(`^branch "([^"]+)" (?:now|still) has the sync strategy "([^"]+)"$`, func(name, want string) error {
+		key := gitconfig.NewSyncStrategyKey(gitdomain.NewLocalBranchName(name))
+		have := state.fixture.DevRepo.TestCommands.LocalGitConfig(key)
+		if have == nil {
+			return fmt.Errorf("branch %q has no sync strategy, expected %q", name, want)
+		}
+		if *have != want {
+			return fmt.Errorf("expected branch %q to have sync strategy %q but it has %q", name, want, *have)
+		}
+		return nil
+	})
+
+	suite.Step(`^branch "([^"]+)" (?:now|still) has no sync strategy$`, func(name string) error {
+		key := gitconfig.NewSyncStrategyKey(gitdomain.NewLocalBranchName(name))
+		have := state.fixture.DevRepo.TestCommands.LocalGitConfig(key)
+		if have != nil {
+			return fmt.Errorf("branch %q should have no sync strategy but has %q", name, *have)
+		}
+		return nil
+	})
+
 	suite.Step(`^branch "([^"]+)" is active in another worktree`, func(branch string) error {
 		state.fixture.AddSecondWorktree(gitdomain.NewLocalBranchName(branch))
 		return nil
 	})
 
+	suite.Step(`^branch "([^"]+)" is (?:now|still) checked out in the worktree "([^"]+)"$`, func(branch, path string) error {
+		worktrees, err := state.fixture.DevRepo.Backend.Worktrees()
+		if err != nil {
+			return err
+		}
+		worktree := worktrees.FindByBranch(gitdomain.NewLocalBranchName(branch))
+		if worktree == nil {
+			return fmt.Errorf("branch %q is not checked out in any worktree", branch)
+		}
+		want := filepath.Join(state.fixture.Dir, path)
+		if worktree.Path.String() != want {
+			return fmt.Errorf("expected branch %q to be checked out in the worktree at %q but it is checked out at %q", branch, want, worktree.Path)
+		}
+		return nil
+	})
+
 	suite.Step(`^branch "([^"]+)" is (?:now|still) a contribution branch`, func(name string) error {
 		branch := gitdomain.NewLocalBranchName(name)
 		if !state.fixture.DevRepo.Config.FullConfig.IsContributionBranch(branch) {
@@ -267,11 +339,26 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^branch "([^"]+)" is (?:now|still) a prototype branch`, func(name string) error {
+		branch := gitdomain.NewLocalBranchName(name)
+		if !state.fixture.DevRepo.Config.FullConfig.IsPrototypeBranch(branch) {
+			return fmt.Errorf(
+				"branch %q isn't a prototype branch as expected.\nPrototype branches: %s",
+				branch,
+				strings.Join(state.fixture.DevRepo.Config.FullConfig.PrototypeBranches.Strings(), ", "),
+			)
+		}
+		return nil
+	})
+
 	suite.Step(`^branch "([^"]+)" is now a feature branch`, func(name string) error {
 		branch := gitdomain.NewLocalBranchName(name)
 		if state.fixture.DevRepo.Config.FullConfig.IsParkedBranch(branch) {
 			return fmt.Errorf("branch %q is parked", branch)
 		}
+		if state.fixture.DevRepo.Config.FullConfig.IsPrototypeBranch(branch) {
+			return fmt.Errorf("branch %q is a prototype branch", branch)
+		}
 		if state.fixture.DevRepo.Config.FullConfig.IsObservedBranch(branch) {
 			return fmt.Errorf("branch %q is observed", branch)
 		}
@@ -301,6 +388,16 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^file "([^"]*)" now has content:$`, func(file string, expected *messages.PickleStepArgument_PickleDocString) error {
+		have := strings.TrimSpace(state.fixture.DevRepo.FileContent(file))
+		want := strings.TrimSpace(expected.Content)
+		if have != want {
+			fmt.Println(cmp.Diff(want, have))
+			return errors.New("mismatching file content")
+		}
+		return nil
+	})
+
 	suite.Step(`^file "([^"]*)" (?:now|still) has content "([^"]*)"$`, func(file, expectedContent string) error {
 		actualContent := state.fixture.DevRepo.FileContent(file)
 		if expectedContent != actualContent {
@@ -331,6 +428,12 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return state.fixture.DevRepo.SetColorUI(value)
 	})
 
+	suite.Step(`^Git Town parent setting for branch "([^"]*)" doesn't exist$`, func(branch string) error {
+		branchName := gitdomain.NewLocalBranchName(branch)
+		configKey := gitconfig.NewParentKey(branchName)
+		return state.fixture.DevRepo.Config.GitConfig.RemoveLocalConfigValue(configKey)
+	})
+
 	suite.Step(`^Git Town parent setting for branch "([^"]*)" is "([^"]*)"$`, func(branch, value string) error {
 		branchName := gitdomain.NewLocalBranchName(branch)
 		configKey := gitconfig.NewParentKey(branchName)
@@ -602,6 +705,19 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^I run "([^"]*)" and enter these commit messages:$`, func(cmd string, input *messages.PickleStepArgument_PickleTable) error {
+		state.CaptureState()
+		updateInitialSHAs(state)
+		commitMessages := make([]string, len(input.Rows))
+		for r, row := range input.Rows {
+			commitMessages[r] = row.Cells[0].Value
+		}
+		state.fixture.DevRepo.MockCommitMessages(commitMessages)
+		state.runOutput, state.runExitCode = state.fixture.DevRepo.MustQueryStringCode(cmd)
+		state.fixture.DevRepo.Config.Reload()
+		return nil
+	})
+
 	suite.Step(`^I run "([^"]*)" in the other worktree and enter "([^"]*)" for the commit message$`, func(cmd, message string) error {
 		state.CaptureState()
 		updateInitialSHAs(state)
@@ -822,6 +938,24 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^local Git Town setting "azure-devops-token" is now "([^"]*)"$`, func(wantStr string) error {
+		have := state.fixture.DevRepo.Config.LocalGitConfig.AzureDevOpsToken
+		want := configdomain.AzureDevOpsToken(wantStr)
+		if *have != want {
+			return fmt.Errorf(`expected local setting "azure-devops-token" to be %q, but was %q`, want, have)
+		}
+		return nil
+	})
+
+	suite.Step(`^local Git Town setting "bitbucket-token" is now "([^"]*)"$`, func(wantStr string) error {
+		have := state.fixture.DevRepo.Config.LocalGitConfig.BitbucketToken
+		want := configdomain.BitbucketToken(wantStr)
+		if *have != want {
+			return fmt.Errorf(`expected local setting "bitbucket-token" to be %q, but was %q`, want, have)
+		}
+		return nil
+	})
+
 	suite.Step(`^local Git Town setting "gitea-token" is now "([^"]*)"$`, func(wantStr string) error {
 		have := state.fixture.DevRepo.Config.LocalGitConfig.GiteaToken
 		want := configdomain.GiteaToken(wantStr)
@@ -1126,6 +1260,26 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^origin (?:now|still) shares this lineage$`, func(input *messages.PickleStepArgument_PickleTable) error {
+		table := state.fixture.OriginRepo.SharedLineageTable()
+		diff, errCount := table.EqualGherkin(input)
+		if errCount > 0 {
+			fmt.Printf("\nERROR! Found %d differences in the shared lineage\n\n", errCount)
+			fmt.Println(diff)
+			return errors.New("mismatching shared lineage found, see the diff above")
+		}
+		return nil
+	})
+
+	suite.Step(`^origin shares this lineage$`, func(input *messages.PickleStepArgument_PickleTable) error {
+		lineage := configdomain.Lineage{}
+		for _, row := range input.Rows[1:] {
+			lineage[gitdomain.NewLocalBranchName(row.Cells[0].Value)] = gitdomain.NewLocalBranchName(row.Cells[1].Value)
+		}
+		state.fixture.OriginRepo.SetSharedLineage(lineage)
+		return nil
+	})
+
 	suite.Step(`^origin ships the "([^"]*)" branch$`, func(branch string) error {
 		state.fixture.OriginRepo.CheckoutBranch(gitdomain.NewLocalBranchName("main"))
 		err := state.fixture.OriginRepo.MergeBranch(gitdomain.NewLocalBranchName(branch))
@@ -1280,7 +1434,7 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
-	suite.Step(`^the current branch is an? (local )?(feature|perennial|parked|contribution|observed) branch "([^"]*)"$`, func(localStr, branchType, branchName string) error {
+	suite.Step(`^the current branch is an? (local )?(feature|perennial|parked|contribution|observed|prototype) branch "([^"]*)"$`, func(localStr, branchType, branchName string) error {
 		branch := gitdomain.NewLocalBranchName(branchName)
 		isLocal := localStr != ""
 		switch configdomain.NewBranchType(branchType) {
@@ -1294,6 +1448,8 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 			state.fixture.DevRepo.CreateContributionBranches(branch)
 		case configdomain.BranchTypeObservedBranch:
 			state.fixture.DevRepo.CreateObservedBranches(branch)
+		case configdomain.BranchTypePrototypeBranch:
+			state.fixture.DevRepo.CreatePrototypeBranches(branch)
 		case configdomain.BranchTypeMainBranch:
 		default:
 			panic(fmt.Sprintf("unknown branch type: %q", branchType))
@@ -1318,7 +1474,7 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
-	suite.Step(`^(contribution|feature|observed|parked) branch "([^"]*)" with these commits$`, func(branchTypeName, name string, table *messages.PickleStepArgument_PickleTable) error {
+	suite.Step(`^(contribution|feature|observed|parked|prototype) branch "([^"]*)" with these commits$`, func(branchTypeName, name string, table *messages.PickleStepArgument_PickleTable) error {
 		branchName := gitdomain.NewLocalBranchName(name)
 		switch configdomain.NewBranchType(branchTypeName) {
 		case configdomain.BranchTypeContributionBranch:
@@ -1329,6 +1485,8 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 			state.fixture.DevRepo.CreateObservedBranches(branchName)
 		case configdomain.BranchTypeParkedBranch:
 			state.fixture.DevRepo.CreateParkedBranches(branchName)
+		case configdomain.BranchTypePrototypeBranch:
+			state.fixture.DevRepo.CreatePrototypeBranches(branchName)
 		case configdomain.BranchTypeMainBranch, configdomain.BranchTypePerennialBranch:
 		}
 		state.fixture.DevRepo.CheckoutBranch(branchName)
@@ -1545,6 +1703,10 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return state.fixture.DevRepo.Config.SetParkedBranches(gitdomain.NewLocalBranchNames(branch1, branch2))
 	})
 
+	suite.Step(`^the prototype branches "([^"]+)" and "([^"]+)"$`, func(branch1, branch2 string) error {
+		return state.fixture.DevRepo.Config.SetPrototypeBranches(gitdomain.NewLocalBranchNames(branch1, branch2))
+	})
+
 	suite.Step(`^the perennial branches are "([^"]+)"$`, func(name string) error {
 		return state.fixture.DevRepo.Config.SetPerennialBranches(gitdomain.NewLocalBranchNames(name))
 	})
@@ -1611,6 +1773,14 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^there are (?:now|still) no prototype branches$`, func() error {
+		branches := state.fixture.DevRepo.Config.LocalGitConfig.PrototypeBranches
+		if branches != nil && len(*branches) > 0 {
+			return fmt.Errorf("expected no prototype branches, got %q", branches)
+		}
+		return nil
+	})
+
 	suite.Step(`^there are (?:now|still) no perennial branches$`, func() error {
 		branches := state.fixture.DevRepo.Config.LocalGitConfig.PerennialBranches
 		if branches != nil && len(*branches) > 0 {
@@ -1678,6 +1848,34 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^the uncommitted file still exists in the other worktree$`, func() error {
+		hasFile := state.fixture.SecondWorktree.HasFile(
+			state.uncommittedFileName,
+			state.uncommittedContent,
+		)
+		if hasFile != "" {
+			return errors.New(hasFile)
+		}
+		return nil
+	})
+
+	suite.Step(`^the worktree "([^"]+)" (?:doesn't exist|no longer exists)$`, func(path string) error {
+		worktrees, err := state.fixture.DevRepo.Backend.Worktrees()
+		if err != nil {
+			return err
+		}
+		fullPath := filepath.Join(state.fixture.Dir, path)
+		for _, worktree := range worktrees {
+			if worktree.Path.String() == fullPath {
+				return fmt.Errorf("expected no worktree at %q but it exists with branch %q", fullPath, worktree.Branch)
+			}
+		}
+		if _, err := os.Stat(fullPath); err == nil {
+			return fmt.Errorf("expected no worktree at %q but this directory exists", fullPath)
+		}
+		return nil
+	})
+
 	suite.Step(`^these branches exist now$`, func(input *messages.PickleStepArgument_PickleTable) error {
 		currentBranches := state.fixture.Branches()
 		// fmt.Printf("NOW:\n%s\n", currentBranches.String())
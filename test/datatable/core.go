@@ -3,5 +3,6 @@ package datatable
 import "github.com/git-town/git-town/v14/src/git/gitdomain"
 
 type runner interface {
+	FullSHAsForCommit(name string) gitdomain.SHAs
 	SHAsForCommit(name string) gitdomain.SHAs
 }
@@ -77,6 +77,11 @@ func (self *DataTable) Expand(localRepo runner, remoteRepo runner, worktreeRepo
 				templateOnce.Do(func() { templateRE = regexp.MustCompile(`\{\{.*?\}\}`) })
 				match := templateRE.FindString(cell)
 				switch {
+				case strings.HasPrefix(match, "{{ full-sha "):
+					commitName := match[13 : len(match)-4]
+					shas := localRepo.FullSHAsForCommit(commitName)
+					sha := shas.First()
+					cell = strings.Replace(cell, match, sha.String(), 1)
 				case strings.HasPrefix(match, "{{ sha "):
 					commitName := match[8 : len(match)-4]
 					shas := localRepo.SHAsForCommit(commitName)
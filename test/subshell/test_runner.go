@@ -72,6 +72,20 @@ func (self *TestRunner) MockCommitMessage(message string) {
 	self.createMockBinary(self.gitEditor, fmt.Sprintf("#!/usr/bin/env bash\n\necho %q > $1", message))
 }
 
+// MockCommitMessages sets up this runner with an editor that enters the given commit messages one after the other,
+// one message each time Git opens the editor.
+func (self *TestRunner) MockCommitMessages(messages []string) {
+	self.gitEditor = "git_editor"
+	quoted := make([]string, len(messages))
+	for m, message := range messages {
+		quoted[m] = fmt.Sprintf("%q", message)
+	}
+	counterPath := filepath.Join(self.BinDir, "git_editor_count")
+	_ = os.Remove(counterPath)
+	content := fmt.Sprintf("#!/usr/bin/env bash\n\nmessages=(%s)\ncount=$(cat %q 2>/dev/null || echo 0)\necho \"${messages[$count]}\" > $1\necho $((count + 1)) > %q", strings.Join(quoted, " "), counterPath, counterPath)
+	self.createMockBinary(self.gitEditor, content)
+}
+
 // MockGit pretends that this repo has Git in the given version installed.
 func (self *TestRunner) MockGit(version string) {
 	if runtime.GOOS == "windows" {